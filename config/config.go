@@ -1,7 +1,3 @@
-// Code generated by ADL CLI v0.23.11. DO NOT EDIT.
-// This file was automatically generated from an ADL (Agent Definition Language) specification.
-// Manual changes to this file may be overwritten during regeneration.
-
 package config
 
 import (
@@ -15,4 +11,7 @@ type Config struct {
 
 	// A2A configuration (all A2A_ prefixed vars)
 	A2A serverConfig.Config `env:",prefix=A2A_"`
+
+	// Mock LLM behavior (all MOCK_ prefixed vars)
+	Mock MockConfig `env:",prefix=MOCK_"`
 }
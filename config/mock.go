@@ -0,0 +1,171 @@
+package config
+
+import "time"
+
+// MockConfig holds settings that shape the behavior of the mock LLM client
+type MockConfig struct {
+	// Mode selects the LLM client: mock (scripted), record (proxy a real provider
+	// and capture fixtures) or replay (serve captured fixtures)
+	Mode string `env:"MODE,default=mock"`
+
+	// ScenariosPath points to a YAML file with scripted conversation scenarios
+	ScenariosPath string `env:"SCENARIOS_PATH"`
+
+	// PersonasPath points to a YAML file with further mock agents served by the same process
+	PersonasPath string `env:"PERSONAS_PATH"`
+
+	// ValidatorsPath points to a YAML file with custom regex validation types for the validate skill
+	ValidatorsPath string `env:"VALIDATORS_PATH"`
+
+	// FixturesDir is where record mode writes fixtures and replay mode reads them
+	FixturesDir string `env:"FIXTURES_DIR,default=./fixtures"`
+
+	// ReplayFallback answers requests without a recorded fixture with the mock client instead of failing
+	ReplayFallback bool `env:"REPLAY_FALLBACK,default=false"`
+
+	// Seed makes IDs, UUIDs, random data and timestamps reproducible (0 = unseeded)
+	Seed int64 `env:"SEED,default=0"`
+
+	// ClockStart fixes the mock clock to start at this RFC 3339 time; seeded runs
+	// start at a default epoch when unset
+	ClockStart time.Time `env:"CLOCK_START"`
+
+	// ClockStep is how far the fixed clock advances on every reading
+	ClockStep time.Duration `env:"CLOCK_STEP,default=1s"`
+
+	// ArgsFromMessage fills free-text parameters of synthesized tool calls with the user message
+	ArgsFromMessage bool `env:"ARGS_FROM_MESSAGE,default=true"`
+
+	// Clarify asks for missing input through the input_required tool when a request like
+	// "validate this" names a tool but not what to use it on
+	Clarify bool `env:"CLARIFY,default=true"`
+
+	// ToolErrorPolicy is how the mock reacts to failed tool results: fail, retry, explain or auto
+	ToolErrorPolicy string `env:"TOOL_ERROR_POLICY,default=fail"`
+
+	// ToolErrorActions overrides the policy per error code, e.g. timeout:retry,invalid_arguments:explain
+	ToolErrorActions map[string]string `env:"TOOL_ERROR_ACTIONS"`
+
+	// ToolErrorRetries bounds retries of one tool per user message before the failure is explained
+	ToolErrorRetries int `env:"TOOL_ERROR_RETRIES,default=2"`
+
+	// Tokenizer is the approximation used for token usage: chars (4 bytes per token) or words
+	Tokenizer string `env:"TOKENIZER,default=chars"`
+
+	// ContextWindow fails requests whose prompt exceeds this many tokens (0 = unlimited)
+	ContextWindow int `env:"CONTEXT_WINDOW,default=0"`
+
+	// StreamChunking splits streamed text and tool call arguments: none, word, rune, bytes or random
+	StreamChunking string `env:"STREAM_CHUNKING,default=none"`
+
+	// StreamChunkSize is the chunk length in bytes for bytes chunking and the maximum for random chunking
+	StreamChunkSize int `env:"STREAM_CHUNK_SIZE,default=8"`
+
+	// StreamLatency is the pause between streamed chunks
+	StreamLatency time.Duration `env:"STREAM_LATENCY,default=0s"`
+
+	// StreamJitter randomly shifts each pause by up to this much in either direction
+	StreamJitter time.Duration `env:"STREAM_JITTER,default=0s"`
+
+	// FaultRates maps fault types to injection probabilities, e.g. rate_limit:0.1,drop_stream:0.05
+	FaultRates map[string]float64 `env:"FAULT_RATES"`
+
+	// FaultRetryAfter is the retry hint returned with injected rate limit errors
+	FaultRetryAfter time.Duration `env:"FAULT_RETRY_AFTER,default=2s"`
+
+	// FaultMarkers lets a user message select a fault with [[fault:<type>]]
+	FaultMarkers bool `env:"FAULT_MARKERS,default=true"`
+
+	// OutcomeMaxEntries bounds the tasks whose failed, rejected or auth-required
+	// outcome is kept for tasks/get (0 = unlimited)
+	OutcomeMaxEntries int `env:"OUTCOME_MAX_ENTRIES,default=1000"`
+
+	// Admin exposes the runtime control API
+	Admin AdminConfig `env:",prefix=ADMIN_"`
+
+	// OpenAI serves the mock model as an OpenAI-compatible provider
+	OpenAI OpenAIConfig `env:",prefix=OPENAI_"`
+
+	// Journal records LLM calls and skill invocations for inspection
+	Journal JournalConfig `env:",prefix=JOURNAL_"`
+
+	// Cancel controls how skills react to canceled tasks
+	Cancel CancelConfig `env:",prefix=CANCEL_"`
+
+	// Push controls how task updates are delivered to push notification webhooks
+	Push PushConfig `env:",prefix=PUSH_"`
+
+	// Chaos injects faults into A2A requests at the HTTP level
+	Chaos ChaosConfig `env:",prefix=CHAOS_"`
+}
+
+// AdminConfig holds the runtime control API server configuration
+type AdminConfig struct {
+	Enable bool   `env:"ENABLE,default=false"`
+	Host   string `env:"HOST,default=127.0.0.1"`
+	Port   string `env:"PORT,default=8082"`
+}
+
+// OpenAIConfig holds the OpenAI-compatible API server configuration
+type OpenAIConfig struct {
+	Enable bool     `env:"ENABLE,default=false"`
+	Host   string   `env:"HOST,default=0.0.0.0"`
+	Port   string   `env:"PORT,default=8083"`
+	Models []string `env:"MODELS,default=mock-model"`
+}
+
+// JournalConfig holds the request journal configuration
+type JournalConfig struct {
+	Enable     bool `env:"ENABLE,default=true"`
+	MaxEntries int  `env:"MAX_ENTRIES,default=1000"`
+}
+
+// CancelConfig holds the cancellation test mode configuration
+type CancelConfig struct {
+	// Enable makes skills report how they reacted to the cancellation of their task
+	Enable bool `env:"ENABLE,default=false"`
+	// Grace is how long skills ignore cancellation, simulating stuck tools
+	Grace time.Duration `env:"GRACE,default=0s"`
+	// Cleanup is how long skills take to wind down once they see the cancellation
+	Cleanup time.Duration `env:"CLEANUP,default=0s"`
+	// Tools limits the test mode to these skills (empty = all skills)
+	Tools []string `env:"TOOLS"`
+}
+
+// PushConfig holds the push notification delivery configuration
+type PushConfig struct {
+	// Headers are sent with every notification, e.g. X-Api-Key:secret
+	Headers map[string]string `env:"HEADERS"`
+	// Retries is how often a failed delivery is retried
+	Retries int `env:"RETRIES,default=3"`
+	// RetryBackoff is the pause before the first retry, doubled for every further one
+	RetryBackoff time.Duration `env:"RETRY_BACKOFF,default=500ms"`
+	// Timeout bounds one delivery attempt
+	Timeout time.Duration `env:"TIMEOUT,default=5s"`
+	// FailureRate is the probability that a delivery attempt fails without being sent
+	FailureRate float64 `env:"FAILURE_RATE,default=0"`
+	// Sink records notifications posted to the control API
+	Sink PushSinkConfig `env:",prefix=SINK_"`
+}
+
+// PushSinkConfig holds the webhook sink configuration
+type PushSinkConfig struct {
+	Enable     bool `env:"ENABLE,default=false"`
+	MaxEntries int  `env:"MAX_ENTRIES,default=1000"`
+}
+
+// ChaosConfig holds the HTTP fault injection configuration of the A2A endpoint
+type ChaosConfig struct {
+	// Rates maps wire fault types to injection probabilities, e.g. reset:0.1,stall:0.05
+	Rates map[string]float64 `env:"RATES"`
+	// Methods limits the rates to these JSON-RPC methods (empty = all methods)
+	Methods []string `env:"METHODS"`
+	// Header lets a request select a fault with the X-Mock-Chaos header
+	Header bool `env:"HEADER,default=true"`
+	// Latency is the delay added by the latency fault
+	Latency time.Duration `env:"LATENCY,default=2s"`
+	// Status is the HTTP status of the http_error fault
+	Status int `env:"STATUS,default=503"`
+	// RetryAfter is the Retry-After hint of the http_error and rate_limit faults
+	RetryAfter time.Duration `env:"RETRY_AFTER,default=1s"`
+}
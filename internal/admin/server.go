@@ -0,0 +1,533 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	chaos "github.com/inference-gateway/mock-agent/internal/chaos"
+	journal "github.com/inference-gateway/mock-agent/internal/journal"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	push "github.com/inference-gateway/mock-agent/internal/push"
+)
+
+// maxBodySize limits request bodies pushed to the control API
+const maxBodySize = 10 << 20
+
+// Server is the runtime control API that reconfigures the mock LLM client
+// and the wire faults of a running agent
+type Server struct {
+	cfg        *config.AdminConfig
+	client     *mock.MockLLMClient
+	chaos      *chaos.Injector
+	journal    *journal.Journal
+	sink       *push.Sink
+	logger     *zap.Logger
+	httpServer *http.Server
+	// personas are the control APIs of the other agents of the process
+	personas map[string]http.Handler
+}
+
+// NewServer creates the control API server for client and injector. The
+// journal routes are only served when j is not nil, the webhook sink when
+// sink is not nil.
+func NewServer(cfg *config.AdminConfig, client *mock.MockLLMClient, injector *chaos.Injector, j *journal.Journal, sink *push.Sink, logger *zap.Logger) *Server {
+	s := &Server{
+		cfg:      cfg,
+		client:   client,
+		chaos:    injector,
+		journal:  j,
+		sink:     sink,
+		logger:   logger,
+		personas: make(map[string]http.Handler),
+	}
+
+	s.httpServer = &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// AddPersona serves the control API of the persona's mock LLM client and
+// wire faults under /personas/{name}. It must be called before Start.
+func (s *Server) AddPersona(name string, client *mock.MockLLMClient, injector *chaos.Injector) {
+	persona := &Server{cfg: s.cfg, client: client, chaos: injector, logger: s.logger.With(zap.String("persona", name))}
+	s.personas[name] = http.StripPrefix("/personas/"+name, persona.Handler())
+}
+
+// Handler returns the HTTP routes of the control API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /state", s.handleGetState)
+	mux.HandleFunc("PUT /scenarios", s.handlePutScenarios)
+	mux.HandleFunc("DELETE /scenarios", s.handleDeleteScenarios)
+	mux.HandleFunc("POST /responses", s.handlePostResponses)
+	mux.HandleFunc("PUT /faults", s.handlePutFaults)
+	mux.HandleFunc("PUT /stream", s.handlePutStream)
+	mux.HandleFunc("PUT /chaos", s.handlePutChaos)
+	mux.HandleFunc("POST /reset", s.handleReset)
+	if s.journal != nil {
+		mux.HandleFunc("GET /journal", s.handleGetJournal)
+		mux.HandleFunc("GET /journal.jsonl", s.handleExportJournal)
+		mux.HandleFunc("DELETE /journal", s.handleDeleteJournal)
+	}
+	mux.HandleFunc("/personas/{name}/", s.handlePersona)
+	if s.sink != nil {
+		mux.HandleFunc("POST /webhooks", s.handlePostWebhook)
+		mux.HandleFunc("POST /webhooks/", s.handlePostWebhook)
+		mux.HandleFunc("GET /webhooks", s.handleGetWebhooks)
+		mux.HandleFunc("DELETE /webhooks", s.handleDeleteWebhooks)
+	}
+	return mux
+}
+
+// Start serves the control API until Stop is called
+func (s *Server) Start(ctx context.Context) error {
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully shuts the control API down
+func (s *Server) Stop(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+func (s *Server) handleGetState(w http.ResponseWriter, r *http.Request) {
+	settings := s.client.Settings()
+
+	state := stateResponse{
+		Scenarios:       []mock.Scenario{},
+		Stream:          streamFromProfile(settings.Stream),
+		Faults:          faultsFromConfig(settings.Faults),
+		Chaos:           chaosFromConfig(s.chaos.Config()),
+		QueuedResponses: s.client.QueuedResponses(),
+	}
+	if settings.Scenarios != nil {
+		state.Scenarios = settings.Scenarios.Scenarios
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *Server) handlePutScenarios(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scenarios, err := mock.ParseScenarios(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.client.SetScenarios(scenarios)
+	s.logger.Info("scenarios replaced via control API", zap.Int("count", len(scenarios.Scenarios)))
+	writeJSON(w, http.StatusOK, map[string]any{"scenarios": len(scenarios.Scenarios)})
+}
+
+func (s *Server) handleDeleteScenarios(w http.ResponseWriter, r *http.Request) {
+	s.client.SetScenarios(nil)
+	s.logger.Info("scenarios cleared via control API")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handlePostResponses(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Responses []mock.ScenarioTurn `json:"responses"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.client.QueueResponses(req.Responses...); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	queued := s.client.QueuedResponses()
+	s.logger.Info("responses queued via control API", zap.Int("added", len(req.Responses)), zap.Int("queued", queued))
+	writeJSON(w, http.StatusOK, map[string]any{"queued_responses": queued})
+}
+
+func (s *Server) handlePutFaults(w http.ResponseWriter, r *http.Request) {
+	var req faultsBody
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	faults, err := req.config(s.client.Settings().Faults)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.client.SetFaults(faults); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.logger.Info("fault injection updated via control API", zap.Any("rates", faults.Rates), zap.Bool("markers", faults.Markers))
+	writeJSON(w, http.StatusOK, faultsFromConfig(faults))
+}
+
+func (s *Server) handlePutStream(w http.ResponseWriter, r *http.Request) {
+	var req streamBody
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	profile, err := req.profile()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.client.SetStreamProfile(profile); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.logger.Info("stream profile updated via control API",
+		zap.String("chunking", profile.Chunking),
+		zap.Duration("latency", profile.Latency))
+	writeJSON(w, http.StatusOK, streamFromProfile(profile))
+}
+
+func (s *Server) handlePutChaos(w http.ResponseWriter, r *http.Request) {
+	var req chaosBody
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cfg, err := req.config(s.chaos.Config())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.chaos.Set(cfg); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.logger.Info("chaos faults updated via control API", zap.Any("rates", cfg.Rates), zap.Strings("methods", cfg.Methods))
+	writeJSON(w, http.StatusOK, chaosFromConfig(cfg))
+}
+
+func (s *Server) handleReset(w http.ResponseWriter, r *http.Request) {
+	s.client.Reset()
+	s.chaos.Reset()
+	if s.journal != nil {
+		s.journal.Reset()
+	}
+	if s.sink != nil {
+		s.sink.Reset()
+	}
+	s.logger.Info("mock state reset via control API")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetJournal(w http.ResponseWriter, r *http.Request) {
+	filter, err := journalFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"entries": s.journal.Entries(filter)})
+}
+
+func (s *Server) handleExportJournal(w http.ResponseWriter, r *http.Request) {
+	filter, err := journalFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	if err := s.journal.WriteJSONL(w, filter); err != nil {
+		s.logger.Warn("failed to export journal", zap.Error(err))
+	}
+}
+
+func (s *Server) handleDeleteJournal(w http.ResponseWriter, r *http.Request) {
+	s.journal.Reset()
+	s.logger.Info("journal cleared via control API")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handlePersona(w http.ResponseWriter, r *http.Request) {
+	persona, ok := s.personas[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown persona %q", r.PathValue("name")))
+		return
+	}
+	persona.ServeHTTP(w, r)
+}
+
+// handlePostWebhook records a push notification. The status query
+// parameter makes the sink answer with that HTTP status, e.g. to make the
+// agent retry.
+func (s *Server) handlePostWebhook(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	if value := r.URL.Query().Get("status"); value != "" {
+		code, err := strconv.Atoi(value)
+		if err != nil || code < 200 || code > 599 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q", value))
+			return
+		}
+		status = code
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	n := s.sink.Record(r.URL.Path, r.Header, body)
+	s.logger.Debug("push notification received",
+		zap.Int64("seq", n.Seq),
+		zap.String("path", n.Path),
+		zap.String("task_id", n.TaskID),
+		zap.String("state", string(n.State)),
+		zap.Int("status", status))
+	writeJSON(w, status, map[string]any{"seq": n.Seq})
+}
+
+func (s *Server) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := push.Filter{
+		TaskID: query.Get("task_id"),
+		Path:   query.Get("path"),
+	}
+	if after := query.Get("after_seq"); after != "" {
+		seq, err := strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid after_seq: %w", err))
+			return
+		}
+		filter.AfterSeq = seq
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"notifications": s.sink.Notifications(filter)})
+}
+
+func (s *Server) handleDeleteWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.sink.Reset()
+	s.logger.Info("webhook sink cleared via control API")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// journalFilter reads the kind, task_id, context_id, tool and after_seq query parameters
+func journalFilter(r *http.Request) (journal.Filter, error) {
+	query := r.URL.Query()
+	filter := journal.Filter{
+		Kind:      query.Get("kind"),
+		TaskID:    query.Get("task_id"),
+		ContextID: query.Get("context_id"),
+		Tool:      query.Get("tool"),
+	}
+
+	if after := query.Get("after_seq"); after != "" {
+		seq, err := strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			return journal.Filter{}, fmt.Errorf("invalid after_seq: %w", err)
+		}
+		filter.AfterSeq = seq
+	}
+
+	return filter, nil
+}
+
+type stateResponse struct {
+	Scenarios       []mock.Scenario `json:"scenarios"`
+	Stream          streamBody      `json:"stream"`
+	Faults          faultsBody      `json:"faults"`
+	Chaos           chaosBody       `json:"chaos"`
+	QueuedResponses int             `json:"queued_responses"`
+}
+
+// streamBody is the wire form of mock.StreamProfile with durations as strings like "250ms"
+type streamBody struct {
+	Chunking  string `json:"chunking"`
+	ChunkSize int    `json:"chunk_size"`
+	Latency   string `json:"latency"`
+	Jitter    string `json:"jitter"`
+}
+
+func streamFromProfile(p mock.StreamProfile) streamBody {
+	return streamBody{
+		Chunking:  p.Chunking,
+		ChunkSize: p.ChunkSize,
+		Latency:   p.Latency.String(),
+		Jitter:    p.Jitter.String(),
+	}
+}
+
+func (b streamBody) profile() (mock.StreamProfile, error) {
+	latency, err := parseDuration(b.Latency)
+	if err != nil {
+		return mock.StreamProfile{}, fmt.Errorf("invalid latency: %w", err)
+	}
+
+	jitter, err := parseDuration(b.Jitter)
+	if err != nil {
+		return mock.StreamProfile{}, fmt.Errorf("invalid jitter: %w", err)
+	}
+
+	return mock.StreamProfile{
+		Chunking:  b.Chunking,
+		ChunkSize: b.ChunkSize,
+		Latency:   latency,
+		Jitter:    jitter,
+	}, nil
+}
+
+// faultsBody is the wire form of mock.FaultConfig with durations as strings.
+// Every field is a pointer so a body without it keeps the current setting.
+type faultsBody struct {
+	Rates      *map[string]float64 `json:"rates"`
+	RetryAfter *string             `json:"retry_after"`
+	Markers    *bool               `json:"markers"`
+}
+
+func faultsFromConfig(c mock.FaultConfig) faultsBody {
+	retryAfter := c.RetryAfter.String()
+	return faultsBody{
+		Rates:      &c.Rates,
+		RetryAfter: &retryAfter,
+		Markers:    &c.Markers,
+	}
+}
+
+// config is the fault configuration of the body, with the settings it
+// leaves out taken from current
+func (b faultsBody) config(current mock.FaultConfig) (mock.FaultConfig, error) {
+	faults := current
+	if b.Rates != nil {
+		faults.Rates = *b.Rates
+	}
+	if b.RetryAfter != nil {
+		retryAfter, err := parseDuration(*b.RetryAfter)
+		if err != nil {
+			return mock.FaultConfig{}, fmt.Errorf("invalid retry_after: %w", err)
+		}
+		faults.RetryAfter = retryAfter
+	}
+	if b.Markers != nil {
+		faults.Markers = *b.Markers
+	}
+	return faults, nil
+}
+
+// chaosBody is the wire form of chaos.Config with durations as strings.
+// Every field is a pointer so a body without it keeps the current setting.
+type chaosBody struct {
+	Rates      *map[string]float64 `json:"rates"`
+	Methods    *[]string           `json:"methods"`
+	Header     *bool               `json:"header"`
+	Latency    *string             `json:"latency"`
+	Status     *int                `json:"status"`
+	RetryAfter *string             `json:"retry_after"`
+}
+
+func chaosFromConfig(c chaos.Config) chaosBody {
+	latency, retryAfter := c.Latency.String(), c.RetryAfter.String()
+	return chaosBody{
+		Rates:      &c.Rates,
+		Methods:    &c.Methods,
+		Header:     &c.Header,
+		Latency:    &latency,
+		Status:     &c.Status,
+		RetryAfter: &retryAfter,
+	}
+}
+
+// config is the chaos configuration of the body, with the settings it
+// leaves out taken from current
+func (b chaosBody) config(current chaos.Config) (chaos.Config, error) {
+	cfg := current
+	if b.Rates != nil {
+		cfg.Rates = *b.Rates
+	}
+	if b.Methods != nil {
+		cfg.Methods = *b.Methods
+	}
+	if b.Header != nil {
+		cfg.Header = *b.Header
+	}
+	if b.Latency != nil {
+		latency, err := parseDuration(*b.Latency)
+		if err != nil {
+			return chaos.Config{}, fmt.Errorf("invalid latency: %w", err)
+		}
+		cfg.Latency = latency
+	}
+	if b.Status != nil {
+		cfg.Status = *b.Status
+		if cfg.Status == 0 {
+			cfg.Status = http.StatusServiceUnavailable
+		}
+	}
+	if b.RetryAfter != nil {
+		retryAfter, err := parseDuration(*b.RetryAfter)
+		if err != nil {
+			return chaos.Config{}, fmt.Errorf("invalid retry_after: %w", err)
+		}
+		cfg.RetryAfter = retryAfter
+	}
+	return cfg, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]any{"error": err.Error()})
+}
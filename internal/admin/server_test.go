@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	chaos "github.com/inference-gateway/mock-agent/internal/chaos"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+)
+
+func put(t *testing.T, h http.Handler, path, body string) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, path, strings.NewReader(body)))
+	return rec.Code
+}
+
+func TestPutFaults(t *testing.T) {
+	current := mock.FaultConfig{
+		Rates:      map[string]float64{"rate_limit": 0.5},
+		RetryAfter: 2 * time.Second,
+		Markers:    true,
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want mock.FaultConfig
+	}{
+		{"empty body keeps everything", `{}`, current},
+		{"rates", `{"rates": {"hang": 0.1}}`, mock.FaultConfig{Rates: map[string]float64{"hang": 0.1}, RetryAfter: 2 * time.Second, Markers: true}},
+		{"empty rates clear them", `{"rates": {}}`, mock.FaultConfig{Rates: map[string]float64{}, RetryAfter: 2 * time.Second, Markers: true}},
+		{"retry_after", `{"retry_after": "5s"}`, mock.FaultConfig{Rates: current.Rates, RetryAfter: 5 * time.Second, Markers: true}},
+		{"markers", `{"markers": false}`, mock.FaultConfig{Rates: current.Rates, RetryAfter: 2 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock.NewMockLLMClient(mock.WithFaults(current))
+			h := NewServer(&config.AdminConfig{}, client, nil, nil, nil, zap.NewNop()).Handler()
+
+			if code := put(t, h, "/faults", tt.body); code != http.StatusOK {
+				t.Fatalf("PUT /faults = %d", code)
+			}
+			if got := client.Settings().Faults; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("faults = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutChaos(t *testing.T) {
+	current := chaos.Config{
+		Rates:      map[string]float64{"reset": 0.1},
+		Methods:    []string{"tasks/get"},
+		Header:     true,
+		Latency:    2 * time.Second,
+		Status:     http.StatusBadGateway,
+		RetryAfter: time.Second,
+	}
+	with := func(change func(*chaos.Config)) chaos.Config {
+		cfg := current
+		change(&cfg)
+		return cfg
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want chaos.Config
+	}{
+		{"empty body keeps everything", `{}`, current},
+		{"rates", `{"rates": {"latency": 0.5}}`, with(func(c *chaos.Config) { c.Rates = map[string]float64{"latency": 0.5} })},
+		{"empty rates clear them", `{"rates": {}}`, with(func(c *chaos.Config) { c.Rates = map[string]float64{} })},
+		{"methods", `{"methods": []}`, with(func(c *chaos.Config) { c.Methods = []string{} })},
+		{"header", `{"header": false}`, with(func(c *chaos.Config) { c.Header = false })},
+		{"latency", `{"latency": "50ms"}`, with(func(c *chaos.Config) { c.Latency = 50 * time.Millisecond })},
+		{"status", `{"status": 500}`, with(func(c *chaos.Config) { c.Status = http.StatusInternalServerError })},
+		{"zero status is the default", `{"status": 0}`, with(func(c *chaos.Config) { c.Status = http.StatusServiceUnavailable })},
+		{"retry_after", `{"retry_after": "3s"}`, with(func(c *chaos.Config) { c.RetryAfter = 3 * time.Second })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injector, err := chaos.NewInjector(current, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := NewServer(&config.AdminConfig{}, mock.NewMockLLMClient(), injector, nil, nil, zap.NewNop()).Handler()
+
+			if code := put(t, h, "/chaos", tt.body); code != http.StatusOK {
+				t.Fatalf("PUT /chaos = %d", code)
+			}
+			if got := injector.Config(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chaos = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
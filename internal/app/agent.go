@@ -0,0 +1,393 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	skills "github.com/inference-gateway/mock-agent/skills"
+
+	cancellation "github.com/inference-gateway/mock-agent/internal/cancellation"
+	card "github.com/inference-gateway/mock-agent/internal/card"
+	chaos "github.com/inference-gateway/mock-agent/internal/chaos"
+	filegen "github.com/inference-gateway/mock-agent/internal/filegen"
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+	journal "github.com/inference-gateway/mock-agent/internal/journal"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	outcome "github.com/inference-gateway/mock-agent/internal/outcome"
+	persona "github.com/inference-gateway/mock-agent/internal/persona"
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	push "github.com/inference-gateway/mock-agent/internal/push"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	validation "github.com/inference-gateway/mock-agent/internal/validation"
+)
+
+// skill is a skill agents can be built with
+type skill struct {
+	name string
+	// description is the line of the skill in the system prompt
+	description string
+	// tags describe the skill on the agent card
+	tags  []string
+	build func(deps *skillDeps) server.Tool
+}
+
+// skillDeps holds what skills are built from
+type skillDeps struct {
+	source               *rng.Source
+	validators           *validation.Registry
+	statusUpdateInterval time.Duration
+}
+
+// allSkills are the skills in the order they are registered and listed
+var allSkills = []skill{
+	{"echo", "Simply echo back the input message (useful for basic connectivity tests)", []string{"mock", "testing", "echo"}, func(d *skillDeps) server.Tool {
+		return skills.NewEchoSkill(d.source.Fork("echo"))
+	}},
+	{"delay", "Simulate slow responses with configurable delays", []string{"mock", "testing", "performance"}, func(d *skillDeps) server.Tool {
+		return skills.NewDelaySkill()
+	}},
+	{"error", "Simulate error conditions for testing error handling", []string{"mock", "testing", "error-handling"}, func(d *skillDeps) server.Tool {
+		return skills.NewErrorSkill()
+	}},
+	{"random_data", "Generate random test data", []string{"mock", "testing", "data-generation"}, func(d *skillDeps) server.Tool {
+		return skills.NewRandomDataSkill(d.source.Fork("random_data"))
+	}},
+	{"validate", "Validate input against common patterns", []string{"mock", "testing", "validation"}, func(d *skillDeps) server.Tool {
+		return skills.NewValidateSkill(d.validators)
+	}},
+	{"long_running", "Simulate a multi-stage job that reports progress while it runs", []string{"mock", "testing", "progress"}, func(d *skillDeps) server.Tool {
+		return skills.NewLongRunningSkill(d.statusUpdateInterval)
+	}},
+	{"generate_artifact", "Generate artifacts of a given file type and size", []string{"mock", "testing", "artifacts"}, func(d *skillDeps) server.Tool {
+		return skills.NewGenerateArtifactSkill(d.source.Fork("generate_artifact"))
+	}},
+}
+
+// systemPromptTemplate is the system prompt of agents, with %s standing in
+// for the list of their skills
+const systemPromptTemplate = `You are a mock AI assistant designed for testing and development purposes.
+
+You have access to several mock skills that demonstrate different testing scenarios:
+%s
+When responding:
+- Be clear and predictable in your responses
+- Include relevant metadata about the request
+- Support both streaming and non-streaming modes
+- Handle edge cases gracefully
+
+Your purpose is to provide consistent, reproducible responses for testing A2A protocol implementations.
+`
+
+// Shared holds what all agents of the process have in common
+type Shared struct {
+	Config          *config.Config
+	Journal         *journal.Journal
+	CancelPolicy    *cancellation.Policy
+	Validators      *validation.Registry
+	ArtifactService server.ArtifactService
+
+	// stop ends what the agents leave running when the process shuts down
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewShared builds what the agents of cfg share: the journal, the
+// cancellation test mode and the validation types of the validate skill.
+// Agents store artifacts with artifactService, which may be nil. The
+// journal reads the time from source.
+func NewShared(cfg *config.Config, artifactService server.ArtifactService, source *rng.Source, l *zap.Logger) (*Shared, error) {
+	sh := &Shared{Config: cfg, ArtifactService: artifactService, stop: make(chan struct{})}
+
+	// Record LLM calls and skill invocations for the control API
+	if cfg.Mock.Journal.Enable {
+		sh.Journal = journal.New(cfg.Mock.Journal.MaxEntries, source)
+	}
+
+	// Let skills report how they react to canceled tasks
+	if cfg.Mock.Cancel.Enable {
+		sh.CancelPolicy = &cancellation.Policy{
+			Grace:   cfg.Mock.Cancel.Grace,
+			Cleanup: cfg.Mock.Cancel.Cleanup,
+			Tools:   cfg.Mock.Cancel.Tools,
+			Untimed: rng.FixedClockConfigured(&cfg.Mock),
+			Stop:    sh.stop,
+		}
+		l.Info("cancellation test mode enabled",
+			zap.Duration("grace", sh.CancelPolicy.Grace),
+			zap.Duration("cleanup", sh.CancelPolicy.Cleanup),
+			zap.Strings("tools", sh.CancelPolicy.Tools))
+	}
+
+	// Validation types of the validate skill, including custom ones from config
+	sh.Validators = validation.NewRegistry()
+	if cfg.Mock.ValidatorsPath != "" {
+		n, err := sh.Validators.LoadCustomTypes(cfg.Mock.ValidatorsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom validators: %w", err)
+		}
+		l.Info("loaded custom validators", zap.String("path", cfg.Mock.ValidatorsPath), zap.Int("count", n))
+	}
+
+	return sh, nil
+}
+
+// Close ends the skill cleanups still in progress
+func (sh *Shared) Close() {
+	sh.stopOnce.Do(func() { close(sh.stop) })
+}
+
+// Agent is one mock agent: its LLM client, the ADK server on a loopback
+// port and the gateway in front of it
+type Agent struct {
+	Persona    persona.Persona
+	MockClient *mock.MockLLMClient
+	LLMClient  server.LLMClient
+	Upstream   *Upstream
+	Gateway    *gateway.Server
+	Injector   *chaos.Injector
+
+	outcomes *outcome.Store
+}
+
+// NewAgent builds the agent of a persona. The main agent is the persona
+// the environment describes.
+func NewAgent(sh *Shared, p persona.Persona, source *rng.Source, l *zap.Logger) (*Agent, error) {
+	selected, err := selectSkills(p.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := *sh.Config
+	cfg.Mock.ScenariosPath = p.ScenariosPath
+	p.Capabilities.Apply(&cfg.A2A.CapabilitiesConfig)
+	cfg.A2A.ServerConfig.Port = p.Port
+
+	// How skills ask their tasks to end other than by completing
+	outcomes := outcome.NewStore(cfg.Mock.OutcomeMaxEntries)
+
+	// Create toolbox with default tools (like input_required, create_artifact etc)
+	toolBox := server.NewDefaultToolBox(&cfg.A2A.AgentConfig.ToolBoxConfig)
+	deps := &skillDeps{
+		source:               source,
+		validators:           sh.Validators,
+		statusUpdateInterval: cfg.A2A.StreamingStatusUpdateInterval,
+	}
+	var prompt strings.Builder
+	for _, s := range selected {
+		tool := s.build(deps)
+		toolBox.AddTool(journaled(cancellable(outcome.WrapTool(tool, l), sh.CancelPolicy, l), sh.Journal))
+		l.Info(fmt.Sprintf("registered skill: %s (%s)", s.name, tool.GetDescription()))
+		fmt.Fprintf(&prompt, "- %s: %s\n", s.name, s.description)
+	}
+
+	systemPrompt := p.SystemPrompt
+	if systemPrompt == "" {
+		systemPrompt = fmt.Sprintf(systemPromptTemplate, prompt.String())
+	}
+
+	mockClient, err := newMockLLMClient(&cfg, source.Fork("llm"), l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mock LLM client: %w", err)
+	}
+
+	llmClient, err := newLLMClient(&cfg, mockClient, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	if sh.Journal != nil {
+		llmClient = journal.WrapLLMClient(llmClient, sh.Journal)
+	}
+
+	agent, err := server.NewAgentBuilder(l).
+		WithConfig(&cfg.A2A.AgentConfig).
+		WithLLMClient(llmClient).
+		WithToolBox(toolBox).
+		WithMaxChatCompletion(cfg.A2A.AgentConfig.MaxChatCompletionIterations).
+		WithSystemPrompt(systemPrompt).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	// The card lists what the toolbox offers, so every skill on it can be called
+	agentCard := card.Build(card.Info{
+		Name:         p.Name,
+		Description:  p.Description,
+		Version:      p.Version,
+		URL:          personaURL(cfg.A2A.AgentURL, sh.Config.A2A.ServerConfig.Port, p),
+		Capabilities: cfg.A2A.CapabilitiesConfig,
+	}, toolBox.GetTools(), cardSkills(selected, sh.Validators))
+	if p.CardPath != "" {
+		overlaid, dropped, err := card.Overlay(agentCard, p.CardPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(dropped) > 0 {
+			l.Warn("agent card file lists skills the agent does not have", zap.String("path", p.CardPath), zap.Strings("skills", dropped))
+		}
+		agentCard = overlaid
+	}
+
+	// Deliver task updates to the webhooks of push notification configs
+	var notifier *push.Notifier
+	var wrapped server.OpenAICompatibleAgent = cancellation.WrapAgent(outcome.WrapAgent(progress.WrapAgent(agent), outcomes))
+	if cfg.A2A.CapabilitiesConfig.PushNotifications {
+		notifier, err = push.NewNotifier(&cfg.Mock.Push, source.Fork("push"), l)
+		if err != nil {
+			return nil, fmt.Errorf("invalid push notification configuration: %w", err)
+		}
+		wrapped = push.WrapAgent(wrapped, notifier)
+	}
+
+	// The ADK server listens on a reserved port behind the gateway, which
+	// serves the configured port. The metrics port is one for the process,
+	// so only the main agent serves metrics.
+	listener, port, err := reservePort()
+	if err != nil {
+		return nil, err
+	}
+	a2aConfig := cfg.A2A
+	a2aConfig.ServerConfig.Port = port
+	a2aConfig.ServerConfig.TLSConfig.Enable = false
+	if p.PathPrefix != "" || p.Port != sh.Config.A2A.ServerConfig.Port {
+		a2aConfig.TelemetryConfig.Enable = false
+	}
+
+	a2aServer, err := server.NewA2AServerBuilder(a2aConfig, l).
+		WithAgent(wrapped).
+		WithAgentCard(agentCard).
+		WithArtifactService(sh.ArtifactService).
+		WithDefaultBackgroundTaskHandler().
+		WithDefaultStreamingTaskHandler().
+		Build()
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to create A2A server: %w", err)
+	}
+	var handler server.TaskHandler = outcome.WrapBackgroundHandler(a2aServer.GetBackgroundTaskHandler(), outcomes)
+	if notifier != nil {
+		handler = push.WrapBackgroundHandler(handler, notifier)
+	}
+	a2aServer.SetBackgroundTaskHandler(handler)
+	upstream := &Upstream{server: a2aServer, listener: listener, port: port, ready: make(chan struct{}), logger: l}
+
+	// Fail requests at the wire, before or after the ADK server handles them
+	injector, err := chaos.NewInjector(chaos.FromConfig(&cfg.Mock.Chaos), source.Fork("chaos"))
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("invalid chaos configuration: %w", err)
+	}
+
+	gw := gateway.NewServer(cfg.A2A.ServerConfig, cfg.A2A.CapabilitiesConfig, upstream, l)
+	a := &Agent{
+		Persona:    p,
+		MockClient: mockClient,
+		LLMClient:  llmClient,
+		Upstream:   upstream,
+		Gateway:    gw,
+		Injector:   injector,
+		outcomes:   outcomes,
+	}
+	gw.Use(chaos.Middleware(injector, l))
+	gw.Use(push.Middleware(notifier, a.Task, l))
+	gw.Use(outcome.Middleware(outcomes, l))
+	gw.Use(progress.Middleware())
+
+	return a, nil
+}
+
+// Task gets a task in the state of its outcome, bypassing wire faults and
+// without counting as a poll of its outcome
+func (a *Agent) Task(ctx context.Context, taskID string) (*types.Task, error) {
+	task, err := a.Gateway.Task(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if o, found := a.outcomes.Get(taskID); found {
+		task.Status.State = o.State
+	}
+	return task, nil
+}
+
+// cardSkills describes the selected skills and the built-in create_artifact
+// tool for the agent card. The validate skill lists the validation types of
+// the registry, and generate_artifact the MIME types of its files.
+func cardSkills(selected []skill, validators *validation.Registry) []card.Skill {
+	skills := make([]card.Skill, 0, len(selected)+1)
+	for _, s := range selected {
+		c := card.Skill{ID: s.name, Tags: s.tags}
+		switch s.name {
+		case "validate":
+			definitions := validators.Definitions()
+			names := make([]string, 0, len(definitions))
+			for _, d := range definitions {
+				names = append(names, d.Name)
+				if d.Example != "" {
+					c.Examples = append(c.Examples, fmt.Sprintf("Validate %s as %s", d.Example, d.Name))
+				}
+			}
+			c.Description = fmt.Sprintf("Validate input against common patterns (%s)", strings.Join(names, ", "))
+		case "generate_artifact":
+			c.OutputModes = []string{"text"}
+			for _, name := range filegen.Formats() {
+				format, _ := filegen.Lookup(name)
+				c.OutputModes = append(c.OutputModes, format.MIMEType)
+			}
+		}
+		skills = append(skills, c)
+	}
+	return append(skills, card.Skill{ID: "create_artifact", Tags: []string{"mock", "testing", "artifacts"}})
+}
+
+// selectSkills returns the skills with the given names, or all skills when
+// names is empty
+func selectSkills(names []string) ([]skill, error) {
+	if len(names) == 0 {
+		return allSkills, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	selected := make([]skill, 0, len(names))
+	for _, s := range allSkills {
+		if wanted[s.name] {
+			selected = append(selected, s)
+			delete(wanted, s.name)
+		}
+	}
+	for name := range wanted {
+		return nil, fmt.Errorf("unknown skill %q", name)
+	}
+	return selected, nil
+}
+
+// personaURL is the URL the card of a persona advertises: the agent URL
+// with the persona's path prefix, or with the persona's port in place of
+// the main agent's
+func personaURL(agentURL, mainPort string, p persona.Persona) string {
+	if agentURL == "" {
+		return ""
+	}
+	if p.PathPrefix != "" {
+		return strings.TrimSuffix(agentURL, "/") + p.PathPrefix
+	}
+
+	u, err := url.Parse(agentURL)
+	if err != nil || p.Port == mainPort || u.Port() != mainPort {
+		return agentURL
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), p.Port)
+	return u.String()
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+
+	server "github.com/inference-gateway/adk/server"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+
+	cancellation "github.com/inference-gateway/mock-agent/internal/cancellation"
+	journal "github.com/inference-gateway/mock-agent/internal/journal"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// newMockLLMClient builds the scripted mock LLM client from the mock configuration
+func newMockLLMClient(cfg *config.Config, source *rng.Source, l *zap.Logger) (*mock.MockLLMClient, error) {
+	streamProfile := mock.StreamProfile{
+		Chunking:  cfg.Mock.StreamChunking,
+		ChunkSize: cfg.Mock.StreamChunkSize,
+		Latency:   cfg.Mock.StreamLatency,
+		Jitter:    cfg.Mock.StreamJitter,
+	}
+	if err := streamProfile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid stream profile: %w", err)
+	}
+
+	faults := mock.FaultConfig{
+		Rates:      cfg.Mock.FaultRates,
+		RetryAfter: cfg.Mock.FaultRetryAfter,
+		Markers:    cfg.Mock.FaultMarkers,
+	}
+	if err := faults.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fault configuration: %w", err)
+	}
+
+	toolErrors := mock.ToolErrorPolicy{
+		Default:    cfg.Mock.ToolErrorPolicy,
+		Actions:    cfg.Mock.ToolErrorActions,
+		MaxRetries: cfg.Mock.ToolErrorRetries,
+	}
+	if err := toolErrors.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tool error policy: %w", err)
+	}
+
+	tokenizer, err := mock.NewTokenizer(cfg.Mock.Tokenizer)
+	if err != nil {
+		return nil, err
+	}
+
+	mockOpts := []mock.Option{
+		mock.WithSource(source),
+		mock.WithStreamProfile(streamProfile),
+		mock.WithFaults(faults),
+		mock.WithArgumentsFromMessage(cfg.Mock.ArgsFromMessage),
+		mock.WithClarification(cfg.Mock.Clarify),
+		mock.WithTokenizer(tokenizer),
+		mock.WithContextWindow(cfg.Mock.ContextWindow),
+		mock.WithToolErrorPolicy(toolErrors),
+	}
+	if cfg.Mock.ScenariosPath != "" {
+		scenarios, err := mock.LoadScenarios(cfg.Mock.ScenariosPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mock scenarios: %w", err)
+		}
+		mockOpts = append(mockOpts, mock.WithScenarios(scenarios))
+		l.Info("loaded mock scenarios",
+			zap.String("path", cfg.Mock.ScenariosPath),
+			zap.Int("count", len(scenarios.Scenarios)))
+	}
+
+	return mock.NewMockLLMClient(mockOpts...), nil
+}
+
+// newLLMClient builds the LLM client for the configured mock mode
+func newLLMClient(cfg *config.Config, mockClient *mock.MockLLMClient, l *zap.Logger) (server.LLMClient, error) {
+	switch cfg.Mock.Mode {
+	case "", "mock":
+		l.Info("using mock LLM client (no external API calls)")
+		return mockClient, nil
+
+	case "record":
+		upstream, err := server.NewOpenAICompatibleLLMClient(&cfg.A2A.AgentConfig, l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upstream LLM client: %w", err)
+		}
+		l.Info("recording LLM traffic",
+			zap.String("provider", cfg.A2A.AgentConfig.Provider),
+			zap.String("model", cfg.A2A.AgentConfig.Model),
+			zap.String("fixtures_dir", cfg.Mock.FixturesDir))
+		return mock.NewRecordingLLMClient(upstream, cfg.Mock.FixturesDir, l)
+
+	case "replay":
+		var fallback server.LLMClient
+		if cfg.Mock.ReplayFallback {
+			fallback = mockClient
+		}
+		replayClient, err := mock.NewReplayLLMClient(cfg.Mock.FixturesDir, fallback)
+		if err != nil {
+			return nil, err
+		}
+		l.Info("replaying recorded LLM fixtures",
+			zap.String("fixtures_dir", cfg.Mock.FixturesDir),
+			zap.Int("count", replayClient.Len()),
+			zap.Bool("fallback", cfg.Mock.ReplayFallback))
+		return replayClient, nil
+
+	default:
+		return nil, fmt.Errorf("unknown mock mode %q: must be one of (mock, record, replay)", cfg.Mock.Mode)
+	}
+}
+
+// journaled wraps a skill so its invocations are recorded when the journal is enabled
+func journaled(tool server.Tool, j *journal.Journal) server.Tool {
+	if j == nil {
+		return tool
+	}
+	return journal.WrapTool(tool, j)
+}
+
+// cancellable wraps a skill in the cancellation test mode when it is enabled
+func cancellable(tool server.Tool, policy *cancellation.Policy, l *zap.Logger) server.Tool {
+	if policy == nil {
+		return tool
+	}
+	return cancellation.WrapTool(tool, *policy, l)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	"go.uber.org/zap"
+)
+
+// readyPollInterval is how often Start checks whether the ADK server accepts
+// connections yet
+const readyPollInterval = 10 * time.Millisecond
+
+// Upstream runs the ADK server behind the gateway. The ADK server listens on
+// every interface of its port, which is reserved on loopback when the agent
+// is built and held until Start hands it over, so no other process takes it
+// meanwhile.
+type Upstream struct {
+	server   server.A2AServer
+	listener net.Listener
+	port     string
+	ready    chan struct{}
+	logger   *zap.Logger
+}
+
+// reservePort holds a free loopback port for the ADK server
+func reservePort() (net.Listener, string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to reserve a port for the A2A server: %w", err)
+	}
+	return listener, fmt.Sprint(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// Port returns the port of the ADK server
+func (u *Upstream) Port() string {
+	return u.port
+}
+
+// Ready is closed once the ADK server accepts connections
+func (u *Upstream) Ready() <-chan struct{} {
+	return u.ready
+}
+
+// Start releases the reserved port and runs the ADK server on it until Stop
+// is called
+func (u *Upstream) Start(ctx context.Context) error {
+	_ = u.listener.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- u.server.Start(ctx) }()
+
+	address := net.JoinHostPort("127.0.0.1", u.port)
+	for {
+		if conn, err := net.DialTimeout("tcp", address, time.Second); err == nil {
+			_ = conn.Close()
+			close(u.ready)
+			break
+		}
+		select {
+		case err := <-done:
+			return serveResult(err)
+		case <-ctx.Done():
+			return nil
+		case <-time.After(readyPollInterval):
+		}
+	}
+	return serveResult(<-done)
+}
+
+// Stop shuts the ADK server down, or gives the reserved port back when the
+// server never started
+func (u *Upstream) Stop(ctx context.Context) error {
+	_ = u.listener.Close()
+	return u.server.Stop(ctx)
+}
+
+// serveResult is the error of a server that stopped serving, if it did not
+// stop because it was shut down
+func serveResult(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package cancellation
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	relay "github.com/inference-gateway/mock-agent/internal/relay"
+)
+
+// Agent keeps canceled runs canceled. When a task is canceled while a tool
+// runs, the wrapped agent may drop the tool result and finish the run as
+// completed, or end it without a word, and the task handlers then save the
+// task as completed over its canceled state.
+type Agent struct {
+	inner server.OpenAICompatibleAgent
+}
+
+// WrapAgent returns an agent that reports the runs of inner that were
+// canceled as canceled
+func WrapAgent(inner server.OpenAICompatibleAgent) *Agent {
+	return &Agent{inner: inner}
+}
+
+func (a *Agent) RunWithStream(ctx context.Context, messages []types.Message) (<-chan cloudevents.Event, error) {
+	events, err := a.inner.RunWithStream(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan cloudevents.Event)
+	go func() {
+		defer close(out)
+
+		var finished, canceledRun, interrupted bool
+		for event := range events {
+			if ctx.Err() != nil {
+				event = canceled(event)
+			}
+			switch event.Type() {
+			case types.EventTaskStatusChanged:
+				var status types.TaskStatus
+				if event.DataAs(&status) == nil {
+					switch status.State {
+					case types.TaskStateCanceled:
+						finished, canceledRun = true, true
+					case types.TaskStateCompleted, types.TaskStateFailed:
+						finished = true
+					}
+				}
+			case types.EventInputRequired:
+				finished = true
+			case types.EventTaskInterrupted:
+				interrupted = true
+			}
+			relay.Event(ctx, out, event)
+		}
+
+		// A canceled run that ends without saying so, or without the
+		// interruption the streaming handler waits for, would be saved as
+		// completed by the task handlers
+		if ctx.Err() == nil || interrupted || (finished && !canceledRun) {
+			return
+		}
+		if !finished {
+			status := cloudevents.NewEvent()
+			status.SetSource("mock-agent/cancellation")
+			status.SetType(types.EventTaskStatusChanged)
+			_ = status.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: types.TaskStateCanceled})
+			relay.Event(ctx, out, status)
+		}
+		message := types.NewStreamingStatusMessage("task-interrupted", string(types.TaskStateCanceled), nil)
+		if task, ok := ctx.Value(server.TaskContextKey).(*types.Task); ok && task != nil {
+			message.TaskID = &task.ID
+			message.ContextID = &task.ContextID
+		}
+		relay.Event(ctx, out, types.NewMessageEvent(types.EventTaskInterrupted, message.MessageID, message))
+	}()
+
+	return out, nil
+}
+
+// canceled turns a completed status change into a canceled one
+func canceled(event cloudevents.Event) cloudevents.Event {
+	if event.Type() != types.EventTaskStatusChanged {
+		return event
+	}
+
+	var status types.TaskStatus
+	if err := event.DataAs(&status); err != nil || status.State != types.TaskStateCompleted {
+		return event
+	}
+
+	status.State = types.TaskStateCanceled
+	event = event.Clone()
+	_ = event.SetData(cloudevents.ApplicationJSON, status)
+	return event
+}
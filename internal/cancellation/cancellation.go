@@ -0,0 +1,180 @@
+package cancellation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	"go.uber.org/zap"
+
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// CodeCanceled is the error code of skills that stopped because their task was canceled
+const CodeCanceled = "canceled"
+
+// Policy is how skills react when their task is canceled while they run
+type Policy struct {
+	// Grace is how long a skill ignores the cancellation, like a stuck tool
+	Grace time.Duration
+	// Cleanup is how long a skill takes to wind down once it sees the cancellation
+	Cleanup time.Duration
+	// Tools limits the policy to these skills; empty applies it to all of them
+	Tools []string
+	// Untimed leaves the durations out of reports. Runs on a fixed clock
+	// set it, since measured wall time would differ from run to run.
+	Untimed bool
+	// Stop cuts cleanups short when it is closed, e.g. when the agent shuts
+	// down (nil = never)
+	Stop <-chan struct{}
+}
+
+// Report describes how a skill reacted to the cancellation of its task. The
+// durations are nil when the policy is untimed.
+type Report struct {
+	// CanceledAfterMs is how long the skill had run when its task was canceled
+	CanceledAfterMs *int64 `json:"canceled_after_ms,omitempty"`
+	// IgnoredMs is how long the skill kept running without seeing the cancellation
+	IgnoredMs *int64 `json:"ignored_ms,omitempty"`
+	// Observed is true when the skill stopped because of the cancellation,
+	// false when it finished its work regardless
+	Observed bool `json:"observed"`
+	// CleanupMs is how long the skill took to return once it saw the cancellation
+	CleanupMs *int64 `json:"cleanup_ms,omitempty"`
+}
+
+// Tool runs a skill under a cancellation policy. When the task is canceled
+// during the run, the result of the skill reports how it reacted.
+type Tool struct {
+	server.Tool
+	policy Policy
+	logger *zap.Logger
+}
+
+// WrapTool returns inner under the policy, or inner itself when the policy
+// does not cover it
+func WrapTool(inner server.Tool, policy Policy, logger *zap.Logger) server.Tool {
+	if len(policy.Tools) > 0 && !slices.Contains(policy.Tools, inner.GetName()) {
+		return inner
+	}
+	return &Tool{Tool: inner, policy: policy, logger: logger}
+}
+
+type outcome struct {
+	result string
+	err    error
+}
+
+func (t *Tool) Execute(ctx context.Context, arguments map[string]any) (string, error) {
+	startedAt := time.Now()
+
+	// The skill runs on a context of its own, which is only canceled once
+	// the grace period after the cancellation of the task has passed
+	runCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	defer stop()
+
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := t.Tool.Execute(runCtx, arguments)
+		done <- outcome{result: result, err: err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+	}
+
+	canceledAt := time.Now()
+	var report Report
+	report.CanceledAfterMs = t.millis(canceledAt.Sub(startedAt))
+
+	grace := time.NewTimer(t.policy.Grace)
+	defer grace.Stop()
+
+	select {
+	case out = <-done:
+		report.IgnoredMs = t.millis(time.Since(canceledAt))
+		report.CleanupMs = t.millis(0)
+	case <-grace.C:
+		report.IgnoredMs = t.millis(time.Since(canceledAt))
+		stop()
+		stoppedAt := time.Now()
+		out = <-done
+		report.Observed = errors.Is(out.err, context.Canceled)
+		if report.Observed {
+			t.cleanup()
+		}
+		report.CleanupMs = t.millis(time.Since(stoppedAt))
+	}
+
+	fields := []zap.Field{zap.String("tool", t.GetName()), zap.Bool("observed", report.Observed)}
+	if !t.policy.Untimed {
+		fields = append(fields,
+			zap.Int64("canceled_after_ms", *report.CanceledAfterMs),
+			zap.Int64("ignored_ms", *report.IgnoredMs),
+			zap.Int64("cleanup_ms", *report.CleanupMs))
+	}
+	t.logger.Info("skill run canceled", fields...)
+
+	return withReport(out, report)
+}
+
+// cleanup waits while the skill winds down, or until the policy stops
+func (t *Tool) cleanup() {
+	timer := time.NewTimer(t.policy.Cleanup)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-t.policy.Stop:
+	}
+}
+
+// millis is d for a report, or nil when the policy is untimed
+func (t *Tool) millis(d time.Duration) *int64 {
+	if t.policy.Untimed {
+		return nil
+	}
+	ms := d.Milliseconds()
+	return &ms
+}
+
+// withReport adds the report to the outcome of a canceled skill run.
+// Failures become structured errors that carry it in their details;
+// results that are JSON objects carry it in a cancellation field.
+func withReport(out outcome, report Report) (string, error) {
+	details := map[string]any{"cancellation": report}
+
+	if out.err != nil {
+		var failure *toolresult.Error
+		if errors.As(out.err, &failure) {
+			reported := *failure
+			reported.Details = details
+			return "", &reported
+		}
+
+		code := toolresult.CodeExecutionFailed
+		if errors.Is(out.err, context.Canceled) {
+			code = CodeCanceled
+		}
+		failure = toolresult.NewError(code, out.err.Error(), false)
+		failure.Details = details
+		return "", failure
+	}
+
+	var result map[string]any
+	if json.Unmarshal([]byte(out.result), &result) != nil || result == nil {
+		return out.result, nil
+	}
+	result["cancellation"] = report
+	data, err := json.Marshal(result)
+	if err != nil {
+		return out.result, nil
+	}
+	return string(data), nil
+}
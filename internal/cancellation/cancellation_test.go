@@ -0,0 +1,83 @@
+package cancellation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// waitingTool runs until its context is canceled
+type waitingTool struct{}
+
+func (waitingTool) GetName() string               { return "wait" }
+func (waitingTool) GetDescription() string        { return "" }
+func (waitingTool) GetParameters() map[string]any { return nil }
+func (waitingTool) Execute(ctx context.Context, _ map[string]any) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+// runCanceled runs the waiting tool under policy and cancels its task
+// right away, returning the report of the run
+func runCanceled(t *testing.T, policy Policy) map[string]any {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := WrapTool(waitingTool{}, policy, zap.NewNop()).Execute(ctx, nil)
+	var failure *toolresult.Error
+	if !errors.As(err, &failure) {
+		t.Fatalf("Execute() error = %v, want a tool result error", err)
+	}
+	data, _ := json.Marshal(failure.Details["cancellation"])
+	var report map[string]any
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestReportTimings(t *testing.T) {
+	tests := []struct {
+		name    string
+		untimed bool
+		want    []string
+	}{
+		{"timed", false, []string{"canceled_after_ms", "ignored_ms", "cleanup_ms", "observed"}},
+		{"untimed", true, []string{"observed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runCanceled(t, Policy{Untimed: tt.untimed})
+			if len(report) != len(tt.want) {
+				t.Errorf("report = %v, want fields %v", report, tt.want)
+			}
+			for _, field := range tt.want {
+				if _, ok := report[field]; !ok {
+					t.Errorf("report = %v, missing %s", report, field)
+				}
+			}
+			if report["observed"] != true {
+				t.Errorf("observed = %v, want true", report["observed"])
+			}
+		})
+	}
+}
+
+func TestCleanupStops(t *testing.T) {
+	stop := make(chan struct{})
+	close(stop)
+
+	start := time.Now()
+	runCanceled(t, Policy{Cleanup: time.Minute, Stop: stop})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cleanup took %s after the policy stopped", elapsed)
+	}
+}
@@ -0,0 +1,216 @@
+package card
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	adkconfig "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+const (
+	// ProtocolVersion is the A2A protocol version the agent speaks
+	ProtocolVersion = "0.3.0"
+	// DefaultPath is the card file generated from agent.yaml
+	DefaultPath = ".well-known/agent-card.json"
+	// hiddenTool is how the agent asks for input; clients cannot call it
+	hiddenTool = "input_required"
+)
+
+// Info is the configuration the card is built from
+type Info struct {
+	Name         string
+	Description  string
+	Version      string
+	URL          string
+	Capabilities adkconfig.CapabilitiesConfig
+}
+
+// Skill is what the card tells about a tool besides its name and
+// description. Empty fields fall back to the tool and the card defaults.
+type Skill struct {
+	ID          string
+	Description string
+	Tags        []string
+	Examples    []string
+	InputModes  []string
+	OutputModes []string
+}
+
+// Build returns the card of an agent whose toolbox offers tools. Every tool
+// but input_required becomes a skill: first the ones skills describes, in
+// their order, then the others by name.
+func Build(info Info, tools []sdk.ChatCompletionTool, skills []Skill) types.AgentCard {
+	descriptions := make(map[string]string, len(tools))
+	for _, tool := range tools {
+		if tool.Function.Name == hiddenTool {
+			continue
+		}
+		description := ""
+		if tool.Function.Description != nil {
+			description = *tool.Function.Description
+		}
+		descriptions[tool.Function.Name] = description
+	}
+
+	card := types.AgentCard{
+		Name:               info.Name,
+		Description:        info.Description,
+		Version:            info.Version,
+		URL:                info.URL,
+		ProtocolVersion:    ProtocolVersion,
+		PreferredTransport: "JSONRPC",
+		DefaultInputModes:  []string{"text"},
+		DefaultOutputModes: []string{"text"},
+		Capabilities: types.AgentCapabilities{
+			Streaming:              &info.Capabilities.Streaming,
+			PushNotifications:      &info.Capabilities.PushNotifications,
+			StateTransitionHistory: &info.Capabilities.StateTransitionHistory,
+		},
+		Skills: make([]types.AgentSkill, 0, len(descriptions)),
+	}
+
+	for _, s := range skills {
+		description, ok := descriptions[s.ID]
+		if !ok {
+			continue
+		}
+		delete(descriptions, s.ID)
+		if s.Description != "" {
+			description = s.Description
+		}
+		tags := s.Tags
+		if len(tags) == 0 {
+			tags = []string{"mock", "testing"}
+		}
+		card.Skills = append(card.Skills, types.AgentSkill{
+			ID:          s.ID,
+			Name:        s.ID,
+			Description: description,
+			Tags:        tags,
+			Examples:    s.Examples,
+			InputModes:  s.InputModes,
+			OutputModes: s.OutputModes,
+		})
+	}
+
+	rest := make([]string, 0, len(descriptions))
+	for name := range descriptions {
+		rest = append(rest, name)
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		card.Skills = append(card.Skills, types.AgentSkill{
+			ID:          name,
+			Name:        name,
+			Description: descriptions[name],
+			Tags:        []string{"mock", "testing"},
+		})
+	}
+	return card
+}
+
+// File returns the card file to lay over the generated card: configured,
+// or DefaultPath when that is unset and the file exists
+func File(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if _, err := os.Stat(DefaultPath); err == nil {
+		return DefaultPath
+	}
+	return ""
+}
+
+// protectedFields are built from the configuration and cannot be overlaid
+var protectedFields = map[string]bool{
+	"name":         true,
+	"description":  true,
+	"version":      true,
+	"url":          true,
+	"capabilities": true,
+}
+
+// skillFields are the fields of a skill an overlay can set
+var skillFields = []string{"description", "tags", "examples", "inputModes", "outputModes", "security"}
+
+// Overlay lays the fields of the card file at path over card, e.g. a
+// provider, security schemes or skill examples. The name, description,
+// version, URL and capabilities keep the values of card, and skills of the
+// file only update the skills of card with the same ID. It returns the IDs
+// of the skills of the file that card does not have.
+func Overlay(card types.AgentCard, path string) (types.AgentCard, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return card, nil, fmt.Errorf("failed to read agent card file: %w", err)
+	}
+	var file map[string]any
+	if err := json.Unmarshal(data, &file); err != nil {
+		return card, nil, fmt.Errorf("failed to parse agent card file %s: %w", path, err)
+	}
+
+	generated, err := toMap(card)
+	if err != nil {
+		return card, nil, err
+	}
+
+	var dropped []string
+	for key, value := range file {
+		if protectedFields[key] {
+			continue
+		}
+		if key != "skills" {
+			generated[key] = value
+			continue
+		}
+
+		overlays, _ := value.([]any)
+		skills, _ := generated["skills"].([]any)
+		for _, overlay := range overlays {
+			fields, _ := overlay.(map[string]any)
+			id, _ := fields["id"].(string)
+			skill := findSkill(skills, id)
+			if skill == nil {
+				dropped = append(dropped, id)
+				continue
+			}
+			for _, field := range skillFields {
+				if v, ok := fields[field]; ok {
+					skill[field] = v
+				}
+			}
+		}
+	}
+
+	merged, err := json.Marshal(generated)
+	if err != nil {
+		return card, nil, err
+	}
+	var result types.AgentCard
+	if err := json.Unmarshal(merged, &result); err != nil {
+		return card, nil, fmt.Errorf("invalid agent card file %s: %w", path, err)
+	}
+	return result, dropped, nil
+}
+
+func findSkill(skills []any, id string) map[string]any {
+	for _, skill := range skills {
+		if fields, ok := skill.(map[string]any); ok && fields["id"] == id {
+			return fields
+		}
+	}
+	return nil
+}
+
+func toMap(card types.AgentCard) (map[string]any, error) {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
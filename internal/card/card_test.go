@@ -0,0 +1,146 @@
+package card
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	adkconfig "github.com/inference-gateway/adk/server/config"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+func tool(name, description string) sdk.ChatCompletionTool {
+	return sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: name, Description: &description}}
+}
+
+func TestBuild(t *testing.T) {
+	tools := []sdk.ChatCompletionTool{
+		tool("validate", "Validate input"),
+		tool("input_required", "Ask the user"),
+		tool("echo", "Echo back"),
+		tool("create_artifact", "Create an artifact"),
+		tool("delay", "Wait"),
+	}
+	skills := []Skill{
+		{ID: "echo", Tags: []string{"echo"}, Examples: []string{"echo hi"}},
+		{ID: "validate", Description: "Validate emails and more", OutputModes: []string{"application/json"}},
+		{ID: "random_data"},
+	}
+
+	card := Build(Info{Name: "mock", Version: "1.0.0", URL: "http://localhost:8080", Capabilities: adkconfig.CapabilitiesConfig{Streaming: true}}, tools, skills)
+
+	var ids []string
+	for _, skill := range card.Skills {
+		ids = append(ids, skill.ID)
+	}
+	// Described skills keep their order, the rest follow by name, and
+	// input_required and skills without a tool are left out
+	if want := []string{"echo", "validate", "create_artifact", "delay"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("skills = %v, want %v", ids, want)
+	}
+
+	echo, validate, delay := card.Skills[0], card.Skills[1], card.Skills[3]
+	if echo.Description != "Echo back" || !reflect.DeepEqual(echo.Tags, []string{"echo"}) || !reflect.DeepEqual(echo.Examples, []string{"echo hi"}) {
+		t.Errorf("echo = %+v", echo)
+	}
+	if validate.Description != "Validate emails and more" || !reflect.DeepEqual(validate.OutputModes, []string{"application/json"}) {
+		t.Errorf("validate = %+v", validate)
+	}
+	if delay.Description != "Wait" || !reflect.DeepEqual(delay.Tags, []string{"mock", "testing"}) {
+		t.Errorf("delay = %+v", delay)
+	}
+	if card.Name != "mock" || card.ProtocolVersion != ProtocolVersion || !*card.Capabilities.Streaming || *card.Capabilities.PushNotifications {
+		t.Errorf("card = %+v", card)
+	}
+}
+
+func writeCard(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agent-card.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOverlay(t *testing.T) {
+	generated := Build(Info{Name: "mock", Description: "Mock agent", Version: "1.0.0", URL: "http://localhost:8080"},
+		[]sdk.ChatCompletionTool{tool("echo", "Echo back"), tool("delay", "Wait")}, nil)
+
+	path := writeCard(t, `{
+		"name": "other", "description": "other", "version": "9.9.9", "url": "http://elsewhere",
+		"capabilities": {"streaming": true},
+		"provider": {"organization": "Acme", "url": "https://acme.example"},
+		"skills": [
+			{"id": "echo", "name": "renamed", "tags": ["echo"], "schema": {}},
+			{"id": "translate", "tags": ["i18n"]}
+		]
+	}`)
+
+	card, dropped, err := Overlay(generated, path)
+	if err != nil {
+		t.Fatalf("Overlay() error = %v", err)
+	}
+	if !reflect.DeepEqual(dropped, []string{"translate"}) {
+		t.Errorf("dropped = %v, want [translate]", dropped)
+	}
+	if card.Name != "mock" || card.Description != "Mock agent" || card.Version != "1.0.0" || card.URL != "http://localhost:8080" || *card.Capabilities.Streaming {
+		t.Errorf("protected fields changed: %+v", card)
+	}
+	if card.Provider == nil || card.Provider.Organization != "Acme" {
+		t.Errorf("provider = %+v, want Acme", card.Provider)
+	}
+	if len(card.Skills) != 2 {
+		t.Fatalf("skills = %+v, want echo and delay", card.Skills)
+	}
+	if echo := card.Skills[1]; echo.Name != "echo" || !reflect.DeepEqual(echo.Tags, []string{"echo"}) {
+		t.Errorf("echo = %+v, want only its tags overlaid", echo)
+	}
+	if delay := card.Skills[0]; !reflect.DeepEqual(delay.Tags, []string{"mock", "testing"}) {
+		t.Errorf("delay = %+v, want it untouched", delay)
+	}
+}
+
+func TestOverlayErrors(t *testing.T) {
+	generated := Build(Info{Name: "mock"}, nil, nil)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "missing.json"), "failed to read agent card file"},
+		{"not JSON", writeCard(t, `{"provider":`), "failed to parse agent card file"},
+		{"field of the wrong type", writeCard(t, `{"defaultInputModes": "text"}`), "invalid agent card file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Overlay(generated, tt.path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Overlay() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	if got := File("custom.json"); got != "custom.json" {
+		t.Errorf("File(custom.json) = %q", got)
+	}
+
+	t.Chdir(t.TempDir())
+	if got := File(""); got != "" {
+		t.Errorf("File() without %s = %q, want none", DefaultPath, got)
+	}
+	if err := os.MkdirAll(filepath.Dir(DefaultPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(DefaultPath, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := File(""); got != DefaultPath {
+		t.Errorf("File() = %q, want %s", got, DefaultPath)
+	}
+}
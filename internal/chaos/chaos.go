@@ -0,0 +1,184 @@
+package chaos
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Fault types the gateway can inject into A2A requests
+const (
+	// FaultLatency delays the request before it is handled
+	FaultLatency = "latency"
+	// FaultHTTPError answers with a 5xx status and a Retry-After header
+	FaultHTTPError = "http_error"
+	// FaultRateLimit answers with 429 and a Retry-After header
+	FaultRateLimit = "rate_limit"
+	// FaultReset resets the connection before the request is handled
+	FaultReset = "reset"
+	// FaultTruncate cuts the JSON-RPC response, or the final event of a
+	// stream, in half and ends the response there
+	FaultTruncate = "truncate"
+	// FaultStall sends half of the response, or of the final event of a
+	// stream, then nothing more until the client gives up
+	FaultStall = "stall"
+	// FaultDropStream closes the connection in place of the response, or of
+	// the final event of a stream
+	FaultDropStream = "drop_stream"
+	// FaultNone exempts a request from faults when sent in the header
+	FaultNone = "none"
+)
+
+// Header selects the fault of a request, e.g. X-Mock-Chaos: stall
+const Header = "X-Mock-Chaos"
+
+var faultTypes = map[string]bool{
+	FaultLatency:    true,
+	FaultHTTPError:  true,
+	FaultRateLimit:  true,
+	FaultReset:      true,
+	FaultTruncate:   true,
+	FaultStall:      true,
+	FaultDropStream: true,
+}
+
+// Config controls which faults are injected into which requests
+type Config struct {
+	// Rates maps a fault type to the probability (0-1) of injecting it into a request
+	Rates map[string]float64 `json:"rates,omitempty"`
+	// Methods limits the rates to these JSON-RPC methods (empty = all methods)
+	Methods []string `json:"methods,omitempty"`
+	// Header enables per-request selection with the X-Mock-Chaos header
+	Header bool `json:"header"`
+	// Latency is the delay of the latency fault
+	Latency time.Duration `json:"latency"`
+	// Status is the HTTP status of the http_error fault
+	Status int `json:"status"`
+	// RetryAfter is the Retry-After hint of the http_error and rate_limit faults
+	RetryAfter time.Duration `json:"retry_after"`
+}
+
+// FromConfig reads the chaos settings of the mock configuration
+func FromConfig(cfg *config.ChaosConfig) Config {
+	return Config{
+		Rates:      cfg.Rates,
+		Methods:    cfg.Methods,
+		Header:     cfg.Header,
+		Latency:    cfg.Latency,
+		Status:     cfg.Status,
+		RetryAfter: cfg.RetryAfter,
+	}
+}
+
+// Validate checks fault names, probabilities and the error status
+func (c Config) Validate() error {
+	total := 0.0
+	for name, rate := range c.Rates {
+		if !faultTypes[name] {
+			return fmt.Errorf("unknown chaos fault type %q", name)
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("chaos rate for %q must be between 0 and 1", name)
+		}
+		total += rate
+	}
+	if total > 1 {
+		return fmt.Errorf("chaos rates must not add up to more than 1")
+	}
+
+	if c.Status < 500 || c.Status > 599 {
+		return fmt.Errorf("chaos status %d is not a 5xx status", c.Status)
+	}
+	if c.Latency < 0 || c.RetryAfter < 0 {
+		return fmt.Errorf("chaos latency and retry after must not be negative")
+	}
+	return nil
+}
+
+// Injector picks the faults of the requests of one agent. Its configuration
+// can be replaced while the agent runs.
+type Injector struct {
+	mu      sync.Mutex
+	cfg     Config
+	initial Config
+	source  *rng.Source
+}
+
+// NewInjector creates an injector that starts with cfg
+func NewInjector(cfg Config, source *rng.Source) (*Injector, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Injector{cfg: cfg, initial: cfg, source: source}, nil
+}
+
+// Config returns the current configuration
+func (i *Injector) Config() Config {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.cfg
+}
+
+// Set replaces the configuration
+func (i *Injector) Set(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cfg = cfg
+	return nil
+}
+
+// Reset restores the configuration the injector started with
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cfg = i.initial
+}
+
+// Pick chooses the fault of a request calling method, or "" for none. The
+// header wins over the rates, which only apply to the configured methods.
+// It returns the configuration the fault is to be injected with.
+func (i *Injector) Pick(method string, header http.Header) (string, Config, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	cfg := i.cfg
+
+	if value := header.Get(Header); value != "" && cfg.Header {
+		switch {
+		case value == FaultNone:
+			return "", cfg, nil
+		case faultTypes[value]:
+			return value, cfg, nil
+		default:
+			return "", cfg, fmt.Errorf("unknown chaos fault type %q", value)
+		}
+	}
+
+	if len(cfg.Rates) == 0 || (len(cfg.Methods) > 0 && !slices.Contains(cfg.Methods, method)) {
+		return "", cfg, nil
+	}
+
+	names := make([]string, 0, len(cfg.Rates))
+	for name := range cfg.Rates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	roll := i.source.Float64()
+	for _, name := range names {
+		roll -= cfg.Rates[name]
+		if roll < 0 {
+			return name, cfg, nil
+		}
+	}
+	return "", cfg, nil
+}
@@ -0,0 +1,227 @@
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+)
+
+// errAborted fails the writes of a response whose connection is dropped
+var errAborted = errors.New("response aborted by chaos fault")
+
+// Middleware serves every JSON-RPC request with the fault the injector
+// picks for it, if any
+func Middleware(injector *Injector, logger *zap.Logger) gateway.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serve(w, r, next, injector, logger)
+		})
+	}
+}
+
+func serve(w http.ResponseWriter, r *http.Request, next http.Handler, injector *Injector, logger *zap.Logger) {
+	req := gateway.RPC(r)
+	fault, cfg, err := injector.Pick(req.Method, r.Header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if fault == "" {
+		next.ServeHTTP(w, r)
+		return
+	}
+	logger.Info("injecting chaos fault", zap.String("method", req.Method), zap.String("fault", fault))
+
+	switch fault {
+	case FaultLatency:
+		select {
+		case <-time.After(cfg.Latency):
+		case <-r.Context().Done():
+			return
+		}
+		next.ServeHTTP(w, r)
+
+	case FaultHTTPError, FaultRateLimit:
+		status := cfg.Status
+		if fault == FaultRateLimit {
+			status = http.StatusTooManyRequests
+		}
+		if cfg.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(cfg.RetryAfter.Seconds()))))
+		}
+		var data any = map[string]any{"fault": fault, "http_status": status}
+		gateway.WriteJSON(w, status, types.JSONRPCErrorResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &types.JSONRPCError{Code: -32603, Message: http.StatusText(status), Data: &data},
+		})
+
+	case FaultReset:
+		resetConnection(w)
+
+	default:
+		cw := &chaosWriter{ResponseWriter: w, fault: fault, ctx: r.Context(), status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		cw.finish()
+	}
+}
+
+// resetConnection closes the connection of w without answering, with a TCP
+// reset where it can
+func resetConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		// HTTP/2 streams cannot be hijacked but are reset by the abort
+		panic(http.ErrAbortHandler)
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+// chaosWriter injects the faults that spoil a response once it is known:
+// the body of a JSON-RPC response is held back until it is complete, the
+// events of a stream are passed on until the final one. The fault then
+// hits that body or event.
+type chaosWriter struct {
+	http.ResponseWriter
+	fault string
+	// ctx ends a stall when the client or the gateway gives up
+	ctx context.Context
+
+	status      int
+	wroteHeader bool
+	stream      bool
+	pending     bytes.Buffer
+	// done discards the rest of a response the fault already cut short
+	done    bool
+	aborted bool
+}
+
+func (c *chaosWriter) WriteHeader(status int) {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+	c.stream = strings.HasPrefix(c.Header().Get("Content-Type"), "text/event-stream")
+	if c.stream {
+		c.ResponseWriter.WriteHeader(status)
+		return
+	}
+	c.status = status
+	c.Header().Del("Content-Length")
+}
+
+func (c *chaosWriter) Write(b []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.aborted {
+		return 0, errAborted
+	}
+	if c.done {
+		return len(b), nil
+	}
+
+	c.pending.Write(b)
+	if !c.stream {
+		return len(b), nil
+	}
+	for {
+		end := bytes.Index(c.pending.Bytes(), []byte("\n\n"))
+		if end < 0 {
+			return len(b), nil
+		}
+		event := bytes.Clone(c.pending.Next(end + 2))
+		if finalEvent(event) {
+			c.pending.Reset()
+			return len(b), c.inject(event)
+		}
+		if _, err := c.ResponseWriter.Write(event); err != nil {
+			return len(b), err
+		}
+	}
+}
+
+func (c *chaosWriter) Flush() {
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// inject sends body, the whole JSON-RPC response or the final event of a
+// stream, the way the fault spoils it
+func (c *chaosWriter) inject(body []byte) error {
+	switch c.fault {
+	case FaultTruncate:
+		_, _ = c.ResponseWriter.Write(body[:len(body)/2])
+		c.Flush()
+		c.done = true
+		return nil
+	case FaultStall:
+		_, _ = c.ResponseWriter.Write(body[:len(body)/2])
+		c.Flush()
+		<-c.ctx.Done()
+	}
+	c.aborted = true
+	return errAborted
+}
+
+// finish sends the held back response through the fault, and drops the
+// connection when the fault asks for it
+func (c *chaosWriter) finish() {
+	if !c.aborted && !c.done {
+		if c.stream {
+			// The stream ended without a final event to spoil
+			_, _ = c.ResponseWriter.Write(c.pending.Bytes())
+		} else {
+			if c.fault != FaultDropStream {
+				c.ResponseWriter.WriteHeader(c.status)
+			}
+			_ = c.inject(c.pending.Bytes())
+		}
+	}
+	if c.aborted {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// finalEvent tells whether an event ends its stream: a final status
+// update, a message or an error
+func finalEvent(event []byte) bool {
+	payload, ok := gateway.EventPayload(event)
+	if !ok {
+		return false
+	}
+	var response struct {
+		Result *struct {
+			Kind  string `json:"kind"`
+			Final bool   `json:"final"`
+		} `json:"result"`
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(payload, &response) != nil {
+		return false
+	}
+	if response.Error != nil {
+		return true
+	}
+	return response.Result != nil && (response.Result.Final || response.Result.Kind == "message")
+}
@@ -0,0 +1,275 @@
+package faker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Generator produces one fake value of a kind
+type Generator func(f *Faker) string
+
+// catalog maps the kinds of fake data to their generators
+var catalog = map[string]Generator{
+	"uuid":         func(f *Faker) string { return f.source.UUID() },
+	"name":         (*Faker).Name,
+	"first_name":   func(f *Faker) string { return f.pick(f.locale.FirstNames) },
+	"last_name":    func(f *Faker) string { return f.pick(f.locale.LastNames) },
+	"email":        (*Faker).Email,
+	"username":     (*Faker).Username,
+	"phone":        func(f *Faker) string { return f.format(f.locale.Phone) },
+	"address":      (*Faker).Address,
+	"street":       func(f *Faker) string { return f.pick(f.locale.Streets) },
+	"city":         func(f *Faker) string { return f.pick(f.locale.Cities) },
+	"postal_code":  func(f *Faker) string { return f.format(f.locale.PostalCode) },
+	"country":      func(f *Faker) string { return f.locale.Country },
+	"country_code": func(f *Faker) string { return f.locale.CountryCode },
+	"company":      (*Faker).Company,
+	"date":         func(f *Faker) string { return f.Time().Format(time.DateOnly) },
+	"datetime":     func(f *Faker) string { return f.Time().Format(time.RFC3339) },
+	"time":         func(f *Faker) string { return f.Time().Format(time.TimeOnly) },
+	"iban":         (*Faker).IBAN,
+	"ipv4":         (*Faker).IPv4,
+	"ipv6":         (*Faker).IPv6,
+	"mac_address":  (*Faker).MACAddress,
+	"domain":       (*Faker).Domain,
+	"url":          (*Faker).URL,
+	"word":         func(f *Faker) string { return f.pick(loremWords) },
+	"sentence":     func(f *Faker) string { return f.Sentence(4 + f.source.Intn(8)) },
+	"paragraph":    (*Faker).Paragraph,
+	"number":       func(f *Faker) string { return strconv.Itoa(f.source.Intn(1000000)) },
+	"boolean":      func(f *Faker) string { return strconv.FormatBool(f.source.Intn(2) == 1) },
+	"json":         (*Faker).JSON,
+}
+
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+	"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore",
+	"magna", "aliqua", "enim", "ad", "minim", "veniam", "quis", "nostrud",
+	"exercitation", "ullamco", "laboris", "nisi", "aliquip", "ex", "ea", "commodo",
+	"consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint",
+}
+
+// emailDomains are reserved for documentation, so generated addresses never reach anyone
+var emailDomains = []string{"example.com", "example.org", "example.net"}
+
+var (
+	// earliest and latest bound generated dates and times
+	earliest = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest   = time.Date(2030, 12, 31, 23, 59, 59, 0, time.UTC)
+)
+
+// Kinds lists the kinds of fake data in alphabetical order
+func Kinds() []string {
+	kinds := make([]string, 0, len(catalog))
+	for kind := range catalog {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Faker generates realistic fake data for a locale from a random source
+type Faker struct {
+	source *rng.Source
+	locale *Locale
+}
+
+// New creates a faker. An unknown locale falls back to DefaultLocale.
+func New(source *rng.Source, locale string) *Faker {
+	l, ok := locales[locale]
+	if !ok {
+		l = locales[DefaultLocale]
+	}
+	return &Faker{source: source, locale: l}
+}
+
+// Generate produces a value of the given kind, e.g. iban or address
+func (f *Faker) Generate(kind string) (string, error) {
+	generator, ok := catalog[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown data type %q: must be one of (%s)", kind, strings.Join(Kinds(), ", "))
+	}
+	return generator(f), nil
+}
+
+// Name returns a full name
+func (f *Faker) Name() string {
+	return f.pick(f.locale.FirstNames) + " " + f.pick(f.locale.LastNames)
+}
+
+// Email returns an address made of a name at a reserved domain
+func (f *Faker) Email() string {
+	local := slug(f.pick(f.locale.FirstNames)) + "." + slug(f.pick(f.locale.LastNames))
+	if f.source.Intn(2) == 0 {
+		local += strconv.Itoa(f.source.Intn(100))
+	}
+	return local + "@" + f.pick(emailDomains)
+}
+
+// Username returns a handle such as mary_smith42
+func (f *Faker) Username() string {
+	return slug(f.pick(f.locale.FirstNames)) + "_" + slug(f.pick(f.locale.LastNames)) + strconv.Itoa(f.source.Intn(100))
+}
+
+// Address returns a street address in the format of the locale
+func (f *Faker) Address() string {
+	return f.locale.Address(1+f.source.Intn(250), f.pick(f.locale.Streets), f.format(f.locale.PostalCode), f.pick(f.locale.Cities))
+}
+
+// Company returns a company name with a legal form
+func (f *Faker) Company() string {
+	return f.pick(f.locale.Companies) + " " + f.pick(f.locale.CompanySuffixes)
+}
+
+// Time returns a time between 1970 and 2030, in whole seconds
+func (f *Faker) Time() time.Time {
+	span := latest.Unix() - earliest.Unix()
+	return time.Unix(earliest.Unix()+int64(f.source.Float64()*float64(span)), 0).UTC()
+}
+
+// IBAN returns an IBAN with valid check digits. Locales of countries
+// without IBANs get a German one.
+func (f *Faker) IBAN() string {
+	country, length := f.locale.CountryCode, f.locale.IBANLength
+	if length == 0 {
+		country, length = "DE", 22
+	}
+
+	var bban strings.Builder
+	for i := 0; i < length-4; i++ {
+		// British IBANs start with the bank's four letter code
+		if country == "GB" && i < 4 {
+			bban.WriteByte(byte('A' + f.source.Intn(26)))
+			continue
+		}
+		bban.WriteByte(byte('0' + f.source.Intn(10)))
+	}
+
+	check := 98 - mod97(bban.String()+country+"00")
+	return fmt.Sprintf("%s%02d%s", country, check, bban.String())
+}
+
+// mod97 computes the IBAN checksum of s, reading letters as 10 to 35
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		digits := string(c)
+		if c >= 'A' && c <= 'Z' {
+			digits = strconv.Itoa(int(c-'A') + 10)
+		}
+		for _, d := range digits {
+			remainder = (remainder*10 + int(d-'0')) % 97
+		}
+	}
+	return remainder
+}
+
+// IPv4 returns an address from the ranges reserved for documentation
+func (f *Faker) IPv4() string {
+	networks := []string{"192.0.2", "198.51.100", "203.0.113"}
+	return fmt.Sprintf("%s.%d", f.pick(networks), 1+f.source.Intn(254))
+}
+
+// IPv6 returns an address from the prefix reserved for documentation
+func (f *Faker) IPv6() string {
+	groups := []string{"2001", "db8"}
+	for i := 0; i < 6; i++ {
+		groups = append(groups, strconv.FormatInt(int64(f.source.Intn(0x10000)), 16))
+	}
+	return strings.Join(groups, ":")
+}
+
+// MACAddress returns a locally administered unicast MAC address
+func (f *Faker) MACAddress() string {
+	octets := make([]string, 6)
+	for i := range octets {
+		b := f.source.Intn(256)
+		if i == 0 {
+			b = b&0xfc | 0x02
+		}
+		octets[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(octets, ":")
+}
+
+// Domain returns a domain under the reserved example TLD
+func (f *Faker) Domain() string {
+	return slug(f.pick(f.locale.Companies)) + ".example"
+}
+
+// URL returns an https URL on a fake domain
+func (f *Faker) URL() string {
+	return "https://www." + f.Domain() + "/" + f.pick(loremWords) + "/" + f.pick(loremWords)
+}
+
+// Sentence returns n lorem ipsum words as a sentence
+func (f *Faker) Sentence(n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = f.pick(loremWords)
+	}
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// Paragraph returns three to six sentences
+func (f *Faker) Paragraph() string {
+	sentences := make([]string, 3+f.source.Intn(4))
+	for i := range sentences {
+		sentences[i] = f.Sentence(4 + f.source.Intn(8))
+	}
+	return strings.Join(sentences, " ")
+}
+
+// JSON returns a small JSON object describing a person
+func (f *Faker) JSON() string {
+	data, _ := json.Marshal(map[string]any{
+		"id":     f.source.UUID(),
+		"name":   f.Name(),
+		"email":  f.Email(),
+		"city":   f.pick(f.locale.Cities),
+		"active": f.source.Intn(2) == 1,
+	})
+	return string(data)
+}
+
+func (f *Faker) pick(options []string) string {
+	return options[f.source.Intn(len(options))]
+}
+
+// format fills a locale format, replacing # with digits and ? with letters
+func (f *Faker) format(pattern string) string {
+	var b strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '#':
+			b.WriteByte(byte('0' + f.source.Intn(10)))
+		case '?':
+			b.WriteByte(byte('A' + f.source.Intn(26)))
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// slug lowercases s and drops everything but ASCII letters and digits
+func slug(s string) string {
+	replacer := strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "é", "e", "è", "e", "ë", "e")
+	s = replacer.Replace(strings.ToLower(s))
+
+	var b strings.Builder
+	for _, c := range s {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package faker
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	validation "github.com/inference-gateway/mock-agent/internal/validation"
+)
+
+func TestGenerateSeeded(t *testing.T) {
+	draw := func(seed int64, locale string) []string {
+		f := New(rng.New(seed, nil), locale)
+		var values []string
+		for range 3 {
+			for _, kind := range Kinds() {
+				value, err := f.Generate(kind)
+				if err != nil {
+					t.Fatal(err)
+				}
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	for _, locale := range Locales() {
+		t.Run(locale, func(t *testing.T) {
+			if a, b := draw(42, locale), draw(42, locale); !reflect.DeepEqual(a, b) {
+				t.Errorf("seed 42 generated different values:\n%v\n%v", a, b)
+			}
+			if a, b := draw(42, locale), draw(43, locale); reflect.DeepEqual(a, b) {
+				t.Error("seeds 42 and 43 generated the same values")
+			}
+		})
+	}
+}
+
+func TestGenerateUnknownKind(t *testing.T) {
+	if _, err := New(rng.New(1, nil), DefaultLocale).Generate("ssn"); err == nil || !strings.Contains(err.Error(), "iban") {
+		t.Errorf("Generate(ssn) error = %v, want one listing the kinds", err)
+	}
+}
+
+func TestGenerateFormats(t *testing.T) {
+	validators := validation.NewRegistry()
+	valid := func(name string) func(string) bool {
+		d, _ := validators.Lookup(name)
+		return func(value string) bool { return d.Validate(value, validation.Options{}) == nil }
+	}
+	matches := func(pattern string) func(string) bool {
+		return regexp.MustCompile(pattern).MatchString
+	}
+	parses := func(layout string) func(string) bool {
+		return func(value string) bool {
+			parsed, err := time.Parse(layout, value)
+			return err == nil && !parsed.Before(earliest) && !parsed.After(latest)
+		}
+	}
+
+	checks := map[string]func(string) bool{
+		"uuid":        valid("uuid"),
+		"email":       valid("email"),
+		"ipv4":        matches(`^(192\.0\.2|198\.51\.100|203\.0\.113)\.\d{1,3}$`),
+		"ipv6":        func(v string) bool { return valid("ipv6")(v) && strings.HasPrefix(v, "2001:db8:") },
+		"url":         func(v string) bool { return valid("url")(v) && strings.Contains(v, ".example/") },
+		"domain":      matches(`^[a-z0-9]+\.example$`),
+		"mac_address": matches(`^[0-9a-f][26ae](:[0-9a-f]{2}){5}$`),
+		"username":    matches(`^[a-z]+_[a-z]+\d{1,2}$`),
+		"date":        parses(time.DateOnly),
+		"datetime":    func(v string) bool { return parses(time.RFC3339)(v) && valid("date")(v) },
+		"time":        matches(`^\d{2}:\d{2}:\d{2}$`),
+		"sentence":    matches(`^[A-Z][a-z]*( [a-z]+){3,10}\.$`),
+		"number":      func(v string) bool { n, err := strconv.Atoi(v); return err == nil && n >= 0 && n < 1000000 },
+		"boolean":     func(v string) bool { return v == "true" || v == "false" },
+		"json":        valid("json"),
+	}
+
+	f := New(rng.New(7, nil), DefaultLocale)
+	for kind, check := range checks {
+		t.Run(kind, func(t *testing.T) {
+			for range 50 {
+				value, err := f.Generate(kind)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !check(value) {
+					t.Fatalf("Generate(%s) = %q, which is not a %s", kind, value, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestLocales(t *testing.T) {
+	// format turns a locale format into a regular expression
+	format := func(pattern string) *regexp.Regexp {
+		quoted := regexp.QuoteMeta(pattern)
+		quoted = strings.ReplaceAll(quoted, "#", `\d`)
+		quoted = strings.ReplaceAll(quoted, `\?`, `[A-Z]`)
+		return regexp.MustCompile("^" + quoted + "$")
+	}
+
+	for _, name := range Locales() {
+		t.Run(name, func(t *testing.T) {
+			locale, ok := LookupLocale(name)
+			if !ok {
+				t.Fatalf("LookupLocale(%s) found nothing", name)
+			}
+			f := New(rng.New(3, nil), name)
+
+			for range 20 {
+				if phone := f.format(locale.Phone); !format(locale.Phone).MatchString(phone) {
+					t.Errorf("phone %q does not match %q", phone, locale.Phone)
+				}
+				if code := f.format(locale.PostalCode); !format(locale.PostalCode).MatchString(code) {
+					t.Errorf("postal code %q does not match %q", code, locale.PostalCode)
+				}
+				if first, last, _ := strings.Cut(f.Name(), " "); !slices.Contains(locale.FirstNames, first) || !slices.Contains(locale.LastNames, last) {
+					t.Errorf("name %q %q is not made of names of the locale", first, last)
+				}
+				if address := f.Address(); !containsAny(address, locale.Cities) || !containsAny(address, locale.Streets) {
+					t.Errorf("address %q has no city or street of the locale", address)
+				}
+
+				iban := f.IBAN()
+				country, length := locale.CountryCode, locale.IBANLength
+				if length == 0 {
+					country, length = "DE", 22
+				}
+				if len(iban) != length || !strings.HasPrefix(iban, country) {
+					t.Errorf("IBAN %q is not %d characters starting with %s", iban, length, country)
+				}
+				if mod97(iban[4:]+iban[:4]) != 1 {
+					t.Errorf("IBAN %q has wrong check digits", iban)
+				}
+			}
+
+			for _, kind := range []string{"country", "country_code"} {
+				value, _ := f.Generate(kind)
+				if value != locale.Country && value != locale.CountryCode {
+					t.Errorf("Generate(%s) = %q, want the locale's country", kind, value)
+				}
+			}
+		})
+	}
+
+	if New(rng.New(1, nil), "xx_XX").locale != locales[DefaultLocale] {
+		t.Error("an unknown locale does not fall back to the default")
+	}
+}
+
+func containsAny(s string, values []string) bool {
+	for _, v := range values {
+		if strings.Contains(s, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package faker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultLocale is used when no locale is requested
+const DefaultLocale = "en_US"
+
+// Locale holds the words and formats fake data of one country is made of.
+// In formats, # stands for a digit and ? for an uppercase letter.
+type Locale struct {
+	Country     string
+	CountryCode string
+	FirstNames  []string
+	LastNames   []string
+	Streets     []string
+	Cities      []string
+	Companies   []string
+	// CompanySuffixes are legal forms, e.g. Inc. or GmbH
+	CompanySuffixes []string
+	PostalCode      string
+	Phone           string
+	// IBANLength is the length of the country's IBANs, 0 when it has none
+	IBANLength int
+	// Address renders a street address from its parts
+	Address func(number int, street, postalCode, city string) string
+}
+
+var locales = map[string]*Locale{
+	"en_US": {
+		Country:     "United States",
+		CountryCode: "US",
+		FirstNames:  []string{"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda", "David", "Elizabeth", "William", "Barbara", "Richard", "Susan", "Joseph", "Jessica"},
+		LastNames:   []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez", "Hernandez", "Lopez", "Wilson", "Anderson", "Taylor", "Thomas"},
+		Streets:     []string{"Main Street", "Oak Avenue", "Maple Drive", "Cedar Lane", "Park Avenue", "Washington Street", "Lake Road", "Hill Street", "Elm Street", "Sunset Boulevard"},
+		Cities:      []string{"Springfield", "Portland", "Austin", "Denver", "Madison", "Columbus", "Raleigh", "Boston", "Phoenix", "Seattle"},
+		Companies:   []string{"Acme", "Globex", "Initech", "Umbrella", "Stark", "Wayne", "Hooli", "Vandelay", "Cyberdyne", "Soylent"},
+		CompanySuffixes: []string{
+			"Inc.", "LLC", "Corp.", "Group",
+		},
+		PostalCode: "#####",
+		Phone:      "+1 (###) ###-####",
+		Address: func(number int, street, postalCode, city string) string {
+			return fmt.Sprintf("%d %s, %s %s", number, street, city, postalCode)
+		},
+	},
+	"en_GB": {
+		Country:     "United Kingdom",
+		CountryCode: "GB",
+		FirstNames:  []string{"Oliver", "Amelia", "George", "Isla", "Harry", "Ava", "Jack", "Emily", "Charlie", "Sophie", "Thomas", "Grace", "Alfie", "Lily", "Henry", "Freya"},
+		LastNames:   []string{"Smith", "Jones", "Taylor", "Brown", "Williams", "Wilson", "Johnson", "Davies", "Robinson", "Wright", "Thompson", "Evans", "Walker", "White", "Roberts", "Green"},
+		Streets:     []string{"High Street", "Station Road", "Church Lane", "Victoria Road", "Green Lane", "Manor Road", "Park Road", "Queens Road", "Mill Lane", "King Street"},
+		Cities:      []string{"London", "Manchester", "Birmingham", "Leeds", "Bristol", "Liverpool", "Sheffield", "Edinburgh", "Cardiff", "Oxford"},
+		Companies:   []string{"Albion", "Thames", "Britannia", "Crown", "Pennine", "Windsor", "Cotswold", "Mersey", "Highland", "Severn"},
+		CompanySuffixes: []string{
+			"Ltd", "PLC", "& Sons", "Holdings",
+		},
+		PostalCode: "??# #??",
+		Phone:      "+44 20 #### ####",
+		IBANLength: 22,
+		Address: func(number int, street, postalCode, city string) string {
+			return fmt.Sprintf("%d %s, %s %s", number, street, city, postalCode)
+		},
+	},
+	"de_DE": {
+		Country:     "Deutschland",
+		CountryCode: "DE",
+		FirstNames:  []string{"Lukas", "Anna", "Leon", "Lea", "Finn", "Hannah", "Jonas", "Mia", "Paul", "Emma", "Felix", "Sophie", "Maximilian", "Marie", "Elias", "Lena"},
+		LastNames:   []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker", "Schulz", "Hoffmann", "Koch", "Richter", "Klein", "Wolf", "Schröder", "Neumann"},
+		Streets:     []string{"Hauptstraße", "Bahnhofstraße", "Gartenstraße", "Schulstraße", "Dorfstraße", "Bergstraße", "Lindenstraße", "Kirchweg", "Am Markt", "Birkenweg"},
+		Cities:      []string{"Berlin", "Hamburg", "München", "Köln", "Frankfurt am Main", "Stuttgart", "Düsseldorf", "Leipzig", "Dresden", "Hannover"},
+		Companies:   []string{"Nordwind", "Alpen", "Rheintal", "Hanse", "Schwarzwald", "Elbe", "Brandt", "Keller", "Sonnen", "Ostsee"},
+		CompanySuffixes: []string{
+			"GmbH", "AG", "KG", "GmbH & Co. KG",
+		},
+		PostalCode: "#####",
+		Phone:      "+49 30 ########",
+		IBANLength: 22,
+		Address: func(number int, street, postalCode, city string) string {
+			return fmt.Sprintf("%s %d, %s %s", street, number, postalCode, city)
+		},
+	},
+	"fr_FR": {
+		Country:     "France",
+		CountryCode: "FR",
+		FirstNames:  []string{"Gabriel", "Jade", "Louis", "Louise", "Raphaël", "Emma", "Jules", "Alice", "Adam", "Chloé", "Lucas", "Lina", "Léo", "Rose", "Hugo", "Léa"},
+		LastNames:   []string{"Martin", "Bernard", "Dubois", "Thomas", "Robert", "Richard", "Petit", "Durand", "Leroy", "Moreau", "Simon", "Laurent", "Lefebvre", "Michel", "Garcia", "David"},
+		Streets:     []string{"rue de la Paix", "avenue des Champs", "rue Victor Hugo", "boulevard Saint-Michel", "rue du Moulin", "place de la République", "rue de l'Église", "chemin des Vignes", "rue Pasteur", "allée des Tilleuls"},
+		Cities:      []string{"Paris", "Lyon", "Marseille", "Toulouse", "Nice", "Nantes", "Strasbourg", "Bordeaux", "Lille", "Rennes"},
+		Companies:   []string{"Lumière", "Provence", "Atlantique", "Horizon", "Mistral", "Loire", "Azur", "Bretagne", "Étoile", "Vallée"},
+		CompanySuffixes: []string{
+			"SA", "SARL", "SAS", "et Fils",
+		},
+		PostalCode: "#####",
+		Phone:      "+33 1 ## ## ## ##",
+		IBANLength: 27,
+		Address: func(number int, street, postalCode, city string) string {
+			return fmt.Sprintf("%d %s, %s %s", number, street, postalCode, city)
+		},
+	},
+}
+
+// LookupLocale returns the locale with the given name, e.g. de_DE
+func LookupLocale(name string) (*Locale, bool) {
+	locale, ok := locales[name]
+	return locale, ok
+}
+
+// Locales lists the supported locale names in alphabetical order
+func Locales() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
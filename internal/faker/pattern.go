@@ -0,0 +1,113 @@
+package faker
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// maxRepeat bounds unbounded repetitions like * and + in patterns
+const maxRepeat = 5
+
+// Pattern returns a string that matches the regular expression pattern
+func (f *Faker) Pattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var b strings.Builder
+	if err := f.writeMatch(&b, re.Simplify()); err != nil {
+		return "", fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	return b.String(), nil
+}
+
+func (f *Faker) writeMatch(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil
+
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			b.WriteRune(r)
+		}
+		return nil
+
+	case syntax.OpCharClass:
+		b.WriteRune(f.classRune(re.Rune))
+		return nil
+
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune(rune('a' + f.source.Intn(26)))
+		return nil
+
+	case syntax.OpCapture:
+		return f.writeMatch(b, re.Sub[0])
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := f.writeMatch(b, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case syntax.OpAlternate:
+		return f.writeMatch(b, re.Sub[f.source.Intn(len(re.Sub))])
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lower, upper := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			lower, upper = 0, -1
+		case syntax.OpPlus:
+			lower, upper = 1, -1
+		case syntax.OpQuest:
+			lower, upper = 0, 1
+		}
+		if upper < 0 {
+			upper = lower + maxRepeat
+		}
+
+		n := lower + f.source.Intn(upper-lower+1)
+		for i := 0; i < n; i++ {
+			if err := f.writeMatch(b, re.Sub[0]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported syntax %s", re)
+}
+
+// classRune picks a rune from a character class given as ranges, preferring
+// printable ASCII so values stay readable
+func (f *Faker) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], ' '), min(ranges[i+1], '~')
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	n := f.source.Intn(total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n)
+		}
+		n -= size
+	}
+	return ranges[0]
+}
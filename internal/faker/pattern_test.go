@@ -0,0 +1,52 @@
+package faker
+
+import (
+	"regexp"
+	"testing"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+func TestPattern(t *testing.T) {
+	patterns := []string{
+		`^[A-Z]{3}-\d{4}$`,
+		`^(red|green|blue)$`,
+		`^[a-z]+@[a-z]+\.(com|org)$`,
+		`^\w{2,5}\s?\d*$`,
+		`^ID-[0-9a-f]{8}(-[0-9a-f]{4})?$`,
+		`^.{3}$`,
+		`^[^a-z]{4}$`,
+		`\bv\d+\.\d+\b`,
+		`^(?:ab)+c?$`,
+	}
+
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			re := regexp.MustCompile(pattern)
+			f := New(rng.New(11, nil), DefaultLocale)
+			for range 50 {
+				value, err := f.Pattern(pattern)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !re.MatchString(value) {
+					t.Fatalf("Pattern(%s) = %q, which does not match", pattern, value)
+				}
+			}
+
+			a, _ := New(rng.New(5, nil), DefaultLocale).Pattern(pattern)
+			b, _ := New(rng.New(5, nil), DefaultLocale).Pattern(pattern)
+			if a != b {
+				t.Errorf("seed 5 generated %q and %q", a, b)
+			}
+		})
+	}
+}
+
+func TestPatternErrors(t *testing.T) {
+	for _, pattern := range []string{`[a-`, `(a`, `a{2,1}`} {
+		if _, err := New(rng.New(1, nil), DefaultLocale).Pattern(pattern); err == nil {
+			t.Errorf("Pattern(%s) accepted an invalid pattern", pattern)
+		}
+	}
+}
@@ -0,0 +1,570 @@
+package faker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+)
+
+// maxDepth bounds nesting, so recursive schemas still produce finite records
+const maxDepth = 8
+
+// formatKinds map the string formats of JSON Schema to catalog kinds
+var formatKinds = map[string]string{
+	"email":         "email",
+	"idn-email":     "email",
+	"uuid":          "uuid",
+	"date":          "date",
+	"date-time":     "datetime",
+	"time":          "time",
+	"uri":           "url",
+	"uri-reference": "url",
+	"iri":           "url",
+	"url":           "url",
+	"hostname":      "domain",
+	"idn-hostname":  "domain",
+	"ipv4":          "ipv4",
+	"ipv6":          "ipv6",
+}
+
+// nameKinds guess what a string property holds from the words of its
+// name. They are tried in order, so email_address is an email and not an address.
+var nameKinds = []struct {
+	words []string
+	kind  string
+}{
+	{[]string{"email", "mail"}, "email"},
+	{[]string{"phone", "mobile", "tel", "telephone", "fax"}, "phone"},
+	{[]string{"first name", "firstname", "given name", "forename"}, "first_name"},
+	{[]string{"last name", "lastname", "surname", "family name"}, "last_name"},
+	{[]string{"username", "user name", "login", "handle"}, "username"},
+	{[]string{"company", "organization", "organisation", "employer", "business"}, "company"},
+	{[]string{"iban"}, "iban"},
+	{[]string{"ipv6"}, "ipv6"},
+	{[]string{"ip", "ipv4"}, "ipv4"},
+	{[]string{"mac"}, "mac_address"},
+	{[]string{"url", "uri", "website", "homepage", "link"}, "url"},
+	{[]string{"domain", "hostname", "host"}, "domain"},
+	{[]string{"street"}, "street"},
+	{[]string{"zip", "zipcode", "postal", "postcode"}, "postal_code"},
+	{[]string{"city", "town"}, "city"},
+	{[]string{"country code"}, "country_code"},
+	{[]string{"country"}, "country"},
+	{[]string{"address"}, "address"},
+	{[]string{"uuid", "guid", "id"}, "uuid"},
+	{[]string{"name"}, "name"},
+	{[]string{"at", "timestamp", "datetime"}, "datetime"},
+	{[]string{"date", "birthday", "dob", "birth"}, "date"},
+	{[]string{"time"}, "time"},
+	{[]string{"description", "bio", "body", "content", "text"}, "paragraph"},
+	{[]string{"title", "summary", "comment", "note", "notes", "message", "subject"}, "sentence"},
+}
+
+// numberRange is the default range of a numeric property
+type numberRange struct {
+	words    []string
+	min, max float64
+}
+
+// nameRanges guess sensible numbers from the words of a property name
+var nameRanges = []numberRange{
+	{[]string{"age"}, 18, 90},
+	{[]string{"year"}, 1970, 2030},
+	{[]string{"count", "quantity", "qty"}, 1, 100},
+	{[]string{"price", "amount", "total", "cost", "balance", "salary"}, 1, 10000},
+	{[]string{"rating", "stars"}, 1, 5},
+	{[]string{"percent", "percentage", "score"}, 0, 100},
+}
+
+// RecordGenerator produces records that conform to a JSON Schema
+type RecordGenerator struct {
+	faker *Faker
+	root  map[string]any
+	next  int
+}
+
+// Records returns a generator of records that conform to the JSON Schema.
+// Besides the keywords the skills validate, it understands format,
+// $ref into $defs or definitions, allOf, anyOf, oneOf and x-faker, which
+// names the catalog kind of a string, e.g. {"type": "string", "x-faker": "iban"}.
+func (f *Faker) Records(recordSchema map[string]any) (*RecordGenerator, error) {
+	data, err := json.Marshal(recordSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	root := map[string]any{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return &RecordGenerator{faker: f, root: root}, nil
+}
+
+// Next generates the next record. Integer id properties of the record
+// count up from 1.
+func (g *RecordGenerator) Next() (any, error) {
+	g.next++
+	value, err := g.generate(g.root, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if verr := schema.ValidateValue(g.root, value); verr != nil {
+		messages := make([]string, 0, len(verr.Violations))
+		for _, v := range verr.Violations {
+			messages = append(messages, strings.TrimSpace(v.Field+" "+v.Message))
+		}
+		return nil, fmt.Errorf("cannot generate a record that conforms to the schema: %s", strings.Join(messages, "; "))
+	}
+	return value, nil
+}
+
+func (g *RecordGenerator) generate(s map[string]any, name string, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("schema nests deeper than %d levels", maxDepth)
+	}
+
+	s, err := g.resolve(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, ok := s["const"]; ok {
+		return c, nil
+	}
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		return enum[g.faker.source.Intn(len(enum))], nil
+	}
+
+	switch schemaType(s) {
+	case "object":
+		return g.object(s, depth)
+	case "array":
+		return g.array(s, name, depth)
+	case "integer":
+		return g.number(s, name, depth, true)
+	case "number":
+		return g.number(s, name, depth, false)
+	case "boolean":
+		return g.faker.source.Intn(2) == 1, nil
+	case "null":
+		return nil, nil
+	default:
+		return g.string(s, name)
+	}
+}
+
+// resolve follows $ref and combines allOf, anyOf and oneOf into a plain schema
+func (g *RecordGenerator) resolve(s map[string]any) (map[string]any, error) {
+	for range maxDepth {
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			break
+		}
+		target, err := g.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+		s = merge(without(s, "$ref"), target)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		merged := without(s, "allOf")
+		for _, sub := range all {
+			if sub, ok := sub.(map[string]any); ok {
+				resolved, err := g.resolve(sub)
+				if err != nil {
+					return nil, err
+				}
+				merged = merge(merged, resolved)
+			}
+		}
+		s = merged
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		options, ok := s[keyword].([]any)
+		if !ok || len(options) == 0 {
+			continue
+		}
+		option, ok := options[g.faker.source.Intn(len(options))].(map[string]any)
+		if !ok {
+			continue
+		}
+		resolved, err := g.resolve(option)
+		if err != nil {
+			return nil, err
+		}
+		s = merge(without(s, keyword), resolved)
+	}
+	return s, nil
+}
+
+// lookup finds a local reference such as #/$defs/address
+func (g *RecordGenerator) lookup(ref string) (map[string]any, error) {
+	path, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %q: only local references are supported", ref)
+	}
+
+	var node any = g.root
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+		node = object[segment]
+	}
+
+	target, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable reference %q", ref)
+	}
+	return target, nil
+}
+
+func (g *RecordGenerator) object(s map[string]any, depth int) (any, error) {
+	properties, _ := s["properties"].(map[string]any)
+
+	// Sorted so seeded records come out the same on every run
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := properties[name]; !exists {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+
+	record := make(map[string]any, len(names))
+	for _, name := range names {
+		property, _ := properties[name].(map[string]any)
+		if property == nil {
+			property = map[string]any{"type": "string"}
+		}
+		value, err := g.generate(property, name, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		record[name] = value
+	}
+	return record, nil
+}
+
+func (g *RecordGenerator) array(s map[string]any, name string, depth int) (any, error) {
+	lower := 1
+	if n, ok := s["minItems"].(float64); ok {
+		lower = int(n)
+	}
+	upper := lower + 3
+	if n, ok := s["maxItems"].(float64); ok && int(n) < upper {
+		upper = int(n)
+	}
+	if upper < lower {
+		return nil, fmt.Errorf("%s: minItems exceeds maxItems", label(name))
+	}
+
+	items, _ := s["items"].(map[string]any)
+	if items == nil {
+		items = map[string]any{"type": "string"}
+	}
+	unique, _ := s["uniqueItems"].(bool)
+
+	n := lower + g.faker.source.Intn(upper-lower+1)
+	values := make([]any, 0, n)
+	seen := map[string]bool{}
+	for attempts := 0; len(values) < n && attempts < n*10; attempts++ {
+		value, err := g.generate(items, singular(name), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if unique {
+			key, _ := json.Marshal(value)
+			if seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (g *RecordGenerator) number(s map[string]any, name string, depth int, integer bool) (any, error) {
+	if integer && depth == 1 && strings.EqualFold(name, "id") {
+		if fits(s, float64(g.next)) {
+			return g.next, nil
+		}
+	}
+
+	lower, upper := 0.0, 1000.0
+	words := nameWords(name)
+	for _, r := range nameRanges {
+		if hasAnyWords(words, r.words) {
+			lower, upper = r.min, r.max
+			break
+		}
+	}
+
+	span := upper - lower
+	minimum, hasMin := s["minimum"].(float64)
+	maximum, hasMax := s["maximum"].(float64)
+	if v, ok := s["exclusiveMinimum"].(float64); ok && (!hasMin || v >= minimum) {
+		minimum, hasMin = nextAfter(v, integer, 1), true
+	}
+	if v, ok := s["exclusiveMaximum"].(float64); ok && (!hasMax || v <= maximum) {
+		maximum, hasMax = nextAfter(v, integer, -1), true
+	}
+	switch {
+	case hasMin && hasMax:
+		lower, upper = minimum, maximum
+	case hasMin:
+		lower = minimum
+		if upper < lower {
+			upper = lower + span
+		}
+	case hasMax:
+		upper = maximum
+		if lower > upper {
+			lower = upper - span
+		}
+	}
+
+	step := 0.01
+	if integer {
+		step = 1
+	}
+	if m, ok := s["multipleOf"].(float64); ok && m > 0 {
+		step = m
+	}
+
+	first, last := math.Ceil(lower/step-1e-9), math.Floor(upper/step+1e-9)
+	if first > last {
+		return nil, fmt.Errorf("%s: no %s fits between %v and %v", label(name), schemaType(s), lower, upper)
+	}
+	k := first + float64(g.faker.source.Intn(int(min(last-first, 1e9))+1))
+	value := k * step
+	if integer {
+		return int64(math.Round(value)), nil
+	}
+	return math.Round(value*1e6) / 1e6, nil
+}
+
+func (g *RecordGenerator) string(s map[string]any, name string) (any, error) {
+	var value string
+	kind, _ := s["x-faker"].(string)
+	if kind == "" {
+		format, _ := s["format"].(string)
+		kind = formatKinds[format]
+	}
+
+	switch {
+	case kind != "":
+		generated, err := g.faker.Generate(kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", label(name), err)
+		}
+		value = generated
+
+	case s["pattern"] != nil:
+		pattern, _ := s["pattern"].(string)
+		generated, err := g.faker.Pattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", label(name), err)
+		}
+		return generated, nil
+
+	default:
+		value = g.faker.Sentence(1 + g.faker.source.Intn(3))
+		value = strings.TrimSuffix(value, ".")
+		words := nameWords(name)
+		for _, guess := range nameKinds {
+			if hasAnyWords(words, guess.words) {
+				value, _ = g.faker.Generate(guess.kind)
+				break
+			}
+		}
+	}
+
+	length := len([]rune(value))
+	if n, ok := s["minLength"].(float64); ok {
+		for length < int(n) {
+			value += " " + g.faker.pick(loremWords)
+			length = len([]rune(value))
+		}
+	}
+	if n, ok := s["maxLength"].(float64); ok && length > int(n) {
+		value = strings.TrimRight(string([]rune(value)[:int(n)]), " ")
+	}
+	return value, nil
+}
+
+// schemaType is the type a value is generated as: the first non-null type
+// of the schema, or what its keywords suggest when it has none
+func schemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+		return "null"
+	}
+
+	switch {
+	case s["properties"] != nil:
+		return "object"
+	case s["items"] != nil:
+		return "array"
+	}
+	return "string"
+}
+
+// fits reports whether v satisfies the numeric bounds of s
+func fits(s map[string]any, v float64) bool {
+	if n, ok := s["minimum"].(float64); ok && v < n {
+		return false
+	}
+	if n, ok := s["maximum"].(float64); ok && v > n {
+		return false
+	}
+	if n, ok := s["exclusiveMinimum"].(float64); ok && v <= n {
+		return false
+	}
+	if n, ok := s["exclusiveMaximum"].(float64); ok && v >= n {
+		return false
+	}
+	if n, ok := s["multipleOf"].(float64); ok && n > 0 && math.Mod(v, n) != 0 {
+		return false
+	}
+	return true
+}
+
+// nextAfter is the closest value past an exclusive bound in direction
+func nextAfter(bound float64, integer bool, direction float64) float64 {
+	if integer {
+		if direction > 0 {
+			return math.Floor(bound) + 1
+		}
+		return math.Ceil(bound) - 1
+	}
+	return bound + direction*0.01
+}
+
+// nameWords splits a property name such as dateOfBirth or first_name into lowercase words
+func nameWords(name string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			flush()
+			current = append(current, unicode.ToLower(r))
+		default:
+			current = append(current, unicode.ToLower(r))
+		}
+	}
+	flush()
+	return words
+}
+
+// hasAnyWords reports whether words contain one of the candidates, which
+// may span several words, e.g. "first name"
+func hasAnyWords(words []string, candidates []string) bool {
+	joined := " " + strings.Join(words, " ") + " "
+	for _, candidate := range candidates {
+		if strings.Contains(joined, " "+candidate+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// label names a property in errors, the record itself when it has no name
+func label(name string) string {
+	if name == "" {
+		return "record"
+	}
+	return name
+}
+
+// singular names the items of an array property, e.g. tags becomes tag
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"):
+		return name
+	}
+	return strings.TrimSuffix(name, "s")
+}
+
+func merge(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		switch k {
+		case "properties":
+			properties := map[string]any{}
+			if existing, ok := merged[k].(map[string]any); ok {
+				for name, p := range existing {
+					properties[name] = p
+				}
+			}
+			if added, ok := v.(map[string]any); ok {
+				for name, p := range added {
+					properties[name] = p
+				}
+			}
+			merged[k] = properties
+		case "required":
+			existing, _ := merged[k].([]any)
+			added, _ := v.([]any)
+			merged[k] = append(append([]any{}, existing...), added...)
+		default:
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func without(s map[string]any, keyword string) map[string]any {
+	result := make(map[string]any, len(s))
+	for k, v := range s {
+		if k != keyword {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package faker
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+)
+
+// decode reads a schema written as JSON, the way schemas reach the skills
+func decode(t *testing.T, document string) map[string]any {
+	t.Helper()
+	var s map[string]any
+	if err := json.Unmarshal([]byte(document), &s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestRecordsConform(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"formats", `{"type": "object", "properties": {
+			"id": {"type": "integer"},
+			"email": {"type": "string", "format": "email"},
+			"website": {"type": "string", "format": "uri"},
+			"born": {"type": "string", "format": "date"},
+			"seen": {"type": "string", "format": "date-time"},
+			"host": {"type": "string", "format": "ipv4"},
+			"key": {"type": "string", "format": "uuid"}
+		}, "required": ["id", "email"]}`},
+		{"bounds", `{"type": "object", "properties": {
+			"age": {"type": "integer", "minimum": 21, "maximum": 30},
+			"price": {"type": "number", "exclusiveMinimum": 0, "maximum": 5, "multipleOf": 0.5},
+			"rating": {"type": "integer", "exclusiveMaximum": 3},
+			"code": {"type": "string", "minLength": 20, "maxLength": 25},
+			"short": {"type": "string", "maxLength": 3}
+		}}`},
+		{"enum, const and pattern", `{"type": "object", "properties": {
+			"status": {"enum": ["open", "closed"]},
+			"kind": {"const": "ticket"},
+			"ref": {"type": "string", "pattern": "^[A-Z]{2}-\\d{3}$"},
+			"iban": {"type": "string", "x-faker": "iban"}
+		}, "additionalProperties": false}`},
+		{"arrays", `{"type": "object", "properties": {
+			"tags": {"type": "array", "items": {"enum": ["a", "b", "c", "d"]}, "minItems": 2, "maxItems": 3, "uniqueItems": true},
+			"scores": {"type": "array", "items": {"type": "number", "minimum": 0, "maximum": 1}}
+		}}`},
+		{"references and combinators", `{
+			"$defs": {"address": {"type": "object", "properties": {"city": {"type": "string"}, "zip": {"type": "string"}}, "required": ["city"]}},
+			"type": "object",
+			"properties": {
+				"home": {"$ref": "#/$defs/address"},
+				"contact": {"oneOf": [
+					{"type": "object", "properties": {"phone": {"type": "string"}}, "required": ["phone"]},
+					{"type": "object", "properties": {"email": {"type": "string", "format": "email"}}, "required": ["email"]}
+				]},
+				"named": {"allOf": [
+					{"type": "object", "properties": {"first_name": {"type": "string"}}, "required": ["first_name"]},
+					{"properties": {"last_name": {"type": "string"}}, "required": ["last_name"]}
+				]},
+				"note": {"type": ["string", "null"]}
+			}
+		}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := decode(t, tt.schema)
+			records, err := New(rng.New(9, nil), DefaultLocale).Records(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range 25 {
+				record, err := records.Next()
+				if err != nil {
+					t.Fatalf("record %d: %v", i, err)
+				}
+				if verr := schema.ValidateValue(s, record); verr != nil {
+					t.Fatalf("record %d does not conform: %v", i, verr)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsSeeded(t *testing.T) {
+	s := decode(t, `{"type": "object", "properties": {
+		"id": {"type": "integer"},
+		"name": {"type": "string"},
+		"email": {"type": "string"},
+		"city": {"type": "string"},
+		"age": {"type": "integer"},
+		"tags": {"type": "array", "items": {"type": "string"}}
+	}}`)
+	draw := func(seed int64) []any {
+		records, err := New(rng.New(seed, nil), "de_DE").Records(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values []any
+		for range 5 {
+			record, err := records.Next()
+			if err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, record)
+		}
+		return values
+	}
+
+	a := draw(42)
+	if b := draw(42); !reflect.DeepEqual(a, b) {
+		t.Errorf("seed 42 generated different records:\n%v\n%v", a, b)
+	}
+	if b := draw(43); reflect.DeepEqual(a, b) {
+		t.Error("seeds 42 and 43 generated the same records")
+	}
+	for i, record := range a {
+		if id := record.(map[string]any)["id"]; id != i+1 {
+			t.Errorf("record %d has id %v, want %d", i, id, i+1)
+		}
+	}
+}
+
+func TestRecordsNames(t *testing.T) {
+	s := decode(t, `{"type": "object", "properties": {
+		"contactEmail": {"type": "string"},
+		"firstName": {"type": "string"},
+		"age": {"type": "integer"},
+		"created_at": {"type": "string"}
+	}}`)
+	records, err := New(rng.New(2, nil), DefaultLocale).Records(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locale, _ := LookupLocale(DefaultLocale)
+	for range 20 {
+		value, err := records.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		record := value.(map[string]any)
+		if email := record["contactEmail"].(string); !strings.Contains(email, "@example.") {
+			t.Errorf("contactEmail = %q, want an email address", email)
+		}
+		if first := record["firstName"].(string); !containsAny(first, locale.FirstNames) {
+			t.Errorf("firstName = %q, want a first name", first)
+		}
+		if age := record["age"].(int64); age < 18 || age > 90 {
+			t.Errorf("age = %d, want 18 to 90", age)
+		}
+		if created := record["created_at"].(string); !strings.Contains(created, "T") {
+			t.Errorf("created_at = %q, want a date-time", created)
+		}
+	}
+}
+
+func TestRecordsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{"no number in range", `{"type": "integer", "minimum": 5, "maximum": 4}`, "no integer fits"},
+		{"items out of order", `{"type": "array", "minItems": 3, "maxItems": 1}`, "minItems exceeds maxItems"},
+		{"remote reference", `{"$ref": "https://example.com/schema.json"}`, "only local references"},
+		{"missing reference", `{"$ref": "#/$defs/missing"}`, "unresolvable reference"},
+		{"recursion", `{"$defs": {"node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/node"}}, "required": ["next"]}}, "$ref": "#/$defs/node"}`, "deeper than"},
+		{"unknown kind", `{"type": "string", "x-faker": "ssn"}`, "unknown data type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := New(rng.New(1, nil), DefaultLocale).Records(decode(t, tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := records.Next(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Next() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package filegen
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/adler32"
+	"hash/crc32"
+	"io"
+	"time"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+const (
+	// pngOverhead is the signature, the IHDR, IDAT and IEND chunks and the
+	// zlib header and checksum around the pixel data
+	pngOverhead = 8 + 25 + 12 + 12 + 6
+	// pngMinSize is a one pixel image
+	pngMinSize = pngOverhead + 2 + 5
+	// storedBlockSize is the most data a stored deflate block holds
+	storedBlockSize = 65535
+	// pngPaddingChunk is a private ancillary chunk decoders skip
+	pngPaddingChunk = "paDd"
+)
+
+// png is a grayscale image of random pixels, stored uncompressed so its
+// size is known up front. A padding chunk makes up the difference to size.
+func (g *generator) png(size int) ([]byte, error) {
+	width := 15
+	if size > 4096 {
+		width = 1023
+	}
+	height := max(1, (size-pngOverhead)/(width+1))
+	for height > 0 {
+		rest := size - pngLength(height*(width+1))
+		if rest == 0 || rest >= 12 {
+			break
+		}
+		height--
+	}
+	if height == 0 {
+		// A single row as wide as the size allows
+		height = 1
+		width = size - pngMinSize + 1
+	}
+
+	raw := height * (width + 1)
+	padding := size - pngLength(raw)
+
+	var b bytes.Buffer
+	b.Grow(size)
+	b.WriteString("\x89PNG\r\n\x1a\n")
+
+	header := make([]byte, 13)
+	binary.BigEndian.PutUint32(header[0:], uint32(width))
+	binary.BigEndian.PutUint32(header[4:], uint32(height))
+	header[8] = 8 // bit depth; color type, compression, filter and interlace are 0
+	writeChunk(&b, "IHDR", header)
+
+	// The pixel data is a zlib stream of stored blocks, a filter byte of 0
+	// leading every row
+	blocks := (raw + storedBlockSize - 1) / storedBlockSize
+	chunk := startChunk(&b, "IDAT", 6+raw+5*blocks)
+	checksum := adler32.New()
+	chunk.Write([]byte{0x78, 0x01})
+	for offset := 0; offset < raw; offset += storedBlockSize {
+		n := min(storedBlockSize, raw-offset)
+		block := []byte{0, byte(n), byte(n >> 8), ^byte(n), ^byte(n >> 8)}
+		if offset+n == raw {
+			block[0] = 1
+		}
+		chunk.Write(block)
+
+		data := g.random(n)
+		for i := (width + 1 - offset%(width+1)) % (width + 1); i < n; i += width + 1 {
+			data[i] = 0
+		}
+		checksum.Write(data)
+		chunk.Write(data)
+	}
+	chunk.Write(checksum.Sum(nil))
+	chunk.end()
+
+	if padding > 0 {
+		writeChunk(&b, pngPaddingChunk, make([]byte, padding-12))
+	}
+	writeChunk(&b, "IEND", nil)
+	return b.Bytes(), nil
+}
+
+// pngLength is the size of an image with raw bytes of filtered pixel data
+func pngLength(raw int) int {
+	return pngOverhead + raw + 5*((raw+storedBlockSize-1)/storedBlockSize)
+}
+
+func writeChunk(b *bytes.Buffer, kind string, data []byte) {
+	chunk := startChunk(b, kind, len(data))
+	chunk.Write(data)
+	chunk.end()
+}
+
+// pngChunk writes the data of a chunk and its checksum
+type pngChunk struct {
+	b   *bytes.Buffer
+	crc io.Writer
+	sum func() uint32
+}
+
+func startChunk(b *bytes.Buffer, kind string, length int) *pngChunk {
+	_ = binary.Write(b, binary.BigEndian, uint32(length))
+	crc := crc32.NewIEEE()
+	chunk := &pngChunk{b: b, crc: crc, sum: crc.Sum32}
+	chunk.Write([]byte(kind))
+	return chunk
+}
+
+func (c *pngChunk) Write(data []byte) {
+	c.b.Write(data)
+	_, _ = c.crc.Write(data)
+}
+
+func (c *pngChunk) end() {
+	_ = binary.Write(c.b, binary.BigEndian, c.sum())
+}
+
+// pdf is a one page document whose content stream is made of comment lines
+// of random hex, so the page renders blank. Lengths and offsets are written
+// with leading zeros, which keeps the size of the skeleton fixed.
+func (g *generator) pdf(size int) ([]byte, error) {
+	content := size - pdfMinSize()
+
+	var b bytes.Buffer
+	b.Grow(size)
+	for _, part := range pdfSkeleton(content) {
+		if part != nil {
+			b.Write(part)
+			continue
+		}
+		for written := 0; written < content; {
+			line := "% " + hex.EncodeToString(g.random(32)) + "\n"
+			n := min(len(line), content-written)
+			b.WriteString(line[:n])
+			written += n
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// pdfSkeleton returns a document around a content stream of the given
+// length, with nil standing in for the stream data
+func pdfSkeleton(content int) [][]byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R >>",
+	}
+
+	head := "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"
+	offsets := make([]int, 0, len(objects)+1)
+	for i, object := range objects {
+		offsets = append(offsets, len(head))
+		head += fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	offsets = append(offsets, len(head))
+	head += fmt.Sprintf("4 0 obj\n<< /Length %010d >>\nstream\n", content)
+
+	tail := "\nendstream\nendobj\n"
+	xref := len(head) + content + len(tail)
+	tail += fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		tail += fmt.Sprintf("%010d 00000 n \n", offset)
+	}
+	tail += fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%010d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return [][]byte{[]byte(head), nil, []byte(tail)}
+}
+
+// pdfMinSize is a document with an empty content stream
+func pdfMinSize() int {
+	size := 0
+	for _, part := range pdfSkeleton(0) {
+		size += len(part)
+	}
+	return size
+}
+
+// zipEntry is the file inside generated zip archives
+const zipEntry = "data.bin"
+
+// zip is an archive holding one uncompressed file of random bytes
+func (g *generator) zip(size int) ([]byte, error) {
+	return zipArchive(g.random(size-zipMinSize()), g.source.Now())
+}
+
+func zipArchive(content []byte, modified time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	entry, err := w.CreateHeader(&zip.FileHeader{Name: zipEntry, Method: zip.Store, Modified: modified})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := entry.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// zipMinSize is an archive with an empty file
+func zipMinSize() int {
+	archive, _ := zipArchive(nil, rng.DefaultEpoch)
+	return len(archive)
+}
@@ -0,0 +1,111 @@
+package filegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	faker "github.com/inference-gateway/mock-agent/internal/faker"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// MaxSize bounds the files one call generates, which are built in memory
+const MaxSize = 512 << 20
+
+// poolSize is how many distinct records or paragraphs a text file cycles
+// through, so large files do not cost a faker call per line
+const poolSize = 256
+
+// Format is a kind of file the generator produces
+type Format struct {
+	Name      string
+	Extension string
+	MIMEType  string
+	// MinSize is the smallest file of the format, in bytes
+	MinSize int
+	// Text formats are UTF-8 and can be delivered as text parts
+	Text     bool
+	generate func(g *generator, size int) ([]byte, error)
+}
+
+var formats = map[string]*Format{
+	"json":     {Name: "json", Extension: ".json", MIMEType: "application/json", MinSize: 2, Text: true, generate: (*generator).json},
+	"csv":      {Name: "csv", Extension: ".csv", MIMEType: "text/csv", MinSize: len(csvHeader), Text: true, generate: (*generator).csv},
+	"markdown": {Name: "markdown", Extension: ".md", MIMEType: "text/markdown", MinSize: len(markdownTitle), Text: true, generate: (*generator).markdown},
+	"text":     {Name: "text", Extension: ".txt", MIMEType: "text/plain", MinSize: 1, Text: true, generate: (*generator).text},
+	"png":      {Name: "png", Extension: ".png", MIMEType: "image/png", MinSize: pngMinSize, generate: (*generator).png},
+	"pdf":      {Name: "pdf", Extension: ".pdf", MIMEType: "application/pdf", MinSize: pdfMinSize(), generate: (*generator).pdf},
+	"zip":      {Name: "zip", Extension: ".zip", MIMEType: "application/zip", MinSize: zipMinSize(), generate: (*generator).zip},
+	"binary":   {Name: "binary", Extension: ".bin", MIMEType: "application/octet-stream", MinSize: 1, generate: (*generator).binary},
+}
+
+// Formats lists the names of the formats in alphabetical order
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the format with the given name
+func Lookup(name string) (*Format, bool) {
+	format, ok := formats[name]
+	return format, ok
+}
+
+// Generate produces a file of the format that is exactly size bytes long.
+// Text formats are padded with whitespace where the last record or
+// paragraph does not fit; binary formats carry random data.
+func (f *Format) Generate(source *rng.Source, locale string, size int) ([]byte, error) {
+	if size < f.MinSize {
+		return nil, fmt.Errorf("%s files are at least %d bytes", f.Name, f.MinSize)
+	}
+	if size > MaxSize {
+		return nil, fmt.Errorf("files are at most %d bytes", MaxSize)
+	}
+
+	data, err := f.generate(&generator{source: source, faker: faker.New(source, locale)}, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != size {
+		return nil, fmt.Errorf("generated %s file has %d bytes instead of %d", f.Name, len(data), size)
+	}
+	return data, nil
+}
+
+// generator holds the random source of one Generate call
+type generator struct {
+	source *rng.Source
+	faker  *faker.Faker
+}
+
+// random returns n random bytes
+func (g *generator) random(n int) []byte {
+	data := make([]byte, n)
+	_, _ = g.source.Read(data)
+	return data
+}
+
+func (g *generator) binary(size int) ([]byte, error) {
+	return g.random(size), nil
+}
+
+// pool returns up to poolSize values of produce, enough for a file of size
+// bytes assuming values of at least minLength bytes
+func pool(size, minLength int, produce func() string) []string {
+	values := make([]string, min(poolSize, size/minLength+1))
+	for i := range values {
+		values[i] = produce()
+	}
+	return values
+}
+
+// pad fills b up to size with filler
+func pad(b *bytes.Buffer, size int, filler byte) {
+	for b.Len() < size {
+		b.WriteByte(filler)
+	}
+}
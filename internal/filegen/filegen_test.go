@@ -0,0 +1,142 @@
+package filegen
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// parse checks that data is a well-formed file of each format
+var parse = map[string]func(data []byte) error{
+	"json": func(data []byte) error {
+		var records []map[string]any
+		return json.Unmarshal(data, &records)
+	},
+	"csv": func(data []byte) error {
+		_, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		return err
+	},
+	"markdown": func(data []byte) error {
+		if !bytes.HasPrefix(data, []byte(markdownTitle)) || !utf8.Valid(data) {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	},
+	"text": func(data []byte) error {
+		if !utf8.Valid(data) {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	},
+	"png": func(data []byte) error {
+		_, err := png.Decode(bytes.NewReader(data))
+		return err
+	},
+	"pdf": func(data []byte) error {
+		if !bytes.HasPrefix(data, []byte("%PDF-")) || !bytes.HasSuffix(bytes.TrimRight(data, "\n"), []byte("%%EOF")) {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	},
+	"zip": func(data []byte) error {
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return err
+		}
+		for _, file := range archive.File {
+			r, err := file.Open()
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(io.Discard, r)
+			r.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	"binary": func(data []byte) error { return nil },
+}
+
+func TestGenerate(t *testing.T) {
+	for _, name := range Formats() {
+		format, _ := Lookup(name)
+		check, ok := parse[name]
+		if !ok {
+			t.Fatalf("no parser for format %s", name)
+		}
+
+		for _, size := range []int{format.MinSize, format.MinSize + 1, 1000, 70000, 200000} {
+			if size < format.MinSize {
+				continue
+			}
+			data, err := format.Generate(rng.New(1, nil), "en_US", size)
+			if err != nil {
+				t.Errorf("%s Generate(%d) error = %v", name, size, err)
+				continue
+			}
+			if len(data) != size {
+				t.Errorf("%s Generate(%d) = %d bytes", name, size, len(data))
+			}
+			if err := check(data); err != nil {
+				t.Errorf("%s Generate(%d) is not a valid file: %v", name, size, err)
+			}
+		}
+	}
+}
+
+func TestGenerateSeeded(t *testing.T) {
+	for _, name := range Formats() {
+		format, _ := Lookup(name)
+		a, _ := format.Generate(rng.New(7, nil), "en_US", 4096)
+		b, _ := format.Generate(rng.New(7, nil), "en_US", 4096)
+		c, _ := format.Generate(rng.New(8, nil), "en_US", 4096)
+		if !bytes.Equal(a, b) {
+			t.Errorf("%s: same seed gave different files", name)
+		}
+		if bytes.Equal(a, c) {
+			t.Errorf("%s: seeds 7 and 8 gave the same file", name)
+		}
+	}
+}
+
+func TestGenerateSize(t *testing.T) {
+	format, _ := Lookup("pdf")
+	tests := []struct {
+		name    string
+		size    int
+		wantErr string
+	}{
+		{"below the minimum", format.MinSize - 1, "at least"},
+		{"above the maximum", MaxSize + 1, "at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := format.Generate(rng.New(1, nil), "en_US", tt.size); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate(%d) = %v, want an error containing %q", tt.size, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if want := []string{"binary", "csv", "json", "markdown", "pdf", "png", "text", "zip"}; strings.Join(Formats(), ",") != strings.Join(want, ",") {
+		t.Errorf("Formats() = %v, want %v", Formats(), want)
+	}
+	if format, ok := Lookup("markdown"); !ok || format.Extension != ".md" || format.MIMEType != "text/markdown" || !format.Text {
+		t.Errorf("Lookup(markdown) = %+v, %v", format, ok)
+	}
+	if _, ok := Lookup("docx"); ok {
+		t.Error("Lookup(docx) found a format")
+	}
+}
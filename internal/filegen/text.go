@@ -0,0 +1,107 @@
+package filegen
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+)
+
+const (
+	csvHeader     = "id,name,email,company,city\n"
+	markdownTitle = "# Generated document\n\n"
+)
+
+// person is the record of JSON and CSV files; the ID is added per row
+type person struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Company string `json:"company"`
+	City    string `json:"city"`
+}
+
+func (g *generator) person() person {
+	city, _ := g.faker.Generate("city")
+	return person{Name: g.faker.Name(), Email: g.faker.Email(), Company: g.faker.Company(), City: city}
+}
+
+// json is an array of person objects
+func (g *generator) json(size int) ([]byte, error) {
+	records := pool(size, 80, func() string {
+		data, _ := json.Marshal(g.person())
+		return string(data[1:])
+	})
+
+	var b bytes.Buffer
+	b.Grow(size)
+	b.WriteByte('[')
+	for i := 0; ; i++ {
+		record := `{"id":` + strconv.Itoa(i+1) + "," + records[i%len(records)]
+		if i > 0 {
+			record = "," + record
+		}
+		if b.Len()+len(record)+1 > size {
+			break
+		}
+		b.WriteString(record)
+	}
+	pad(&b, size-1, ' ')
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+// csv is a header and a row per person; blank lines fill the end
+func (g *generator) csv(size int) ([]byte, error) {
+	rows := pool(size, 60, func() string {
+		p := g.person()
+		var row bytes.Buffer
+		w := csv.NewWriter(&row)
+		_ = w.Write([]string{p.Name, p.Email, p.Company, p.City})
+		w.Flush()
+		return row.String()
+	})
+
+	var b bytes.Buffer
+	b.Grow(size)
+	b.WriteString(csvHeader)
+	for i := 0; ; i++ {
+		row := strconv.Itoa(i+1) + "," + rows[i%len(rows)]
+		if b.Len()+len(row) > size {
+			break
+		}
+		b.WriteString(row)
+	}
+	pad(&b, size, '\n')
+	return b.Bytes(), nil
+}
+
+// markdown is a title and numbered sections of lorem ipsum
+func (g *generator) markdown(size int) ([]byte, error) {
+	paragraphs := pool(size, 200, g.faker.Paragraph)
+
+	var b bytes.Buffer
+	b.Grow(size)
+	b.WriteString(markdownTitle)
+	for i := 0; ; i++ {
+		section := "## Section " + strconv.Itoa(i+1) + "\n\n" + paragraphs[i%len(paragraphs)] + "\n\n"
+		if b.Len()+len(section) > size {
+			break
+		}
+		b.WriteString(section)
+	}
+	pad(&b, size, '\n')
+	return b.Bytes(), nil
+}
+
+// text is lorem ipsum paragraphs, the last one cut to fit
+func (g *generator) text(size int) ([]byte, error) {
+	paragraphs := pool(size, 200, g.faker.Paragraph)
+
+	var b bytes.Buffer
+	b.Grow(size)
+	for i := 0; b.Len() < size; i++ {
+		paragraph := paragraphs[i%len(paragraphs)] + "\n\n"
+		b.WriteString(paragraph[:min(len(paragraph), size-b.Len())])
+	}
+	return b.Bytes(), nil
+}
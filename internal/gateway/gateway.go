@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	adkconfig "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// maxBodySize limits the JSON-RPC requests the gateway inspects
+const maxBodySize = 10 << 20
+
+// codeUnsupportedOperation is the A2A error of a method the agent does not offer
+const codeUnsupportedOperation = -32004
+
+// Upstream is the ADK server the gateway forwards to
+type Upstream interface {
+	// Port is the port the server listens on
+	Port() string
+	// Ready is closed once the server accepts connections
+	Ready() <-chan struct{}
+}
+
+// Middleware handles the JSON-RPC requests of the A2A endpoint, passing
+// them on to next unless it answers them itself
+type Middleware func(next http.Handler) http.Handler
+
+// Server is the public A2A endpoint. It forwards every request to the ADK
+// server listening on an internal port, passing the JSON-RPC requests of
+// the A2A endpoint through its middlewares, and turns down streams when the
+// agent does not advertise streaming.
+type Server struct {
+	cfg          adkconfig.ServerConfig
+	capabilities adkconfig.CapabilitiesConfig
+	upstream     *url.URL
+	client       *http.Client
+	proxy        *httputil.ReverseProxy
+	middlewares  []Middleware
+	logger       *zap.Logger
+	httpServer   *http.Server
+	// mounts serves the gateways of other agents under path prefixes
+	mounts map[string]http.Handler
+
+	// ctx ends the requests in flight when the gateway stops
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewServer creates a gateway serving cfg in front of upstream. Requests
+// wait for upstream to be ready.
+func NewServer(cfg adkconfig.ServerConfig, capabilities adkconfig.CapabilitiesConfig, upstream Upstream, logger *zap.Logger) *Server {
+	target := &url.URL{Scheme: "http", Host: net.JoinHostPort("127.0.0.1", upstream.Port())}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		select {
+		case <-upstream.Ready():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return dial(ctx, network, address)
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = transport
+	// Flush every write so streamed events are not held back
+	proxy.FlushInterval = -1
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		logger.Error("failed to forward A2A request", zap.String("path", r.URL.Path), zap.Error(err))
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		cfg:          cfg,
+		capabilities: capabilities,
+		upstream:     target,
+		client:       &http.Client{Transport: transport},
+		proxy:        proxy,
+		logger:       logger,
+		ctx:          ctx,
+		cancel:       cancel,
+		mounts:       make(map[string]http.Handler),
+	}
+
+	s.httpServer = &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      s,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	return s
+}
+
+// Use adds m to the middlewares of the A2A endpoint. Requests pass through
+// them in the order they were added. It must be called before Start.
+func (s *Server) Use(m Middleware) {
+	s.middlewares = append(s.middlewares, m)
+}
+
+// Mount serves h under prefix, with the prefix cut from the request paths.
+// It must be called before Start.
+func (s *Server) Mount(prefix string, h http.Handler) {
+	s.mounts[prefix] = http.StripPrefix(prefix, h)
+}
+
+// Start serves the gateway until Stop is called
+func (s *Server) Start(ctx context.Context) error {
+	var err error
+	if s.cfg.TLSConfig.Enable {
+		err = s.httpServer.ListenAndServeTLS(s.cfg.TLSConfig.CertPath, s.cfg.TLSConfig.KeyPath)
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully shuts the gateway down
+func (s *Server) Stop(ctx context.Context) error {
+	s.cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for prefix, h := range s.mounts {
+		if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+			h.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	// Requests in flight end with the gateway
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	defer context.AfterFunc(s.ctx, cancel)()
+	r = r.WithContext(ctx)
+
+	if r.Method != http.MethodPost || r.URL.Path != "/a2a" {
+		s.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	_ = r.Body.Close()
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+
+	var req Request
+	if json.Unmarshal(body, &req) != nil {
+		s.proxy.ServeHTTP(w, r)
+		return
+	}
+	req.body = body
+
+	var h http.Handler = http.HandlerFunc(s.forward)
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		h = s.middlewares[i](h)
+	}
+	h.ServeHTTP(w, r.WithContext(context.WithValue(ctx, requestKey{}, req)))
+}
+
+// forward passes a JSON-RPC request on to the ADK server
+func (s *Server) forward(w http.ResponseWriter, r *http.Request) {
+	switch RPC(r).Method {
+	case "message/stream", "tasks/resubscribe":
+		if !s.capabilities.Streaming {
+			WriteError(w, RPC(r).ID, codeUnsupportedOperation, "This operation is not supported", map[string]any{"detail": "the agent does not support streaming"})
+			return
+		}
+	}
+	s.proxy.ServeHTTP(w, r)
+}
+
+// Task gets a task from the ADK server
+func (s *Server) Task(ctx context.Context, taskID string) (*types.Task, error) {
+	payload, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "gateway",
+		"method":  "tasks/get",
+		"params":  map[string]any{"id": taskID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.upstream.JoinPath("/a2a").String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Result *types.Task         `json:"result"`
+		Error  *types.JSONRPCError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode task %q: %w", taskID, err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("task %q: %s", taskID, response.Error.Message)
+	}
+	if response.Result == nil {
+		return nil, fmt.Errorf("task %q not found", taskID)
+	}
+	return response.Result, nil
+}
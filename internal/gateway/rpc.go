@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Request is a JSON-RPC request of the A2A endpoint
+type Request struct {
+	ID     any    `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		// ID is the task ID of the tasks methods
+		ID string `json:"id"`
+	} `json:"params"`
+
+	body []byte
+}
+
+type requestKey struct{}
+
+// RPC returns the JSON-RPC request a middleware handles
+func RPC(r *http.Request) Request {
+	req, _ := r.Context().Value(requestKey{}).(Request)
+	return req
+}
+
+// DecodeParams reads the params of the request into v
+func (r Request) DecodeParams(v any) error {
+	request := struct {
+		Params any `json:"params"`
+	}{Params: v}
+	if err := json.Unmarshal(r.body, &request); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
+
+func WriteJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func WriteResult(w http.ResponseWriter, id any, result any) {
+	WriteJSON(w, http.StatusOK, types.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+// WriteError answers with a JSON-RPC error, with data when it is not nil
+func WriteError(w http.ResponseWriter, id any, code int, message string, data any) {
+	rpcError := &types.JSONRPCError{Code: code, Message: message}
+	if data != nil {
+		rpcError.Data = &data
+	}
+	WriteJSON(w, http.StatusOK, types.JSONRPCErrorResponse{JSONRPC: "2.0", ID: id, Error: rpcError})
+}
+
+// Recorder buffers a response so it can be changed before it is sent
+type Recorder struct {
+	header http.Header
+	status int
+	Body   bytes.Buffer
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (r *Recorder) Header() http.Header         { return r.header }
+func (r *Recorder) Write(b []byte) (int, error) { return r.Body.Write(b) }
+func (r *Recorder) WriteHeader(status int)      { r.status = status }
+
+// Send sends the recorded response with body in place of its own
+func (r *Recorder) Send(w http.ResponseWriter, body []byte) {
+	for key, values := range r.header {
+		if key != "Content-Length" {
+			w.Header()[key] = values
+		}
+	}
+	w.WriteHeader(r.status)
+	_, _ = w.Write(body)
+}
+
+// EventWriter passes a stream of server-sent events on event by event,
+// sending what rewrite returns in place of each event. Responses other than
+// event streams, e.g. errors, pass unchanged.
+type EventWriter struct {
+	http.ResponseWriter
+	rewrite func(event []byte) []byte
+	pending bytes.Buffer
+}
+
+// NewEventWriter returns an event writer for w. An event is dropped when
+// rewrite returns nothing for it.
+func NewEventWriter(w http.ResponseWriter, rewrite func(event []byte) []byte) *EventWriter {
+	return &EventWriter{ResponseWriter: w, rewrite: rewrite}
+}
+
+// WriteHeader drops the length of an event stream, which rewriting changes
+func (e *EventWriter) WriteHeader(status int) {
+	if e.stream() {
+		e.Header().Del("Content-Length")
+	}
+	e.ResponseWriter.WriteHeader(status)
+}
+
+func (e *EventWriter) Write(b []byte) (int, error) {
+	if !e.stream() {
+		return e.ResponseWriter.Write(b)
+	}
+
+	e.pending.Write(b)
+	for {
+		end := bytes.Index(e.pending.Bytes(), []byte("\n\n"))
+		if end < 0 {
+			return len(b), nil
+		}
+		event := e.rewrite(e.pending.Next(end + 2))
+		if len(event) == 0 {
+			continue
+		}
+		if _, err := e.ResponseWriter.Write(event); err != nil {
+			return len(b), err
+		}
+	}
+}
+
+func (e *EventWriter) Flush() {
+	if flusher, ok := e.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (e *EventWriter) stream() bool {
+	return strings.HasPrefix(e.Header().Get("Content-Type"), "text/event-stream")
+}
+
+// Finish passes on what is left of an event cut short by the upstream
+func (e *EventWriter) Finish() {
+	if e.pending.Len() > 0 {
+		_, _ = e.ResponseWriter.Write(e.pending.Bytes())
+		e.pending.Reset()
+		e.Flush()
+	}
+}
+
+// EventPayload returns the JSON data of an event
+func EventPayload(event []byte) ([]byte, bool) {
+	return bytes.CutPrefix(bytes.TrimSuffix(event, []byte("\n\n")), []byte("data: "))
+}
+
+// Event renders a JSON-RPC response as an event, or returns fallback when it
+// cannot be encoded
+func Event(response any, fallback []byte) []byte {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fallback
+	}
+	return append(append([]byte("data: "), data...), '\n', '\n')
+}
@@ -0,0 +1,146 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Entry kinds
+const (
+	KindLLM  = "llm"
+	KindTool = "tool"
+)
+
+// Entry is one recorded LLM call or skill invocation
+type Entry struct {
+	Seq        int64     `json:"seq"`
+	Kind       string    `json:"kind"`
+	TaskID     string    `json:"task_id,omitempty"`
+	ContextID  string    `json:"context_id,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs float64   `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+
+	// LLM calls
+	Stream   bool          `json:"stream,omitempty"`
+	Messages []sdk.Message `json:"messages,omitempty"`
+	Tools    []string      `json:"tools,omitempty"`
+	Response *LLMResponse  `json:"response,omitempty"`
+
+	// Skill invocations
+	Tool      string         `json:"tool,omitempty"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Result    string         `json:"result,omitempty"`
+}
+
+// LLMResponse is the assistant output of an LLM call, reassembled from chunks when streamed
+type LLMResponse struct {
+	Content      string                              `json:"content,omitempty"`
+	ToolCalls    []sdk.ChatCompletionMessageToolCall `json:"tool_calls,omitempty"`
+	FinishReason string                              `json:"finish_reason,omitempty"`
+	Usage        *sdk.CompletionUsage                `json:"usage,omitempty"`
+	Chunks       int                                 `json:"chunks,omitempty"`
+}
+
+// Filter selects entries; empty fields match everything
+type Filter struct {
+	Kind      string
+	TaskID    string
+	ContextID string
+	Tool      string
+	// AfterSeq returns only entries recorded after this sequence number
+	AfterSeq int64
+}
+
+func (f Filter) matches(e *Entry) bool {
+	return (f.Kind == "" || e.Kind == f.Kind) &&
+		(f.TaskID == "" || e.TaskID == f.TaskID) &&
+		(f.ContextID == "" || e.ContextID == f.ContextID) &&
+		(f.Tool == "" || e.Tool == f.Tool) &&
+		e.Seq > f.AfterSeq
+}
+
+// Journal keeps the most recent entries in memory
+type Journal struct {
+	mu         sync.RWMutex
+	entries    []Entry
+	maxEntries int
+	seq        int64
+	// clock stamps the start of entries
+	clock rng.Clock
+}
+
+// New creates a journal that keeps at most maxEntries entries (0 = unlimited),
+// stamping them with clock; a nil clock means the wall clock
+func New(maxEntries int, clock rng.Clock) *Journal {
+	if clock == nil {
+		clock = rng.SystemClock()
+	}
+	return &Journal{maxEntries: maxEntries, clock: clock}
+}
+
+// Record appends an entry, assigning its sequence number
+func (j *Journal) Record(e Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.seq++
+	e.Seq = j.seq
+	j.entries = append(j.entries, e)
+	if j.maxEntries > 0 && len(j.entries) > j.maxEntries {
+		j.entries = j.entries[len(j.entries)-j.maxEntries:]
+	}
+}
+
+// Entries returns the entries matching filter, oldest first
+func (j *Journal) Entries(filter Filter) []Entry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	result := []Entry{}
+	for i := range j.entries {
+		if filter.matches(&j.entries[i]) {
+			result = append(result, j.entries[i])
+		}
+	}
+	return result
+}
+
+// WriteJSONL writes the entries matching filter as JSON lines
+func (j *Journal) WriteJSONL(w io.Writer, filter Filter) error {
+	encoder := json.NewEncoder(w)
+	for _, e := range j.Entries(filter) {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset drops all entries. Sequence numbers keep increasing.
+func (j *Journal) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = nil
+}
+
+// taskIDs reads the A2A task and context IDs the server attaches to the request context
+func taskIDs(ctx context.Context) (string, string) {
+	if task, ok := ctx.Value(server.TaskContextKey).(*types.Task); ok && task != nil {
+		return task.ID, task.ContextID
+	}
+	return "", ""
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,200 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// seqs returns the sequence numbers of entries
+func seqs(entries []Entry) []int64 {
+	result := []int64{}
+	for _, e := range entries {
+		result = append(result, e.Seq)
+	}
+	return result
+}
+
+func TestEntriesFilter(t *testing.T) {
+	j := New(0, nil)
+	j.Record(Entry{Kind: KindLLM, TaskID: "task-1", ContextID: "ctx-1"})
+	j.Record(Entry{Kind: KindTool, TaskID: "task-1", ContextID: "ctx-1", Tool: "echo"})
+	j.Record(Entry{Kind: KindLLM, TaskID: "task-2", ContextID: "ctx-1"})
+	j.Record(Entry{Kind: KindTool, TaskID: "task-2", ContextID: "ctx-2", Tool: "delay"})
+	j.Record(Entry{Kind: KindLLM})
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []int64
+	}{
+		{"everything", Filter{}, []int64{1, 2, 3, 4, 5}},
+		{"kind", Filter{Kind: KindTool}, []int64{2, 4}},
+		{"task", Filter{TaskID: "task-2"}, []int64{3, 4}},
+		{"context", Filter{ContextID: "ctx-1"}, []int64{1, 2, 3}},
+		{"tool", Filter{Tool: "echo"}, []int64{2}},
+		{"after seq", Filter{AfterSeq: 3}, []int64{4, 5}},
+		{"combined", Filter{Kind: KindLLM, ContextID: "ctx-1", AfterSeq: 1}, []int64{3}},
+		{"no match", Filter{TaskID: "task-3"}, []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seqs(j.Entries(tt.filter)); !slices.Equal(got, tt.want) {
+				t.Errorf("Entries(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		records    int
+		want       []int64
+	}{
+		{"unlimited", 0, 4, []int64{1, 2, 3, 4}},
+		{"below the limit", 5, 4, []int64{1, 2, 3, 4}},
+		{"at the limit", 4, 4, []int64{1, 2, 3, 4}},
+		{"oldest dropped", 2, 5, []int64{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := New(tt.maxEntries, nil)
+			for range tt.records {
+				j.Record(Entry{Kind: KindLLM})
+			}
+			if got := seqs(j.Entries(Filter{})); !slices.Equal(got, tt.want) {
+				t.Errorf("Entries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	j := New(0, nil)
+	j.Record(Entry{Kind: KindLLM})
+	j.Record(Entry{Kind: KindLLM})
+	j.Reset()
+	if got := j.Entries(Filter{}); len(got) != 0 {
+		t.Fatalf("Entries() after Reset = %v, want none", seqs(got))
+	}
+
+	// Clients polling with after_seq do not see numbers again
+	j.Record(Entry{Kind: KindLLM})
+	if got := seqs(j.Entries(Filter{AfterSeq: 2})); !slices.Equal(got, []int64{3}) {
+		t.Errorf("Entries() after Reset = %v, want [3]", got)
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	j := New(0, nil)
+	j.Record(Entry{Kind: KindLLM, TaskID: "task-1"})
+	j.Record(Entry{Kind: KindTool, TaskID: "task-2", Tool: "echo"})
+	j.Record(Entry{Kind: KindTool, TaskID: "task-1", Tool: "echo"})
+
+	var buf bytes.Buffer
+	if err := j.WriteJSONL(&buf, Filter{TaskID: "task-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int64
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not an entry: %v", scanner.Text(), err)
+		}
+		got = append(got, e.Seq)
+	}
+	if !slices.Equal(got, []int64{1, 3}) {
+		t.Errorf("WriteJSONL wrote entries %v, want [1 3]", got)
+	}
+}
+
+// stubClient answers every call with the same content, streamed in two chunks
+type stubClient struct {
+	err error
+}
+
+func (c stubClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &sdk.CreateChatCompletionResponse{Choices: []sdk.ChatCompletionChoice{{
+		Message:      sdk.Message{Role: sdk.Assistant, Content: "hello world"},
+		FinishReason: sdk.Stop,
+	}}}, nil
+}
+
+func (c stubClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	out := make(chan *sdk.CreateChatCompletionStreamResponse, 3)
+	errs := make(chan error, 1)
+	for _, delta := range []string{"hello ", "world"} {
+		out <- &sdk.CreateChatCompletionStreamResponse{Choices: []sdk.ChatCompletionStreamChoice{{Delta: sdk.ChatCompletionStreamResponseDelta{Content: delta}}}}
+	}
+	out <- &sdk.CreateChatCompletionStreamResponse{Choices: []sdk.ChatCompletionStreamChoice{{FinishReason: string(sdk.Stop)}}}
+	close(out)
+	if c.err != nil {
+		errs <- c.err
+	}
+	close(errs)
+	return out, errs
+}
+
+func TestLLMClient(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	j := New(0, rng.NewStepClock(start, time.Second))
+	ctx := context.WithValue(context.Background(), server.TaskContextKey, &types.Task{ID: "task-1", ContextID: "ctx-1"})
+	messages := []sdk.Message{{Role: sdk.User, Content: "hi"}}
+
+	client := WrapLLMClient(stubClient{}, j)
+	if _, err := client.CreateChatCompletion(ctx, messages); err != nil {
+		t.Fatal(err)
+	}
+	respChan, errChan := client.CreateStreamingChatCompletion(ctx, messages)
+	for range respChan {
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+	failing := WrapLLMClient(stubClient{err: errors.New("boom")}, j)
+	_, _ = failing.CreateChatCompletion(context.Background(), messages)
+
+	entries := j.Entries(Filter{})
+	if len(entries) != 3 {
+		t.Fatalf("journal has %d entries, want 3", len(entries))
+	}
+	for i, e := range entries {
+		if want := start.Add(time.Duration(i) * time.Second); !e.StartedAt.Equal(want) {
+			t.Errorf("entry %d started at %v, want %v from the clock", i, e.StartedAt, want)
+		}
+	}
+
+	sent, streamed, failed := entries[0], entries[1], entries[2]
+	if sent.TaskID != "task-1" || sent.ContextID != "ctx-1" || sent.Stream {
+		t.Errorf("entry = %+v, want an unstreamed call of task-1", sent)
+	}
+	if sent.Response == nil || sent.Response.Content != "hello world" {
+		t.Errorf("response = %+v, want hello world", sent.Response)
+	}
+	if !streamed.Stream || streamed.Response == nil || streamed.Response.Content != "hello world" || streamed.Response.Chunks != 3 || streamed.Response.FinishReason != string(sdk.Stop) {
+		t.Errorf("streamed response = %+v, want hello world put together from 3 chunks", streamed.Response)
+	}
+	if failed.Error != "boom" || failed.TaskID != "" {
+		t.Errorf("failed entry = %+v, want error boom and no task", failed)
+	}
+}
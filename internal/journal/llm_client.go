@@ -0,0 +1,174 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	sdk "github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// LLMClient records every call of the wrapped client in the journal
+type LLMClient struct {
+	inner   server.LLMClient
+	journal *Journal
+}
+
+// WrapLLMClient returns a client that journals calls before delegating to inner
+func WrapLLMClient(inner server.LLMClient, journal *Journal) *LLMClient {
+	return &LLMClient{inner: inner, journal: journal}
+}
+
+func (c *LLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	entry, began := newLLMEntry(ctx, c.journal.clock, false, messages, tools), time.Now()
+
+	resp, err := c.inner.CreateChatCompletion(ctx, messages, tools...)
+
+	entry.DurationMs = durationMs(time.Since(began))
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if resp != nil && len(resp.Choices) > 0 {
+		choice := resp.Choices[0]
+		entry.Response = &LLMResponse{
+			Content:      choice.Message.Content,
+			FinishReason: string(choice.FinishReason),
+			Usage:        resp.Usage,
+		}
+		if choice.Message.ToolCalls != nil {
+			entry.Response.ToolCalls = *choice.Message.ToolCalls
+		}
+	}
+	c.journal.Record(entry)
+
+	return resp, err
+}
+
+func (c *LLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	entry, began := newLLMEntry(ctx, c.journal.clock, true, messages, tools), time.Now()
+	innerResp, innerErr := c.inner.CreateStreamingChatCompletion(ctx, messages, tools...)
+
+	// Unbuffered so no chunk is still pending when errChan closes
+	respChan := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(respChan)
+		defer close(errChan)
+
+		response := &LLMResponse{}
+		calls := map[int]*sdk.ChatCompletionMessageToolCall{}
+		defer func() {
+			for _, idx := range sortedKeys(calls) {
+				response.ToolCalls = append(response.ToolCalls, *calls[idx])
+			}
+			entry.Response = response
+			entry.DurationMs = durationMs(time.Since(began))
+			c.journal.Record(entry)
+		}()
+
+		for {
+			select {
+			case chunk, ok := <-innerResp:
+				if !ok {
+					select {
+					case err := <-innerErr:
+						if err != nil {
+							entry.Error = err.Error()
+							errChan <- err
+						}
+					default:
+					}
+					return
+				}
+				response.Chunks++
+				accumulate(response, calls, chunk)
+				select {
+				case respChan <- chunk:
+				case <-ctx.Done():
+					entry.Error = fmt.Sprintf("stream abandoned: %v", ctx.Err())
+					return
+				}
+
+			case err, ok := <-innerErr:
+				if !ok {
+					innerErr = nil
+					continue
+				}
+				if err != nil {
+					entry.Error = err.Error()
+					errChan <- err
+					return
+				}
+			}
+		}
+	}()
+
+	return respChan, errChan
+}
+
+func newLLMEntry(ctx context.Context, clock rng.Clock, stream bool, messages []sdk.Message, tools []sdk.ChatCompletionTool) Entry {
+	taskID, contextID := taskIDs(ctx)
+
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+
+	return Entry{
+		Kind:      KindLLM,
+		TaskID:    taskID,
+		ContextID: contextID,
+		StartedAt: clock.Now(),
+		Stream:    stream,
+		Messages:  append([]sdk.Message(nil), messages...),
+		Tools:     names,
+	}
+}
+
+// accumulate merges a stream chunk into the reassembled response
+func accumulate(response *LLMResponse, calls map[int]*sdk.ChatCompletionMessageToolCall, chunk *sdk.CreateChatCompletionStreamResponse) {
+	if chunk == nil {
+		return
+	}
+	if chunk.Usage != nil {
+		response.Usage = chunk.Usage
+	}
+	if len(chunk.Choices) == 0 {
+		return
+	}
+
+	choice := chunk.Choices[0]
+	response.Content += choice.Delta.Content
+	if choice.FinishReason != "" {
+		response.FinishReason = choice.FinishReason
+	}
+
+	for _, part := range choice.Delta.ToolCalls {
+		call, ok := calls[part.Index]
+		if !ok {
+			call = &sdk.ChatCompletionMessageToolCall{Type: sdk.Function}
+			calls[part.Index] = call
+		}
+		if part.ID != "" {
+			call.Id = part.ID
+		}
+		if part.Function.Name != "" {
+			call.Function.Name = part.Function.Name
+		}
+		call.Function.Arguments += part.Function.Arguments
+	}
+}
+
+func sortedKeys(calls map[int]*sdk.ChatCompletionMessageToolCall) []int {
+	keys := make([]int, 0, len(calls))
+	for k := range calls {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
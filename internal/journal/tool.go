@@ -0,0 +1,43 @@
+package journal
+
+import (
+	"context"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+)
+
+// Tool records every execution of the wrapped tool in the journal
+type Tool struct {
+	server.Tool
+	journal *Journal
+}
+
+// WrapTool returns a tool that journals executions before delegating to inner
+func WrapTool(inner server.Tool, journal *Journal) *Tool {
+	return &Tool{Tool: inner, journal: journal}
+}
+
+func (t *Tool) Execute(ctx context.Context, arguments map[string]any) (string, error) {
+	taskID, contextID := taskIDs(ctx)
+	startedAt, began := t.journal.clock.Now(), time.Now()
+
+	result, err := t.Tool.Execute(ctx, arguments)
+
+	entry := Entry{
+		Kind:       KindTool,
+		TaskID:     taskID,
+		ContextID:  contextID,
+		StartedAt:  startedAt,
+		DurationMs: durationMs(time.Since(began)),
+		Tool:       t.GetName(),
+		Arguments:  arguments,
+		Result:     result,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	t.journal.Record(entry)
+
+	return result, err
+}
@@ -0,0 +1,355 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// maxSchemaDepth bounds recursion into nested and self-similar schemas
+const maxSchemaDepth = 8
+
+// textProperties are parameter names that receive the user message when
+// arguments are pulled from the message
+var textProperties = map[string]bool{
+	"message":     true,
+	"input":       true,
+	"text":        true,
+	"query":       true,
+	"prompt":      true,
+	"content":     true,
+	"question":    true,
+	"description": true,
+	"body":        true,
+}
+
+// argumentSynthesizer builds tool call arguments that satisfy a tool's JSON
+// Schema parameters
+type argumentSynthesizer struct {
+	source      *rng.Source
+	userMessage string
+	fromMessage bool
+	// err records the first schema no value can satisfy
+	err error
+}
+
+// arguments returns JSON encoded arguments for tool
+func (a *argumentSynthesizer) arguments(tool sdk.ChatCompletionTool) (string, error) {
+	schema := map[string]any{}
+	if tool.Function.Parameters != nil {
+		// Round trip through JSON so Go literals like []string become []any
+		data, err := json.Marshal(tool.Function.Parameters)
+		if err != nil {
+			return "", fmt.Errorf("invalid parameters schema for tool %s: %w", tool.Function.Name, err)
+		}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return "", fmt.Errorf("invalid parameters schema for tool %s: %w", tool.Function.Name, err)
+		}
+	}
+
+	value, ok := a.value(schema, "", 0).(map[string]any)
+	if a.err != nil {
+		return "", fmt.Errorf("cannot synthesize arguments for tool %s: %w", tool.Function.Name, a.err)
+	}
+	if !ok {
+		value = map[string]any{}
+	}
+
+	args, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(args), nil
+}
+
+// value synthesizes a value for schema. name is the property the value is
+// assigned to, if any.
+func (a *argumentSynthesizer) value(schema map[string]any, name string, depth int) any {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+
+	if v, ok := schema["const"]; ok {
+		return v
+	}
+	if v, ok := schema["default"]; ok {
+		return v
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[a.source.Intn(len(enum))]
+	}
+	if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0]
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[key].([]any); ok && len(options) > 0 {
+			if option, ok := options[0].(map[string]any); ok {
+				return a.value(option, name, depth+1)
+			}
+		}
+	}
+	if all, ok := schema["allOf"].([]any); ok && len(all) > 0 {
+		return a.value(mergeSchemas(schema, all), name, depth+1)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return a.object(schema, depth)
+	case "array":
+		return a.array(schema, name, depth)
+	case "string":
+		return a.text(schema, name)
+	case "integer":
+		return int64(a.number(schema, true))
+	case "number":
+		return a.number(schema, false)
+	case "boolean":
+		return a.source.Intn(2) == 1
+	case "null":
+		return nil
+	}
+
+	return a.text(schema, name)
+}
+
+func (a *argumentSynthesizer) object(schema map[string]any, depth int) map[string]any {
+	result := map[string]any{}
+	properties, _ := schema["properties"].(map[string]any)
+
+	// Sorted so seeded runs draw values in a stable order
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			property = map[string]any{}
+		}
+		result[name] = a.value(property, name, depth+1)
+	}
+
+	// Required fields the properties do not describe still get a value
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := result[name]; !exists {
+					result[name] = a.text(map[string]any{}, name)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (a *argumentSynthesizer) array(schema map[string]any, name string, depth int) []any {
+	items, _ := schema["items"].(map[string]any)
+	if items == nil {
+		items = map[string]any{"type": "string"}
+	}
+
+	count := max(intKeyword(schema, "minItems", 1), 1)
+	if maxItems := intKeyword(schema, "maxItems", -1); maxItems >= 0 {
+		count = min(count, maxItems)
+	}
+
+	result := make([]any, 0, count)
+	for range count {
+		result = append(result, a.value(items, name, depth+1))
+	}
+	return result
+}
+
+func (a *argumentSynthesizer) text(schema map[string]any, name string) string {
+	var s string
+	switch format, _ := schema["format"].(string); format {
+	case "email":
+		s = fmt.Sprintf("user%d@example.com", a.source.Intn(1000))
+	case "uri", "url", "uri-reference", "iri":
+		s = fmt.Sprintf("https://example.com/%s", a.source.ID()[:8])
+	case "uuid":
+		s = a.source.UUID()
+	case "date-time":
+		s = a.source.Now().UTC().Format("2006-01-02T15:04:05Z07:00")
+	case "date":
+		s = a.source.Now().UTC().Format("2006-01-02")
+	case "time":
+		s = a.source.Now().UTC().Format("15:04:05Z07:00")
+	case "ipv4":
+		s = fmt.Sprintf("192.0.2.%d", 1+a.source.Intn(254))
+	case "ipv6":
+		s = fmt.Sprintf("2001:db8::%x", 1+a.source.Intn(0xfffe))
+	case "hostname":
+		s = "host-" + a.source.ID()[:8] + ".example.com"
+	default:
+		if a.fromMessage && a.userMessage != "" && textProperties[strings.ToLower(name)] {
+			s = a.userMessage
+		} else if name != "" {
+			s = "mock " + name
+		} else {
+			s = "mock value"
+		}
+	}
+
+	minLength := intKeyword(schema, "minLength", 0)
+	for len([]rune(s)) < minLength {
+		s += "x"
+	}
+	if maxLength := intKeyword(schema, "maxLength", -1); maxLength >= 0 && len([]rune(s)) > maxLength {
+		s = string([]rune(s)[:maxLength])
+	}
+
+	return s
+}
+
+// number picks a value inside the minimum/maximum bounds, honoring exclusive
+// bounds and multipleOf
+func (a *argumentSynthesizer) number(schema map[string]any, integer bool) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if v, ok := schema["minimum"].(float64); ok {
+		lower = v
+	}
+	if v, ok := schema["maximum"].(float64); ok {
+		upper = v
+	}
+
+	step := 1e-6
+	if integer {
+		step = 1
+	}
+	if v, ok := schema["exclusiveMinimum"].(float64); ok {
+		lower = math.Max(lower, v+step)
+	}
+	if v, ok := schema["exclusiveMaximum"].(float64); ok {
+		upper = math.Min(upper, v-step)
+	}
+
+	switch {
+	case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+		lower, upper = 1, 100
+	case math.IsInf(lower, -1):
+		lower = upper - 100
+	case math.IsInf(upper, 1), upper-lower > 1e6:
+		upper = lower + 100
+	}
+	if upper < lower {
+		return lower
+	}
+
+	v := lower + a.source.Float64()*(upper-lower)
+	if integer {
+		lo, hi := math.Ceil(lower), math.Floor(upper)
+		if hi < lo {
+			return lo
+		}
+		v = lo + float64(a.source.Intn(int(hi-lo)+1))
+	}
+
+	if multipleOf, ok := schema["multipleOf"].(float64); ok && multipleOf > 0 {
+		if integer {
+			multipleOf = integerMultiple(multipleOf)
+		}
+		// Round the drawn value to the nearest multiple that stays in bounds
+		lo := math.Ceil(lower/multipleOf - 1e-9)
+		hi := math.Floor(upper/multipleOf + 1e-9)
+		if hi < lo {
+			if a.err == nil {
+				a.err = fmt.Errorf("no multiple of %v lies between %v and %v", multipleOf, lower, upper)
+			}
+			return lower
+		}
+		v = math.Max(lo, math.Min(hi, math.Round(v/multipleOf))) * multipleOf
+	}
+
+	return v
+}
+
+// integerMultiple returns the smallest whole multiple of step, so integer
+// values honor a fractional multipleOf
+func integerMultiple(step float64) float64 {
+	for k := 1.0; k <= 1000; k++ {
+		if n := k * step; math.Abs(n-math.Round(n)) < 1e-9 {
+			return math.Round(n)
+		}
+	}
+	return math.Ceil(step)
+}
+
+// schemaType returns the schema's type, taking the first non-null type of a
+// type list and inferring object or array from the keywords present
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+// mergeSchemas folds allOf subschemas into one object schema
+func mergeSchemas(schema map[string]any, all []any) map[string]any {
+	merged := map[string]any{}
+	properties := map[string]any{}
+	var required []any
+
+	for _, part := range append([]any{schema}, all...) {
+		sub, ok := part.(map[string]any)
+		if !ok {
+			continue
+		}
+		for k, v := range sub {
+			switch k {
+			case "allOf":
+			case "properties":
+				if props, ok := v.(map[string]any); ok {
+					for name, prop := range props {
+						properties[name] = prop
+					}
+				}
+			case "required":
+				if r, ok := v.([]any); ok {
+					required = append(required, r...)
+				}
+			default:
+				merged[k] = v
+			}
+		}
+	}
+
+	if len(properties) > 0 {
+		merged["properties"] = properties
+	}
+	if len(required) > 0 {
+		merged["required"] = required
+	}
+	return merged
+}
+
+func intKeyword(schema map[string]any, key string, fallback int) int {
+	if v, ok := schema[key].(float64); ok {
+		return int(v)
+	}
+	return fallback
+}
@@ -0,0 +1,208 @@
+package mock
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+)
+
+func TestArguments(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]any
+		// want holds arguments whose value is known in advance
+		want map[string]any
+	}{
+		{
+			name:       "no parameters",
+			parameters: nil,
+			want:       map[string]any{},
+		},
+		{
+			name: "required string from message",
+			parameters: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"query": map[string]any{"type": "string"}},
+				"required":   []string{"query"},
+			},
+			want: map[string]any{"query": "find the invoice"},
+		},
+		{
+			name: "const, default and enum",
+			parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"version": map[string]any{"const": "v2"},
+					"limit":   map[string]any{"type": "integer", "default": 10},
+					"unit":    map[string]any{"type": "string", "enum": []string{"celsius"}},
+				},
+			},
+			want: map[string]any{"version": "v2", "limit": float64(10), "unit": "celsius"},
+		},
+		{
+			name: "bounded numbers",
+			parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"count":   map[string]any{"type": "integer", "minimum": 3, "maximum": 5},
+					"ratio":   map[string]any{"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+					"percent": map[string]any{"type": "integer", "minimum": 0, "maximum": 100, "multipleOf": 25},
+				},
+				"required": []string{"count", "ratio", "percent"},
+			},
+		},
+		{
+			name: "string lengths and formats",
+			parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"code":  map[string]any{"type": "string", "minLength": 12, "maxLength": 12},
+					"email": map[string]any{"type": "string", "format": "email"},
+					"id":    map[string]any{"type": "string", "format": "uuid"},
+				},
+			},
+		},
+		{
+			name: "nested objects and arrays",
+			parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"customer": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"name": map[string]any{"type": "string"},
+							"tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "minItems": 2, "maxItems": 3},
+						},
+						"required": []string{"name", "tags"},
+					},
+				},
+				"required":             []string{"customer"},
+				"additionalProperties": false,
+			},
+		},
+		{
+			name: "nullable type and allOf",
+			parameters: map[string]any{
+				"type": "object",
+				"allOf": []any{
+					map[string]any{"properties": map[string]any{"a": map[string]any{"type": []string{"null", "boolean"}}}, "required": []string{"a"}},
+					map[string]any{"properties": map[string]any{"b": map[string]any{"type": "integer", "minimum": 1, "maximum": 1}}, "required": []string{"b"}},
+				},
+			},
+			want: map[string]any{"b": float64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "custom"}}
+			if tt.parameters != nil {
+				parameters := sdk.FunctionParameters(tt.parameters)
+				tool.Function.Parameters = &parameters
+			}
+			synthesizer := &argumentSynthesizer{
+				source:      rng.New(42, nil),
+				userMessage: "find the invoice",
+				fromMessage: true,
+			}
+
+			encoded, err := synthesizer.arguments(tool)
+			if err != nil {
+				t.Fatalf("arguments() error = %v", err)
+			}
+			var args map[string]any
+			if err := json.Unmarshal([]byte(encoded), &args); err != nil {
+				t.Fatalf("arguments() = %s, not a JSON object: %v", encoded, err)
+			}
+
+			if err := schema.Validate(tt.parameters, args); err != nil {
+				t.Errorf("arguments() = %s, fails its schema: %v", encoded, err)
+			}
+			for name, want := range tt.want {
+				if got := args[name]; got != want {
+					t.Errorf("arguments()[%q] = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestArgumentsSeeded(t *testing.T) {
+	parameters := sdk.FunctionParameters{
+		"type": "object",
+		"properties": map[string]any{
+			"id":    map[string]any{"type": "string", "format": "uuid"},
+			"count": map[string]any{"type": "integer", "minimum": 1, "maximum": 1000},
+		},
+	}
+	tool := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "custom", Parameters: &parameters}}
+
+	synthesize := func(seed int64) string {
+		args, err := (&argumentSynthesizer{source: rng.New(seed, nil)}).arguments(tool)
+		if err != nil {
+			t.Fatalf("arguments() error = %v", err)
+		}
+		return args
+	}
+
+	if a, b := synthesize(7), synthesize(7); a != b {
+		t.Errorf("same seed gave %s and %s", a, b)
+	}
+	if a, b := synthesize(7), synthesize(8); a == b {
+		t.Errorf("seeds 7 and 8 both gave %s", a)
+	}
+}
+
+func TestArgumentsMultipleOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]any
+		wantErr  bool
+	}{
+		{"integer", map[string]any{"type": "integer", "minimum": 0, "maximum": 100, "multipleOf": 5}, false},
+		{"integer with fractional step", map[string]any{"type": "integer", "minimum": 1, "maximum": 9, "multipleOf": 1.5}, false},
+		{"number", map[string]any{"type": "number", "minimum": 0.1, "maximum": 0.9, "multipleOf": 0.25}, false},
+		{"near the maximum", map[string]any{"type": "integer", "minimum": 91, "maximum": 99, "multipleOf": 4}, false},
+		{"no multiple fits", map[string]any{"type": "integer", "minimum": 5, "maximum": 7, "multipleOf": 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters := sdk.FunctionParameters{
+				"type":       "object",
+				"properties": map[string]any{"n": tt.property},
+				"required":   []string{"n"},
+			}
+			tool := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "custom", Parameters: &parameters}}
+
+			seen := map[float64]bool{}
+			for seed := range int64(20) {
+				encoded, err := (&argumentSynthesizer{source: rng.New(seed, nil)}).arguments(tool)
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("arguments() = %s, want an error", encoded)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("arguments() error = %v", err)
+				}
+				var args map[string]any
+				if err := json.Unmarshal([]byte(encoded), &args); err != nil {
+					t.Fatalf("arguments() = %s, not a JSON object: %v", encoded, err)
+				}
+				if err := schema.Validate(parameters, args); err != nil {
+					t.Fatalf("arguments() = %s, fails its schema: %v", encoded, err)
+				}
+				seen[args["n"].(float64)] = true
+			}
+			if len(seen) < 2 {
+				t.Errorf("20 seeds all drew %v", seen)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/inference-gateway/sdk"
+)
+
+// inputRequiredTool is the ADK built-in that pauses a task until the user replies
+const inputRequiredTool = "input_required"
+
+// maxQuestions bounds the questions a client remembers asking
+const maxQuestions = 1024
+
+// questions remembers what the client asked through input_required calls,
+// built-in, scripted or queued, so a plain assistant message in the task
+// history is only taken for a question when the client really asked it
+type questions struct {
+	mu    sync.Mutex
+	texts map[string]bool
+	order []string
+}
+
+// record remembers the questions of the input_required calls among calls
+func (q *questions) record(calls []sdk.ChatCompletionMessageToolCall) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, call := range calls {
+		if call.Function.Name != inputRequiredTool {
+			continue
+		}
+		var args struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal([]byte(call.Function.Arguments), &args) != nil || args.Message == "" {
+			continue
+		}
+		question := strings.TrimSpace(args.Message)
+		if q.texts == nil {
+			q.texts = make(map[string]bool)
+		}
+		if q.texts[question] {
+			continue
+		}
+		q.texts[question] = true
+		q.order = append(q.order, question)
+		if len(q.order) > maxQuestions {
+			delete(q.texts, q.order[0])
+			q.order = q.order[1:]
+		}
+	}
+}
+
+// asked tells whether content is a question the client asked
+func (q *questions) asked(content string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.texts[strings.TrimSpace(content)]
+}
+
+// fillerWords carry no value a tool could work on, e.g. "validate this for me"
+var fillerWords = map[string]bool{
+	"a": true, "an": true, "the": true, "this": true, "that": true, "it": true,
+	"these": true, "those": true, "something": true, "anything": true, "one": true,
+	"please": true, "pls": true, "can": true, "could": true, "would": true, "you": true,
+	"me": true, "my": true, "for": true, "to": true, "of": true, "some": true,
+	"is": true, "if": true, "valid": true, "now": true, "just": true, "again": true,
+	"value": true, "input": true, "text": true, "message": true,
+}
+
+// validationNouns name what the user wants validated, by validation type
+var validationNouns = map[string]string{
+	"email":       "email address",
+	"url":         "URL",
+	"json":        "JSON document",
+	"uuid":        "UUID",
+	"phone":       "phone number",
+	"ipv4":        "IPv4 address",
+	"ipv6":        "IPv6 address",
+	"semver":      "semantic version",
+	"cron":        "cron expression",
+	"base64":      "Base64 string",
+	"date":        "ISO 8601 date",
+	"credit_card": "credit card number",
+	"json_schema": "JSON document",
+}
+
+// missingInput is a tool call whose subject the request leaves out
+type missingInput struct {
+	// parameter receives the user's answer
+	parameter string
+	question  string
+}
+
+// inputGap reports what a tool call needs from the user when the request
+// names the tool but not what to use it on, e.g. "validate this"
+func inputGap(tools []sdk.ChatCompletionTool, call sdk.ChatCompletionMessageToolCall, request string) (missingInput, bool) {
+	lowerMsg := toLower(request)
+	name := call.Function.Name
+
+	var gap missingInput
+	ignored := map[string]bool{toLower(name): true}
+	switch name {
+	case "validate":
+		gap.parameter = "input"
+		gap.question = "What should I validate, e.g. an email address, URL, UUID, phone number or JSON document?"
+		if noun, ok := validationNouns[validationType(lowerMsg)]; ok {
+			gap.question = fmt.Sprintf("Which %s should I validate?", noun)
+		}
+		ignored["check"] = true
+		for kind, noun := range validationNouns {
+			ignored[kind] = true
+			for _, word := range strings.Fields(toLower(noun)) {
+				ignored[word] = true
+			}
+		}
+
+	case "echo":
+		gap.parameter = "message"
+		gap.question = "What message should I echo back?"
+
+	default:
+		if builtinTools[name] || name == inputRequiredTool {
+			return gap, false
+		}
+		tool, ok := findTool(tools, name)
+		if !ok {
+			return gap, false
+		}
+		gap.parameter = requiredTextParameter(tool)
+		if gap.parameter == "" {
+			return gap, false
+		}
+		gap.question = fmt.Sprintf("What %s should I use for %s?", strings.ReplaceAll(gap.parameter, "_", " "), name)
+	}
+
+	for _, word := range strings.Fields(lowerMsg) {
+		word = strings.Trim(word, ".,!?;:'\"()")
+		if word != "" && !fillerWords[word] && !ignored[word] {
+			return gap, false
+		}
+	}
+	return gap, true
+}
+
+// fill completes a tool call with the user's answer to the clarification
+func (gap missingInput) fill(call sdk.ChatCompletionMessageToolCall, request, answer string) sdk.ChatCompletionMessageToolCall {
+	args := map[string]any{}
+	_ = json.Unmarshal([]byte(call.Function.Arguments), &args)
+	args[gap.parameter] = answer
+	if call.Function.Name == "validate" {
+		// The answer may show what to validate, e.g. a URL for "validate this"
+		args["validation_type"] = defaultValidationType(toLower(request + " " + answer))
+	}
+
+	data, err := json.Marshal(args)
+	if err == nil {
+		call.Function.Arguments = string(data)
+	}
+	return call
+}
+
+// clarify asks for the missing subject of a tool call through the
+// input_required tool, or returns nil when the calls can run as they are
+func (m *MockLLMClient) clarify(tools []sdk.ChatCompletionTool, calls []sdk.ChatCompletionMessageToolCall, request string) []sdk.ChatCompletionMessageToolCall {
+	if !m.clarification || len(calls) != 1 {
+		return nil
+	}
+	if _, ok := findTool(tools, inputRequiredTool); !ok {
+		return nil
+	}
+
+	gap, ok := inputGap(tools, calls[0], request)
+	if !ok {
+		return nil
+	}
+
+	args, _ := json.Marshal(map[string]any{"message": gap.question})
+	return []sdk.ChatCompletionMessageToolCall{
+		{
+			Id:   "call-" + m.source.ID(),
+			Type: sdk.Function,
+			Function: sdk.ChatCompletionMessageToolCallFunction{
+				Name:      inputRequiredTool,
+				Arguments: string(args),
+			},
+		},
+	}
+}
+
+// resumeRequest continues the request the user was asked about with their
+// answer. It returns nil unless the latest user message answers a clarification.
+func (m *MockLLMClient) resumeRequest(conv conversation, tools []sdk.ChatCompletionTool) []sdk.ChatCompletionMessageToolCall {
+	if conv.answer == "" || conv.turn > 0 || len(tools) == 0 {
+		return nil
+	}
+
+	calls := m.generateMockToolCalls(tools, conv.request)
+	if len(calls) == 1 {
+		if gap, ok := inputGap(tools, calls[0], conv.request); ok {
+			return []sdk.ChatCompletionMessageToolCall{gap.fill(calls[0], conv.request, conv.answer)}
+		}
+	}
+
+	// The question did not come from the built-in rules, e.g. a scenario
+	// asked it, so the request and the answer are read together
+	return m.generateMockToolCalls(tools, conv.intent())
+}
+
+// requiredTextParameter returns the first required free-text parameter of tool
+func requiredTextParameter(tool sdk.ChatCompletionTool) string {
+	if tool.Function.Parameters == nil {
+		return ""
+	}
+
+	var schema struct {
+		Properties map[string]map[string]any `json:"properties"`
+		Required   []string                  `json:"required"`
+	}
+	data, err := json.Marshal(tool.Function.Parameters)
+	if err != nil || json.Unmarshal(data, &schema) != nil {
+		return ""
+	}
+
+	for _, name := range schema.Required {
+		if textProperties[strings.ToLower(name)] && schemaType(schema.Properties[name]) == "string" {
+			return name
+		}
+	}
+	return ""
+}
+
+func findTool(tools []sdk.ChatCompletionTool, name string) (sdk.ChatCompletionTool, bool) {
+	for _, tool := range tools {
+		if tool.Function.Name == name {
+			return tool, true
+		}
+	}
+	return sdk.ChatCompletionTool{}, false
+}
@@ -0,0 +1,134 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"time"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Fault types the mock can inject into a completion
+const (
+	// FaultError fails the request with a provider error
+	FaultError = "error"
+	// FaultHang blocks until the request context is done
+	FaultHang = "hang"
+	// FaultDropStream cuts a stream off in the middle of a chunk and fails it
+	FaultDropStream = "drop_stream"
+	// FaultNoFinish sends the whole response but never a finish reason
+	FaultNoFinish = "no_finish"
+	// FaultMalformedArgs emits tool calls whose arguments are not valid JSON
+	FaultMalformedArgs = "malformed_args"
+	// FaultRateLimit fails the request with a RateLimitError
+	FaultRateLimit = "rate_limit"
+)
+
+var faultTypes = map[string]bool{
+	FaultError:         true,
+	FaultHang:          true,
+	FaultDropStream:    true,
+	FaultNoFinish:      true,
+	FaultMalformedArgs: true,
+	FaultRateLimit:     true,
+}
+
+// faultMarker selects a fault from the user message, e.g. [[fault:rate_limit]]
+var faultMarker = regexp.MustCompile(`\[\[fault:([a-z_]+)\]\]`)
+
+// RateLimitError is returned by the rate_limit fault
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded (429): retry after %s", e.RetryAfter)
+}
+
+// FaultConfig controls which faults are injected and how often
+type FaultConfig struct {
+	// Rates maps a fault type to the probability (0-1) of injecting it into any request
+	Rates map[string]float64 `json:"rates,omitempty"`
+	// RetryAfter is the hint carried by rate limit errors
+	RetryAfter time.Duration `json:"retry_after"`
+	// Markers enables per-request selection with [[fault:<type>]] in the user message
+	Markers bool `json:"markers"`
+}
+
+// Validate checks fault names and probabilities
+func (c FaultConfig) Validate() error {
+	total := 0.0
+	for name, rate := range c.Rates {
+		if !faultTypes[name] {
+			return fmt.Errorf("unknown fault type %q", name)
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("fault rate for %q must be between 0 and 1", name)
+		}
+		total += rate
+	}
+
+	if total > 1 {
+		return fmt.Errorf("fault rates must not add up to more than 1")
+	}
+
+	return nil
+}
+
+// pick chooses the fault for a request: a message marker wins, then the configured rates
+func (c FaultConfig) pick(conv conversation, source *rng.Source) string {
+	if c.Markers {
+		if match := faultMarker.FindStringSubmatch(conv.userMessage); match != nil && faultTypes[match[1]] {
+			return match[1]
+		}
+	}
+
+	if len(c.Rates) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(c.Rates))
+	for name := range c.Rates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	roll := source.Float64()
+	for _, name := range names {
+		roll -= c.Rates[name]
+		if roll < 0 {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// failFast returns the error for faults that replace the whole response
+func (c FaultConfig) failFast(ctx context.Context, fault string) error {
+	switch fault {
+	case FaultError:
+		return fmt.Errorf("mock fault: upstream provider returned an internal error (500)")
+	case FaultRateLimit:
+		return &RateLimitError{RetryAfter: c.RetryAfter}
+	case FaultHang:
+		<-ctx.Done()
+		return fmt.Errorf("mock fault: request hung until context was done: %w", ctx.Err())
+	}
+	return nil
+}
+
+// corruptArguments truncates every tool call's arguments so they no longer parse as JSON
+func corruptArguments(result *completion) {
+	for i := range result.toolCalls {
+		args := result.toolCalls[i].Function.Arguments
+		result.toolCalls[i].Function.Arguments = args[:len(args)/2] + `,"unterminated`
+	}
+}
+
+// ErrDroppedStream is the error of requests hit by drop_stream, sent on the
+// error channel once a stream is cut off
+var ErrDroppedStream = fmt.Errorf("mock fault: connection dropped mid-response: %w", io.ErrUnexpectedEOF)
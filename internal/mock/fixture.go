@@ -0,0 +1,142 @@
+package mock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/inference-gateway/sdk"
+
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// Fixture is one recorded request/response pair
+type Fixture struct {
+	Key      string                                    `json:"key"`
+	Stream   bool                                      `json:"stream"`
+	Messages []sdk.Message                             `json:"messages"`
+	Tools    []sdk.ChatCompletionTool                  `json:"tools,omitempty"`
+	Response *sdk.CreateChatCompletionResponse         `json:"response,omitempty"`
+	Chunks   []*sdk.CreateChatCompletionStreamResponse `json:"chunks,omitempty"`
+	Error    string                                    `json:"error,omitempty"`
+}
+
+// normalizedMessage keeps only the parts of a message that are stable across runs.
+// Tool call IDs are generated by the provider and therefore left out, and so
+// is the content of tool results, which holds timestamps and random data;
+// a tool result is known by the tool that produced it and by its status
+// and error code, so a success and a failure of the same call differ.
+type normalizedMessage struct {
+	Role      string   `json:"role"`
+	Content   string   `json:"content,omitempty"`
+	ToolCalls []string `json:"tool_calls,omitempty"`
+	Tool      string   `json:"tool,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Code      string   `json:"code,omitempty"`
+}
+
+type normalizedTool struct {
+	Name       string `json:"name"`
+	Parameters any    `json:"parameters,omitempty"`
+}
+
+// FixtureKey hashes the normalized messages and tools of a request.
+// Streaming and non-streaming requests get distinct keys.
+func FixtureKey(stream bool, messages []sdk.Message, tools []sdk.ChatCompletionTool) string {
+	normalized := struct {
+		Stream   bool                `json:"stream"`
+		Messages []normalizedMessage `json:"messages"`
+		Tools    []normalizedTool    `json:"tools"`
+	}{Stream: stream}
+
+	toolNames := make(map[string]string)
+	for _, msg := range messages {
+		nm := normalizedMessage{Role: string(msg.Role)}
+		if msg.Role == sdk.Tool {
+			if msg.ToolCallId != nil {
+				nm.Tool = toolNames[*msg.ToolCallId]
+			}
+			result := toolresult.Parse(msg.Content)
+			nm.Status, nm.Code = result.Status, result.Code
+			normalized.Messages = append(normalized.Messages, nm)
+			continue
+		}
+
+		nm.Content = strings.TrimSpace(msg.Content)
+		if msg.ToolCalls != nil {
+			for _, call := range *msg.ToolCalls {
+				toolNames[call.Id] = call.Function.Name
+				nm.ToolCalls = append(nm.ToolCalls, call.Function.Name+":"+call.Function.Arguments)
+			}
+		}
+		normalized.Messages = append(normalized.Messages, nm)
+	}
+
+	for _, tool := range tools {
+		nt := normalizedTool{Name: tool.Function.Name}
+		if tool.Function.Parameters != nil {
+			nt.Parameters = *tool.Function.Parameters
+		}
+		normalized.Tools = append(normalized.Tools, nt)
+	}
+	sort.Slice(normalized.Tools, func(i, j int) bool {
+		return normalized.Tools[i].Name < normalized.Tools[j].Name
+	})
+
+	// encoding/json sorts map keys, so equal requests always encode identically
+	data, _ := json.Marshal(normalized)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// SaveFixture writes the fixture to dir as <key>.json
+func SaveFixture(dir string, fixture *Fixture) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture %s: %w", fixture.Key, err)
+	}
+
+	path := filepath.Join(dir, fixture.Key+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadFixtures reads every fixture in dir, indexed by key
+func LoadFixtures(dir string) (map[string]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+
+	fixtures := make(map[string]*Fixture, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+
+		// Keys are recomputed so fixtures recorded under an older
+		// normalization are still found
+		fixture.Key = FixtureKey(fixture.Stream, fixture.Messages, fixture.Tools)
+		fixtures[fixture.Key] = &fixture
+	}
+
+	return fixtures, nil
+}
@@ -0,0 +1,74 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+)
+
+func toolCall(id, name, args string) sdk.ChatCompletionMessageToolCall {
+	return sdk.ChatCompletionMessageToolCall{
+		Id:       id,
+		Type:     sdk.Function,
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: name, Arguments: args},
+	}
+}
+
+func toolRound(callID, name, args, result string) []sdk.Message {
+	calls := []sdk.ChatCompletionMessageToolCall{toolCall(callID, name, args)}
+	return []sdk.Message{
+		{Role: sdk.User, Content: "echo hello"},
+		{Role: sdk.Assistant, ToolCalls: &calls},
+		{Role: sdk.Tool, Content: result, ToolCallId: &callID},
+	}
+}
+
+func TestFixtureKey(t *testing.T) {
+	echo := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "echo"}}
+	delay := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "delay"}}
+	base := toolRound("call_1", "echo", `{"message":"hello"}`, `{"echo":"hello","timestamp":"2024-01-01T00:00:00Z"}`)
+
+	tests := []struct {
+		name   string
+		stream bool
+		msgs   []sdk.Message
+		tools  []sdk.ChatCompletionTool
+		same   bool
+	}{
+		{"identical request", false, base, []sdk.ChatCompletionTool{echo, delay}, true},
+		{"tool order", false, base, []sdk.ChatCompletionTool{delay, echo}, true},
+		{"tool call id", false, toolRound("call_2", "echo", `{"message":"hello"}`, `{"echo":"hello","timestamp":"2024-01-01T00:00:00Z"}`), []sdk.ChatCompletionTool{echo, delay}, true},
+		{"tool result content", false, toolRound("call_1", "echo", `{"message":"hello"}`, `{"echo":"hello","timestamp":"2031-06-30T12:00:00Z"}`), []sdk.ChatCompletionTool{echo, delay}, true},
+		{"surrounding whitespace", false, append([]sdk.Message{{Role: sdk.User, Content: "  echo hello\n"}}, base[1:]...), []sdk.ChatCompletionTool{echo, delay}, true},
+		{"streaming", true, base, []sdk.ChatCompletionTool{echo, delay}, false},
+		{"tool arguments", false, toolRound("call_1", "echo", `{"message":"bye"}`, `{"echo":"bye"}`), []sdk.ChatCompletionTool{echo, delay}, false},
+		{"failed tool", false, toolRound("call_1", "echo", `{"message":"hello"}`, `{"status":"error","error":"timeout","message":"timed out"}`), []sdk.ChatCompletionTool{echo, delay}, false},
+		{"called tool", false, toolRound("call_1", "delay", `{"message":"hello"}`, `{"echo":"hello"}`), []sdk.ChatCompletionTool{echo, delay}, false},
+		{"user message", false, append([]sdk.Message{{Role: sdk.User, Content: "echo bye"}}, base[1:]...), []sdk.ChatCompletionTool{echo, delay}, false},
+		{"offered tools", false, base, []sdk.ChatCompletionTool{echo}, false},
+	}
+
+	want := FixtureKey(false, base, []sdk.ChatCompletionTool{echo, delay})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FixtureKey(tt.stream, tt.msgs, tt.tools)
+			if (got == want) != tt.same {
+				t.Errorf("FixtureKey() = %s, base key %s, want same = %v", got, want, tt.same)
+			}
+		})
+	}
+}
+
+func TestFixtureKeyToolFailures(t *testing.T) {
+	key := func(result string) string {
+		return FixtureKey(false, toolRound("call_1", "echo", `{"message":"hello"}`, result), nil)
+	}
+	timeout := key(`{"status":"error","error":"timeout","message":"timed out after 3s"}`)
+
+	if got := key(`{"status":"error","error":"timeout","message":"timed out after 7s"}`); got != timeout {
+		t.Error("the message of a failure changed the key")
+	}
+	if got := key(`{"status":"error","error":"not_found","message":"timed out after 3s"}`); got == timeout {
+		t.Error("the error code of a failure did not change the key")
+	}
+}
@@ -4,208 +4,492 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 
-	"github.com/inference-gateway/adk/server"
 	"github.com/inference-gateway/sdk"
+
+	filegen "github.com/inference-gateway/mock-agent/internal/filegen"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
 )
 
-type MockLLMClient struct{}
+// MockLLMClient answers chat completions from scripted scenarios,
+// falling back to keyword-driven tool calls when no scenario matches.
+// Its settings can be changed while it is serving requests.
+type MockLLMClient struct {
+	source          *rng.Source
+	argsFromMessage bool
+	clarification   bool
+	tokenizer       Tokenizer
+	contextWindow   int
+	toolErrors      ToolErrorPolicy
+
+	mu       sync.RWMutex
+	settings Settings
+	defaults Settings
+	queue    []ScenarioTurn
 
-func NewMockLLMClient() server.LLMClient {
-	return &MockLLMClient{}
+	questions questions
 }
 
-func (m *MockLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
-	if len(messages) == 0 {
-		return nil, fmt.Errorf("no messages provided")
+// Settings is the runtime-adjustable behavior of the client
+type Settings struct {
+	Scenarios *ScenarioSet
+	Stream    StreamProfile
+	Faults    FaultConfig
+}
+
+// Option configures a MockLLMClient
+type Option func(*MockLLMClient)
+
+// WithScenarios makes the client consult the given scenarios before its built-in rules
+func WithScenarios(scenarios *ScenarioSet) Option {
+	return func(m *MockLLMClient) {
+		m.settings.Scenarios = scenarios
+	}
+}
+
+// WithSource draws completion IDs, tool call IDs and timestamps from source,
+// which makes responses reproducible when the source is seeded
+func WithSource(source *rng.Source) Option {
+	return func(m *MockLLMClient) {
+		m.source = source
 	}
+}
 
-	lastMessage := messages[len(messages)-1]
-	lastContent := lastMessage.Content
+// WithStreamProfile sets how streaming responses are chunked and paced
+func WithStreamProfile(profile StreamProfile) Option {
+	return func(m *MockLLMClient) {
+		m.settings.Stream = profile
+	}
+}
 
-	userMessage := ""
-	hasToolResults := false
-	var toolError string
-	for _, msg := range messages {
-		if msg.Role == sdk.User {
-			userMessage = msg.Content
+// WithFaults enables fault injection
+func WithFaults(faults FaultConfig) Option {
+	return func(m *MockLLMClient) {
+		m.settings.Faults = faults
+	}
+}
+
+// WithArgumentsFromMessage fills free-text string parameters of synthesized
+// tool calls with the user message instead of placeholders
+func WithArgumentsFromMessage(enabled bool) Option {
+	return func(m *MockLLMClient) {
+		m.argsFromMessage = enabled
+	}
+}
+
+// WithClarification asks the user for the missing subject of requests like
+// "validate this" through the input_required tool, when the agent offers it
+func WithClarification(enabled bool) Option {
+	return func(m *MockLLMClient) {
+		m.clarification = enabled
+	}
+}
+
+// WithTokenizer sets the approximation used to count tokens for usage reports
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(m *MockLLMClient) {
+		m.tokenizer = tokenizer
+	}
+}
+
+// WithContextWindow rejects requests whose prompt exceeds limit tokens (0 = unlimited)
+func WithContextWindow(limit int) Option {
+	return func(m *MockLLMClient) {
+		m.contextWindow = limit
+	}
+}
+
+// WithToolErrorPolicy sets how the model reacts to failed tool results
+func WithToolErrorPolicy(policy ToolErrorPolicy) Option {
+	return func(m *MockLLMClient) {
+		m.toolErrors = policy
+	}
+}
+
+// NewMockLLMClient creates a mock client. The settings given as options are
+// the ones Reset returns to.
+func NewMockLLMClient(opts ...Option) *MockLLMClient {
+	m := &MockLLMClient{
+		source:          rng.New(0, nil),
+		argsFromMessage: true,
+		clarification:   true,
+		tokenizer:       CharTokenizer,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.defaults = m.settings
+	return m
+}
+
+// Settings returns the current settings
+func (m *MockLLMClient) Settings() Settings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.settings
+}
+
+// SetScenarios replaces the scenarios; nil disables them
+func (m *MockLLMClient) SetScenarios(scenarios *ScenarioSet) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings.Scenarios = scenarios
+}
+
+// SetStreamProfile replaces the streaming profile
+func (m *MockLLMClient) SetStreamProfile(profile StreamProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings.Stream = profile
+	return nil
+}
+
+// SetFaults replaces the fault injection configuration
+func (m *MockLLMClient) SetFaults(faults FaultConfig) error {
+	if err := faults.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings.Faults = faults
+	return nil
+}
+
+// QueueResponses makes the next requests answer with the given turns, in
+// order, ahead of scenarios and built-in rules
+func (m *MockLLMClient) QueueResponses(turns ...ScenarioTurn) error {
+	for i := range turns {
+		if err := turns[i].validate(); err != nil {
+			return fmt.Errorf("response %d: %w", i+1, err)
 		}
-		if msg.Role == sdk.Tool {
-			hasToolResults = true
-			if contains(toLower(msg.Content), "error") || contains(toLower(msg.Content), "failed") {
-				toolError = msg.Content
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queue = append(m.queue, turns...)
+	return nil
+}
+
+// QueuedResponses returns how many queued responses are left, counting repeats
+func (m *MockLLMClient) QueuedResponses() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for _, turn := range m.queue {
+		count += turn.rounds()
+	}
+	return count
+}
+
+// Reset restores the settings the client was created with and drops queued responses
+func (m *MockLLMClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings = m.defaults
+	m.queue = nil
+}
+
+// nextQueued pops the oldest queued response, or one repeat of it
+func (m *MockLLMClient) nextQueued() *ScenarioTurn {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.queue) == 0 {
+		return nil
+	}
+	turn := m.queue[0]
+	if turn.rounds() > 1 {
+		m.queue[0].Repeat--
+	} else {
+		m.queue = m.queue[1:]
+	}
+	return &turn
+}
+
+// completion is the mock model output for one request, shared by the
+// streaming and non-streaming code paths
+type completion struct {
+	content      string
+	toolCalls    []sdk.ChatCompletionMessageToolCall
+	finishReason sdk.ChatCompletionChoiceFinishReason
+	// fault is the injected failure, if any
+	fault string
+	usage *sdk.CompletionUsage
+}
+
+// conversation summarizes the parts of the message history the mock reacts to
+type conversation struct {
+	lastContent string
+	userMessage string
+	// request is the user message that started the current request; it differs
+	// from userMessage when the user answered a clarification question
+	request string
+	// answer is the latest user message when it answers a question asked
+	// through the input_required tool
+	answer string
+	// hasToolResults and lastToolResult cover the rounds since the latest user message
+	hasToolResults bool
+	lastToolResult string
+	// turn counts assistant messages since the latest user message
+	turn int
+	// failures are the failed tool results of the latest round
+	failures []toolFailure
+	// failedAttempts counts failed results per tool since the latest user message
+	failedAttempts map[string]int
+}
+
+func summarize(messages []sdk.Message, asked func(content string) bool) conversation {
+	var conv conversation
+	if len(messages) > 0 {
+		conv.lastContent = messages[len(messages)-1].Content
+	}
+
+	calls := map[string]sdk.ChatCompletionMessageToolCall{}
+	conv.failedAttempts = map[string]int{}
+	awaitingInput := false
+	for _, msg := range messages {
+		switch msg.Role {
+		case sdk.User:
+			if awaitingInput {
+				conv.answer = msg.Content
+			} else {
+				conv.request = msg.Content
+				conv.answer = ""
+			}
+			awaitingInput = false
+			conv.userMessage = msg.Content
+			conv.hasToolResults = false
+			conv.lastToolResult = ""
+			conv.turn = 0
+			conv.failures = nil
+			clear(conv.failedAttempts)
+		case sdk.Assistant:
+			conv.turn++
+			conv.failures = nil
+			if msg.ToolCalls != nil && len(*msg.ToolCalls) > 0 {
+				awaitingInput = false
+				for _, call := range *msg.ToolCalls {
+					calls[call.Id] = call
+					awaitingInput = awaitingInput || call.Function.Name == inputRequiredTool
+				}
+			} else if asked != nil && asked(msg.Content) {
+				// A2A task history keeps the question of an input_required
+				// call as a plain assistant message, without the call
+				awaitingInput = true
+			}
+		case sdk.Tool:
+			conv.hasToolResults = true
+			conv.lastToolResult = msg.Content
+			if result := toolresult.Parse(msg.Content); result.Failed() {
+				var call sdk.ChatCompletionMessageToolCall
+				if msg.ToolCallId != nil {
+					call = calls[*msg.ToolCallId]
+				}
+				conv.failures = append(conv.failures, toolFailure{call: call, result: result})
+				conv.failedAttempts[call.Function.Name]++
 			}
 		}
 	}
 
-	if toolError != "" {
-		return nil, fmt.Errorf("tool execution failed: %s", toolError)
+	return conv
+}
+
+// intent is the request with the user's answer to a clarification, if any
+func (c conversation) intent() string {
+	if c.answer == "" {
+		return c.request
+	}
+	return c.request + "\n" + c.answer
+}
+
+// complete produces the response for a request and decides which fault, if
+// any, is injected into it
+func (m *MockLLMClient) complete(settings Settings, messages []sdk.Message, tools []sdk.ChatCompletionTool, streaming bool) (*completion, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages provided")
 	}
 
-	var responseContent string
-	var toolCalls *[]sdk.ChatCompletionMessageToolCall
+	prompt := promptTokens(m.tokenizer, messages, tools)
+	if m.contextWindow > 0 && prompt > m.contextWindow {
+		return nil, &ContextLengthError{Tokens: prompt, Limit: m.contextWindow}
+	}
 
-	if len(tools) > 0 && !hasToolResults {
-		calls := generateMockToolCalls(tools, lastContent)
-		toolCalls = &calls
-		responseContent = ""
+	var result *completion
+	var err error
+	if turn := m.nextQueued(); turn != nil {
+		result, err = turn.completion(m.source)
 	} else {
-		content := lastContent
-		if hasToolResults && userMessage != "" {
-			content = "Task completed successfully. I executed the requested operation based on: " + userMessage
+		result, err = m.respond(settings.Scenarios, messages, tools, streaming)
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.questions.record(result.toolCalls)
+
+	if result.fault == "" {
+		result.fault = settings.Faults.pick(summarize(messages, nil), m.source)
+	}
+	if result.fault == FaultMalformedArgs {
+		corruptArguments(result)
+	}
+
+	result.usage = usage(prompt, completionTokens(m.tokenizer, result))
+
+	return result, nil
+}
+
+// respond decides what the mock model answers. Scenarios take precedence
+// over the built-in keyword rules.
+func (m *MockLLMClient) respond(scenarios *ScenarioSet, messages []sdk.Message, tools []sdk.ChatCompletionTool, streaming bool) (*completion, error) {
+	if _, turn := scenarios.Match(messages, tools); turn != nil {
+		return turn.completion(m.source)
+	}
+
+	conv := summarize(messages, m.questions.asked)
+	if len(conv.failures) > 0 {
+		return m.handleToolFailures(conv)
+	}
+
+	if calls := m.resumeRequest(conv, tools); len(calls) > 0 {
+		return &completion{toolCalls: calls, finishReason: sdk.ToolCalls}, nil
+	}
+
+	if calls, planned := m.planToolCalls(conv, messages, tools); planned {
+		if len(calls) > 0 {
+			return &completion{toolCalls: calls, finishReason: sdk.ToolCalls}, nil
+		}
+	} else if len(tools) > 0 && !conv.hasToolResults {
+		if calls := m.generateMockToolCalls(tools, conv.lastContent); len(calls) > 0 {
+			if question := m.clarify(tools, calls, conv.lastContent); question != nil {
+				calls = question
+			}
+			return &completion{toolCalls: calls, finishReason: sdk.ToolCalls}, nil
+		}
+	}
+
+	content := conv.lastContent
+	if conv.hasToolResults && conv.userMessage != "" {
+		if streaming {
+			content = "Task completed successfully."
+		} else {
+			content = "Task completed successfully. I executed the requested operation based on: " + conv.intent()
 		}
-		responseContent = generateMockResponse(content)
+	}
+
+	return &completion{content: generateMockResponse(content), finishReason: sdk.Stop}, nil
+}
+
+func (m *MockLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	settings := m.Settings()
+	result, err := m.complete(settings, messages, tools, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := settings.Faults.failFast(ctx, result.fault); err != nil {
+		return nil, err
+	}
+
+	finishReason := result.finishReason
+	switch result.fault {
+	case FaultDropStream:
+		return nil, ErrDroppedStream
+	case FaultNoFinish:
+		finishReason = ""
+	}
+
+	var toolCalls *[]sdk.ChatCompletionMessageToolCall
+	if len(result.toolCalls) > 0 {
+		toolCalls = &result.toolCalls
 	}
 
 	return &sdk.CreateChatCompletionResponse{
-		Id:      "mock-" + generateID(),
+		Id:      "mock-" + m.source.ID(),
 		Model:   "mock-model",
 		Object:  "chat.completion",
-		Created: 1234567890,
+		Created: int(m.source.Now().Unix()),
 		Choices: []sdk.ChatCompletionChoice{
 			{
 				Index: 0,
 				Message: sdk.Message{
 					Role:      sdk.Assistant,
-					Content:   responseContent,
+					Content:   result.content,
 					ToolCalls: toolCalls,
 				},
-				FinishReason: sdk.Stop,
+				FinishReason: finishReason,
 			},
 		},
-		Usage: &sdk.CompletionUsage{
-			PromptTokens:     100,
-			CompletionTokens: 50,
-			TotalTokens:      150,
-		},
+		Usage: result.usage,
 	}, nil
 }
 
 func (m *MockLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
-	respChan := make(chan *sdk.CreateChatCompletionStreamResponse, 10)
+	// Unbuffered, so every chunk is received before errChan closes: the agent
+	// stops reading the stream as soon as it sees a closed error channel
+	respChan := make(chan *sdk.CreateChatCompletionStreamResponse)
 	errChan := make(chan error, 1)
 
 	go func() {
 		defer close(respChan)
 		defer close(errChan)
 
-		if len(messages) == 0 {
-			errChan <- fmt.Errorf("no messages provided")
+		settings := m.Settings()
+		result, err := m.complete(settings, messages, tools, true)
+		if err != nil {
+			errChan <- err
 			return
 		}
 
-		lastMessage := messages[len(messages)-1]
-		lastContent := lastMessage.Content
-
-		userMessage := ""
-		hasToolResults := false
-		var toolError string
-		for _, msg := range messages {
-			if msg.Role == sdk.User {
-				userMessage = msg.Content
-			}
-			if msg.Role == sdk.Tool {
-				hasToolResults = true
-				if contains(toLower(msg.Content), "error") || contains(toLower(msg.Content), "failed") {
-					toolError = msg.Content
-				}
-			}
-		}
-
-		if toolError != "" {
-			errChan <- fmt.Errorf("tool execution failed: %s", toolError)
+		if err := settings.Faults.failFast(ctx, result.fault); err != nil {
+			errChan <- err
 			return
 		}
 
-		if len(tools) > 0 && !hasToolResults {
-			toolCalls := generateMockToolCalls(tools, lastContent)
-			if len(toolCalls) > 0 {
-				for idx, toolCall := range toolCalls {
-					chunk := sdk.ChatCompletionMessageToolCallChunk{
-						Index: idx,
-						ID:    toolCall.Id,
-						Type:  string(toolCall.Type),
-						Function: struct {
-							Name      string `json:"name,omitempty"`
-							Arguments string `json:"arguments,omitempty"`
-						}{
-							Name:      toolCall.Function.Name,
-							Arguments: toolCall.Function.Arguments,
-						},
-					}
-
-					respChan <- &sdk.CreateChatCompletionStreamResponse{
-						ID:      "mock-stream-" + generateID(),
-						Model:   "mock-model",
-						Object:  "chat.completion.chunk",
-						Created: 1234567890,
-						Choices: []sdk.ChatCompletionStreamChoice{
-							{
-								Index: 0,
-								Delta: sdk.ChatCompletionStreamResponseDelta{
-									ToolCalls: []sdk.ChatCompletionMessageToolCallChunk{chunk},
-								},
-								FinishReason: "",
-							},
-						},
-					}
-				}
+		id := "mock-stream-" + m.source.ID()
+		created := int(m.source.Now().Unix())
 
-				respChan <- &sdk.CreateChatCompletionStreamResponse{
-					ID:      "mock-stream-" + generateID(),
-					Model:   "mock-model",
-					Object:  "chat.completion.chunk",
-					Created: 1234567890,
-					Choices: []sdk.ChatCompletionStreamChoice{
-						{
-							Index:        0,
-							Delta:        sdk.ChatCompletionStreamResponseDelta{},
-							FinishReason: string(sdk.ToolCalls),
-						},
-					},
-				}
-				return
-			}
+		stream := &chunkStream{
+			ctx:     ctx,
+			out:     respChan,
+			id:      id,
+			created: created,
+			profile: settings.Stream,
+			source:  m.source,
 		}
 
-		responseContent := lastContent
-		if hasToolResults && userMessage != "" {
-			responseContent = "Task completed successfully."
+		deltas := settings.Stream.deltas(result, m.source)
+		if result.fault == FaultDropStream && len(deltas) > 0 {
+			// The connection drops halfway through the middle chunk
+			cut := len(deltas) / 2
+			deltas = append(deltas[:cut:cut], cutShort(deltas[cut]))
 		}
 
-		response := generateMockResponse(responseContent)
-
-		respChan <- &sdk.CreateChatCompletionStreamResponse{
-			ID:      "mock-stream-" + generateID(),
-			Model:   "mock-model",
-			Object:  "chat.completion.chunk",
-			Created: 1234567890,
-			Choices: []sdk.ChatCompletionStreamChoice{
-				{
-					Index: 0,
-					Delta: sdk.ChatCompletionStreamResponseDelta{
-						Content: response,
-					},
-					FinishReason: "",
-				},
-			},
+		for _, delta := range deltas {
+			if !stream.send(delta, "", nil) {
+				return
+			}
 		}
 
-		respChan <- &sdk.CreateChatCompletionStreamResponse{
-			ID:      "mock-stream-" + generateID(),
-			Model:   "mock-model",
-			Object:  "chat.completion.chunk",
-			Created: 1234567890,
-			Choices: []sdk.ChatCompletionStreamChoice{
-				{
-					Index: 0,
-					Delta: sdk.ChatCompletionStreamResponseDelta{
-						Content: "",
-					},
-					FinishReason: string(sdk.Stop),
-				},
-			},
+		switch result.fault {
+		case FaultDropStream:
+			errChan <- ErrDroppedStream
+			return
+		case FaultNoFinish:
+			return
 		}
+
+		stream.send(sdk.ChatCompletionStreamResponseDelta{}, string(result.finishReason), result.usage)
 	}()
 
 	return respChan, errChan
@@ -215,33 +499,68 @@ func generateMockResponse(userMessage string) string {
 	return fmt.Sprintf("This is a mock response to: %q. I'm a mock agent designed for testing purposes.", userMessage)
 }
 
-func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) []sdk.ChatCompletionMessageToolCall {
+// builtinTools have hand-written argument rules in matchToolCalls
+var builtinTools = map[string]bool{
+	"error":             true,
+	"delay":             true,
+	"validate":          true,
+	"create_artifact":   true,
+	"generate_artifact": true,
+	"random_data":       true,
+	"long_running":      true,
+}
+
+func (m *MockLLMClient) generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) []sdk.ChatCompletionMessageToolCall {
 	if len(tools) == 0 {
 		return nil
 	}
 
+	if calls := m.matchToolCalls(tools, userMessage); calls != nil {
+		return calls
+	}
+
+	return m.defaultToolCalls(tools, userMessage)
+}
+
+// matchToolCalls returns the tool call the message asks for through
+// keywords or a tool name, or nil when it asks for none
+func (m *MockLLMClient) matchToolCalls(tools []sdk.ChatCompletionTool, userMessage string) []sdk.ChatCompletionMessageToolCall {
 	lowerMsg := toLower(userMessage)
 
-	if contains(lowerMsg, "error") || contains(lowerMsg, "fail") || contains(lowerMsg, "throw") {
+	// A JSON Schema in the message describes records to generate, so its
+	// property names are not read as keywords
+	if embeddedSchema(userMessage) != nil {
+		lowerMsg = lowerMsg[:strings.Index(lowerMsg, "{")]
+	}
+
+	// Checked before the error rule, since a job may be asked to "fail at step 3"
+	if jobRequest.MatchString(lowerMsg) {
 		for _, tool := range tools {
-			if tool.Function.Name == "error" {
-				errorType := "validation"
-				if contains(lowerMsg, "timeout") {
-					errorType = "timeout"
-				} else if contains(lowerMsg, "internal") || contains(lowerMsg, "server") {
-					errorType = "internal"
-				} else if contains(lowerMsg, "not found") || contains(lowerMsg, "404") {
-					errorType = "not_found"
+			if tool.Function.Name == "long_running" {
+				args, _ := json.Marshal(longRunningArguments(lowerMsg))
+
+				return []sdk.ChatCompletionMessageToolCall{
+					{
+						Id:   "call-" + m.source.ID(),
+						Type: sdk.Function,
+						Function: sdk.ChatCompletionMessageToolCallFunction{
+							Name:      "long_running",
+							Arguments: string(args),
+						},
+					},
 				}
+			}
+		}
+	}
 
-				args, _ := json.Marshal(map[string]any{
-					"error_type": errorType,
-					"message":    userMessage,
-				})
+	if containsAny(lowerMsg, errorWords) {
+		for _, tool := range tools {
+			if tool.Function.Name == "error" {
+				args, _ := json.Marshal(errorArguments(userMessage, lowerMsg))
 
 				return []sdk.ChatCompletionMessageToolCall{
 					{
-						Id:   "call-" + generateID(),
+						Id:   "call-" + m.source.ID(),
 						Type: sdk.Function,
 						Function: sdk.ChatCompletionMessageToolCallFunction{
 							Name:      "error",
@@ -272,7 +591,7 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 
 				return []sdk.ChatCompletionMessageToolCall{
 					{
-						Id:   "call-" + generateID(),
+						Id:   "call-" + m.source.ID(),
 						Type: sdk.Function,
 						Function: sdk.ChatCompletionMessageToolCallFunction{
 							Name:      "delay",
@@ -287,25 +606,11 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 	if contains(lowerMsg, "validate") || contains(lowerMsg, "check") {
 		for _, tool := range tools {
 			if tool.Function.Name == "validate" {
-				pattern := "email"
-				if contains(lowerMsg, "url") || contains(lowerMsg, "http") {
-					pattern = "url"
-				} else if contains(lowerMsg, "json") {
-					pattern = "json"
-				} else if contains(lowerMsg, "uuid") {
-					pattern = "uuid"
-				} else if contains(lowerMsg, "phone") {
-					pattern = "phone"
-				}
-
-				args, _ := json.Marshal(map[string]any{
-					"pattern": pattern,
-					"input":   userMessage,
-				})
+				args, _ := json.Marshal(validateArguments(tool, userMessage, lowerMsg))
 
 				return []sdk.ChatCompletionMessageToolCall{
 					{
-						Id:   "call-" + generateID(),
+						Id:   "call-" + m.source.ID(),
 						Type: sdk.Function,
 						Function: sdk.ChatCompletionMessageToolCallFunction{
 							Name:      "validate",
@@ -318,6 +623,26 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 	}
 
 	if contains(lowerMsg, "artifact") || contains(lowerMsg, "create file") || contains(lowerMsg, "save file") {
+		// Artifacts are generated unless create_artifact is asked for by name
+		if !contains(lowerMsg, "create_artifact") {
+			for _, tool := range tools {
+				if tool.Function.Name == "generate_artifact" {
+					args, _ := json.Marshal(artifactArguments(lowerMsg))
+
+					return []sdk.ChatCompletionMessageToolCall{
+						{
+							Id:   "call-" + m.source.ID(),
+							Type: sdk.Function,
+							Function: sdk.ChatCompletionMessageToolCallFunction{
+								Name:      "generate_artifact",
+								Arguments: string(args),
+							},
+						},
+					}
+				}
+			}
+		}
+
 		for _, tool := range tools {
 			if tool.Function.Name == "create_artifact" {
 				name := "sample-data.json"
@@ -340,7 +665,7 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 
 				return []sdk.ChatCompletionMessageToolCall{
 					{
-						Id:   "call-" + generateID(),
+						Id:   "call-" + m.source.ID(),
 						Type: sdk.Function,
 						Function: sdk.ChatCompletionMessageToolCallFunction{
 							Name:      "create_artifact",
@@ -355,34 +680,11 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 	if contains(lowerMsg, "random") || contains(lowerMsg, "generate") {
 		for _, tool := range tools {
 			if tool.Function.Name == "random_data" {
-				dataType := "uuid"
-				count := 5
-				if contains(lowerMsg, "email") {
-					dataType = "email"
-				} else if contains(lowerMsg, "name") {
-					dataType = "name"
-				} else if contains(lowerMsg, "number") {
-					dataType = "number"
-				} else if contains(lowerMsg, "json") {
-					dataType = "json"
-				}
-
-				if contains(lowerMsg, "10") {
-					count = 10
-				} else if contains(lowerMsg, "3") {
-					count = 3
-				} else if contains(lowerMsg, "1") && !contains(lowerMsg, "10") {
-					count = 1
-				}
-
-				args, _ := json.Marshal(map[string]any{
-					"data_type": dataType,
-					"count":     count,
-				})
+				args, _ := json.Marshal(randomDataArguments(userMessage, lowerMsg))
 
 				return []sdk.ChatCompletionMessageToolCall{
 					{
-						Id:   "call-" + generateID(),
+						Id:   "call-" + m.source.ID(),
 						Type: sdk.Function,
 						Function: sdk.ChatCompletionMessageToolCallFunction{
 							Name:      "random_data",
@@ -394,6 +696,446 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 		}
 	}
 
+	// Any other tool the user mentions by name is called with arguments
+	// synthesized from its parameters schema
+	for _, tool := range tools {
+		name := tool.Function.Name
+		if name != "" && !builtinTools[name] && contains(lowerMsg, toLower(name)) {
+			return []sdk.ChatCompletionMessageToolCall{m.synthesizeToolCall(tool, userMessage)}
+		}
+	}
+
+	return nil
+}
+
+var (
+	// jobRequest asks for a long_running job in so many words, so a message
+	// merely mentioning a job or progress goes to the other rules
+	jobRequest = regexp.MustCompile(`long[\s-]running|with progress|reports?\s+(?:its\s+)?progress|progress updates|\d+[\s-]*(?:steps?|stages?)\s+job\b`)
+	// jobSteps reads the step count of a job, e.g. "a 10 step job"
+	jobSteps = regexp.MustCompile(`(\d+)[\s-]*(?:steps?|stages?)\b`)
+	// jobStepDuration reads the duration of each step, e.g. "2 seconds per step"
+	jobStepDuration = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:s|secs?|seconds?)\s+(?:per|each|a)\b`)
+	// jobFailure reads where a job fails, e.g. "fail at step 3"
+	jobFailure = regexp.MustCompile(`fail\w*\s+(?:at|on|in)\s+(?:step|stage)\s+(\d+)`)
+)
+
+// longRunningArguments reads the job shape from a message, e.g. "run a 5 step
+// job with 2 seconds per step that fails at step 4"
+func longRunningArguments(lowerMsg string) map[string]any {
+	args := map[string]any{}
+	if match := jobSteps.FindStringSubmatch(lowerMsg); match != nil {
+		steps, _ := strconv.Atoi(match[1])
+		args["steps"] = min(max(steps, 1), 100)
+	}
+	if match := jobStepDuration.FindStringSubmatch(lowerMsg); match != nil {
+		seconds, _ := strconv.ParseFloat(match[1], 64)
+		args["step_duration_seconds"] = min(seconds, 300)
+	}
+	if match := jobFailure.FindStringSubmatch(lowerMsg); match != nil {
+		step, _ := strconv.Atoi(match[1])
+		args["fail_at_step"] = min(step, 100)
+	}
+	return args
+}
+
+// randomDataKinds map words of a request to random_data types, tried in
+// order so that e.g. "email addresses" are emails rather than addresses
+var randomDataKinds = []struct {
+	words []string
+	kind  string
+}{
+	{[]string{"iban"}, "iban"},
+	{[]string{"ipv6"}, "ipv6"},
+	{[]string{"ip address", "ipv4", " ips"}, "ipv4"},
+	{[]string{"mac address"}, "mac_address"},
+	{[]string{"email"}, "email"},
+	{[]string{"phone"}, "phone"},
+	{[]string{"first name"}, "first_name"},
+	{[]string{"last name", "surname"}, "last_name"},
+	{[]string{"username"}, "username"},
+	{[]string{"compan"}, "company"},
+	{[]string{"postal code", "zip"}, "postal_code"},
+	{[]string{"country code"}, "country_code"},
+	{[]string{"countr"}, "country"},
+	{[]string{"city", "cities"}, "city"},
+	{[]string{"street"}, "street"},
+	{[]string{"address"}, "address"},
+	{[]string{"datetime", "timestamp"}, "datetime"},
+	{[]string{"date"}, "date"},
+	{[]string{"url"}, "url"},
+	{[]string{"domain"}, "domain"},
+	{[]string{"paragraph"}, "paragraph"},
+	{[]string{"sentence", "lorem"}, "sentence"},
+	{[]string{"word"}, "word"},
+	{[]string{"name"}, "name"},
+	{[]string{"number"}, "number"},
+	{[]string{"boolean"}, "boolean"},
+	{[]string{"json", "record"}, "json"},
+}
+
+// randomDataLocales map words of a request to faker locales
+var randomDataLocales = []struct {
+	words  []string
+	locale string
+}{
+	{[]string{"german", "de_de"}, "de_DE"},
+	{[]string{"french", "fr_fr"}, "fr_FR"},
+	{[]string{"british", "en_gb"}, "en_GB"},
+	{[]string{"american", "en_us"}, "en_US"},
+}
+
+var (
+	// randomDataSeed reads a seed, e.g. "with seed 42"
+	randomDataSeed = regexp.MustCompile(`seed\s+(\d+)`)
+	// randomDataBatch reads a batch size, e.g. "in batches of 50"
+	randomDataBatch = regexp.MustCompile(`batch(?:es)?\s+of\s+(\d+)`)
+	// randomDataCount reads the first number left in a request
+	randomDataCount = regexp.MustCompile(`\b(\d+)\b`)
+)
+
+// randomDataArguments reads what to generate from a request. A JSON Schema
+// embedded in the message, e.g. generate 10 records like {"type": "object", ...},
+// asks for records of that shape; lowerMsg is the request without it.
+func randomDataArguments(message, lowerMsg string) map[string]any {
+	args := map[string]any{"count": 5}
+
+	if recordSchema := embeddedSchema(message); recordSchema != nil {
+		args["schema"] = recordSchema
+	} else {
+		args["data_type"] = "uuid"
+		for _, candidate := range randomDataKinds {
+			if containsAny(lowerMsg, candidate.words) {
+				args["data_type"] = candidate.kind
+				break
+			}
+		}
+	}
+
+	for _, candidate := range randomDataLocales {
+		if containsAny(lowerMsg, candidate.words) {
+			args["locale"] = candidate.locale
+			break
+		}
+	}
+
+	if match := randomDataSeed.FindStringSubmatch(lowerMsg); match != nil {
+		seed, _ := strconv.Atoi(match[1])
+		args["seed"] = seed
+		lowerMsg = strings.Replace(lowerMsg, match[0], "", 1)
+	}
+	if match := randomDataBatch.FindStringSubmatch(lowerMsg); match != nil {
+		size, _ := strconv.Atoi(match[1])
+		args["batch_size"] = min(max(size, 1), 1000)
+		args["stream"] = true
+		lowerMsg = strings.Replace(lowerMsg, match[0], "", 1)
+	}
+	if contains(lowerMsg, "stream") {
+		args["stream"] = true
+	}
+	if match := randomDataCount.FindStringSubmatch(lowerMsg); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		args["count"] = min(max(count, 1), 10000)
+	}
+	return args
+}
+
+// artifactFormats map words of a request to generate_artifact formats
+var artifactFormats = []struct {
+	words  []string
+	format string
+}{
+	{[]string{"png", "image"}, "png"},
+	{[]string{"pdf"}, "pdf"},
+	{[]string{"zip", "archive"}, "zip"},
+	{[]string{"markdown", " md "}, "markdown"},
+	{[]string{"csv"}, "csv"},
+	{[]string{"json"}, "json"},
+	{[]string{"text", "txt"}, "text"},
+	{[]string{"binary", "bin "}, "binary"},
+}
+
+// artifactSizeUnits are the multipliers of sizes in a request
+var artifactSizeUnits = map[string]int{
+	"b": 1, "byte": 1, "bytes": 1,
+	"kb": 1 << 10, "kib": 1 << 10,
+	"mb": 1 << 20, "mib": 1 << 20,
+	"gb": 1 << 30, "gib": 1 << 30,
+}
+
+var (
+	// artifactSize reads a size, e.g. "10 mb" or "512 bytes"
+	artifactSize = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(bytes?|b|kib|kb|mib|mb|gib|gb)\b`)
+	// artifactCount reads how many artifacts to create, e.g. "3 png files"
+	artifactCount = regexp.MustCompile(`\b(\d+)\s+(?:([a-z]+)\s+)?(?:artifacts|files)\b`)
+	// artifactChunks reads how many chunks to stream, e.g. "in 8 chunks"
+	artifactChunks = regexp.MustCompile(`(\d+)\s+chunks`)
+	// artifactChunkSize reads the size of streamed chunks, e.g. "chunks of 64 kb"
+	artifactChunkSize = regexp.MustCompile(`chunks\s+of\s+(\d+(?:\.\d+)?)\s*(bytes?|b|kib|kb|mib|mb)\b`)
+)
+
+// artifactArguments reads the artifacts a request asks for, e.g. "generate
+// 3 png artifacts of 5 mb streamed in 10 chunks"
+func artifactArguments(lowerMsg string) map[string]any {
+	args := map[string]any{}
+	for _, candidate := range artifactFormats {
+		if containsAny(lowerMsg+" ", candidate.words) {
+			args["format"] = candidate.format
+			break
+		}
+	}
+
+	if match := artifactChunkSize.FindStringSubmatch(lowerMsg); match != nil {
+		args["chunk_size_bytes"] = min(artifactBytes(match[1], match[2]), 16<<20)
+		lowerMsg = strings.Replace(lowerMsg, match[0], "", 1)
+	}
+
+	size := 1024
+	if match := artifactSize.FindStringSubmatch(lowerMsg); match != nil {
+		size = min(max(artifactBytes(match[1], match[2]), 1), filegen.MaxSize)
+		args["size_bytes"] = size
+	}
+
+	if match := artifactCount.FindStringSubmatch(lowerMsg); match != nil {
+		if _, unit := artifactSizeUnits[match[2]]; !unit {
+			count, _ := strconv.Atoi(match[1])
+			args["count"] = min(max(count, 1), 100)
+		}
+	}
+
+	if _, ok := args["chunk_size_bytes"]; !ok {
+		chunks := 0
+		if match := artifactChunks.FindStringSubmatch(lowerMsg); match != nil {
+			chunks, _ = strconv.Atoi(match[1])
+		} else if containsAny(lowerMsg, []string{"chunk", "stream"}) {
+			chunks = 4
+		}
+		if chunks > 0 {
+			args["chunk_size_bytes"] = max((size+chunks-1)/chunks, (size+9999)/10000)
+		}
+	}
+	return args
+}
+
+// artifactBytes converts a number and a unit of artifactSizeUnits to bytes
+func artifactBytes(number, unit string) int {
+	n, _ := strconv.ParseFloat(number, 64)
+	return int(n * float64(artifactSizeUnits[unit]))
+}
+
+// embeddedSchema returns the JSON Schema object a message contains, if any
+func embeddedSchema(message string) map[string]any {
+	start, end := strings.Index(message, "{"), strings.LastIndex(message, "}")
+	if start < 0 || end < start {
+		return nil
+	}
+
+	var candidate map[string]any
+	if json.Unmarshal([]byte(message[start:end+1]), &candidate) != nil {
+		return nil
+	}
+	if _, ok := candidate["type"]; !ok {
+		if _, ok := candidate["properties"]; !ok {
+			return nil
+		}
+	}
+	return candidate
+}
+
+func containsAny(s string, words []string) bool {
+	for _, word := range words {
+		if contains(s, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// validationType returns the validation type a message mentions, or "" for none
+func validationType(lowerMsg string) string {
+	switch {
+	case containsAny(lowerMsg, []string{"json schema", "json_schema", "conform"}):
+		return "json_schema"
+	case containsAny(lowerMsg, []string{"credit card", "card number", "luhn"}):
+		return "credit_card"
+	case contains(lowerMsg, "ipv6"):
+		return "ipv6"
+	case containsAny(lowerMsg, []string{"ipv4", "ip address"}):
+		return "ipv4"
+	case containsAny(lowerMsg, []string{"semver", "semantic version"}):
+		return "semver"
+	case contains(lowerMsg, "cron"):
+		return "cron"
+	case contains(lowerMsg, "base64"):
+		return "base64"
+	// " date" so that "validate" does not count
+	case containsAny(lowerMsg, []string{"iso 8601", "iso-8601", " date", "timestamp"}):
+		return "date"
+	case contains(lowerMsg, "url") || contains(lowerMsg, "http"):
+		return "url"
+	case contains(lowerMsg, "json"):
+		return "json"
+	case contains(lowerMsg, "uuid"):
+		return "uuid"
+	case contains(lowerMsg, "phone"):
+		return "phone"
+	case contains(lowerMsg, "email"):
+		return "email"
+	}
+	return ""
+}
+
+// defaultValidationType is the validation type a message mentions, email by default
+func defaultValidationType(lowerMsg string) string {
+	if pattern := validationType(lowerMsg); pattern != "" {
+		return pattern
+	}
+	return "email"
+}
+
+// errorWords ask for the error skill
+var errorWords = []string{"error", "fail", "throw", "reject", "panic", "crash", "auth required", "auth-required", "unauthorized"}
+
+var (
+	// errorRPCCode reads a JSON-RPC error code, e.g. "jsonrpc error -32001"
+	errorRPCCode = regexp.MustCompile(`-32\d{3}`)
+	// errorHTTPStatus reads an HTTP status, e.g. "http 429"
+	errorHTTPStatus = regexp.MustCompile(`\b([45]\d\d)\b`)
+	// errorRetryAfter reads a Retry-After in seconds, e.g. "retry after 30"
+	errorRetryAfter = regexp.MustCompile(`retry[- ]after\s+(\d+)`)
+	// errorPartialResults reads how many results come before a failure, e.g. "5 partial results"
+	errorPartialResults = regexp.MustCompile(`(\d+)\s+partial`)
+	// errorTimes reads how many polls see a wire error, e.g. "for 2 polls" or "3 times"
+	errorTimes = regexp.MustCompile(`(\d+)\s+(?:times?|polls?)\b`)
+)
+
+// errorArguments reads which failure a request asks the error skill for
+func errorArguments(message, lowerMsg string) map[string]any {
+	args := map[string]any{"message": message}
+
+	switch {
+	case containsAny(lowerMsg, []string{"panic", "crash"}):
+		args["error_type"] = "panic"
+	case contains(lowerMsg, "partial"):
+		args["error_type"] = "partial"
+		if match := errorPartialResults.FindStringSubmatch(lowerMsg); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			args["partial_results"] = min(max(n, 1), 100)
+		}
+	case contains(lowerMsg, "reject"):
+		args["error_type"] = "rejected"
+	case containsAny(lowerMsg, []string{"auth required", "auth-required", "auth_required", "unauthenticated", "sign in", "login"}):
+		args["error_type"] = "auth_required"
+	case containsAny(lowerMsg, []string{"jsonrpc", "json-rpc", "rpc error"}) || errorRPCCode.MatchString(lowerMsg):
+		args["error_type"] = "jsonrpc"
+		if code := errorRPCCode.FindString(lowerMsg); code != "" {
+			n, _ := strconv.Atoi(code)
+			args["code"] = n
+		}
+		errorTimesArgument(args, lowerMsg)
+	case contains(lowerMsg, "http") || contains(lowerMsg, "status code"):
+		args["error_type"] = "http"
+		if match := errorHTTPStatus.FindStringSubmatch(lowerMsg); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			args["status_code"] = n
+		}
+		if match := errorRetryAfter.FindStringSubmatch(lowerMsg); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			args["retry_after_seconds"] = n
+		}
+		errorTimesArgument(args, lowerMsg)
+	case containsAny(lowerMsg, []string{"fail the task", "task fail", "failed task"}):
+		args["error_type"] = "task_failed"
+	case contains(lowerMsg, "timeout"):
+		args["error_type"] = "timeout"
+	case contains(lowerMsg, "internal") || contains(lowerMsg, "server"):
+		args["error_type"] = "internal"
+	case contains(lowerMsg, "not found") || contains(lowerMsg, "404"):
+		args["error_type"] = "not_found"
+	default:
+		args["error_type"] = "validation"
+	}
+	return args
+}
+
+// errorTimesArgument limits a wire error to the number of polls the message names
+func errorTimesArgument(args map[string]any, lowerMsg string) {
+	if match := errorTimes.FindStringSubmatch(lowerMsg); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		args["times"] = n
+	}
+}
+
+// validateArguments reads a validation request. Types the tool lists beyond
+// the built-in keywords, like custom validators, count when named in the
+// message; "check {document} against json schema {schema}" is split into
+// the document and its schema.
+func validateArguments(tool sdk.ChatCompletionTool, message, lowerMsg string) map[string]any {
+	kind := validationType(lowerMsg)
+	if kind == "" {
+		kind = mentionedEnumValue(tool, "validation_type", lowerMsg)
+	}
+	if kind == "" {
+		kind = "email"
+	}
+
+	args := map[string]any{"validation_type": kind, "input": message}
+	if kind == "json_schema" {
+		if objects := jsonObjects(message); len(objects) == 2 {
+			var documentSchema map[string]any
+			if json.Unmarshal(objects[1], &documentSchema) == nil {
+				args["input"] = string(objects[0])
+				args["schema"] = documentSchema
+			}
+		}
+	}
+	return args
+}
+
+// mentionedEnumValue returns the longest value of a tool parameter's enum
+// that the message names, reading underscores as spaces too
+func mentionedEnumValue(tool sdk.ChatCompletionTool, parameter, lowerMsg string) string {
+	var parameters struct {
+		Properties map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"properties"`
+	}
+	data, err := json.Marshal(tool.Function.Parameters)
+	if err != nil || json.Unmarshal(data, &parameters) != nil {
+		return ""
+	}
+
+	best := ""
+	for _, value := range parameters.Properties[parameter].Enum {
+		lower := toLower(value)
+		if len(lower) > len(best) && containsAny(lowerMsg, []string{lower, strings.ReplaceAll(lower, "_", " ")}) {
+			best = value
+		}
+	}
+	return best
+}
+
+// jsonObjects returns the JSON objects a message contains, in order
+func jsonObjects(message string) []json.RawMessage {
+	var objects []json.RawMessage
+	for rest := message; ; {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			return objects
+		}
+		var object json.RawMessage
+		decoder := json.NewDecoder(strings.NewReader(rest[start:]))
+		if decoder.Decode(&object) != nil {
+			rest = rest[start+1:]
+			continue
+		}
+		objects = append(objects, object)
+		rest = rest[start+int(decoder.InputOffset()):]
+	}
+}
+
+// defaultToolCalls picks a tool when the message does not ask for one
+func (m *MockLLMClient) defaultToolCalls(tools []sdk.ChatCompletionTool, userMessage string) []sdk.ChatCompletionMessageToolCall {
 	for _, tool := range tools {
 		if tool.Function.Name == "create_artifact" {
 			name := "default-file.json"
@@ -408,7 +1150,7 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 
 			return []sdk.ChatCompletionMessageToolCall{
 				{
-					Id:   "call-" + generateID(),
+					Id:   "call-" + m.source.ID(),
 					Type: sdk.Function,
 					Function: sdk.ChatCompletionMessageToolCallFunction{
 						Name:      "create_artifact",
@@ -427,7 +1169,7 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 
 			return []sdk.ChatCompletionMessageToolCall{
 				{
-					Id:   "call-" + generateID(),
+					Id:   "call-" + m.source.ID(),
 					Type: sdk.Function,
 					Function: sdk.ChatCompletionMessageToolCallFunction{
 						Name:      "echo",
@@ -438,17 +1180,27 @@ func generateMockToolCalls(tools []sdk.ChatCompletionTool, userMessage string) [
 		}
 	}
 
-	args, _ := json.Marshal(map[string]any{})
-	name := tools[0].Function.Name
+	return []sdk.ChatCompletionMessageToolCall{m.synthesizeToolCall(tools[0], userMessage)}
+}
 
-	return []sdk.ChatCompletionMessageToolCall{
-		{
-			Id:   "call-" + generateID(),
-			Type: sdk.Function,
-			Function: sdk.ChatCompletionMessageToolCallFunction{
-				Name:      name,
-				Arguments: string(args),
-			},
+// synthesizeToolCall calls tool with arguments synthesized from its parameters schema
+func (m *MockLLMClient) synthesizeToolCall(tool sdk.ChatCompletionTool, userMessage string) sdk.ChatCompletionMessageToolCall {
+	synthesizer := &argumentSynthesizer{
+		source:      m.source,
+		userMessage: userMessage,
+		fromMessage: m.argsFromMessage,
+	}
+	args, err := synthesizer.arguments(tool)
+	if err != nil {
+		args = "{}"
+	}
+
+	return sdk.ChatCompletionMessageToolCall{
+		Id:   "call-" + m.source.ID(),
+		Type: sdk.Function,
+		Function: sdk.ChatCompletionMessageToolCallFunction{
+			Name:      tool.Function.Name,
+			Arguments: args,
 		},
 	}
 }
@@ -477,7 +1229,3 @@ func toLower(s string) string {
 	}
 	return string(result)
 }
-
-func generateID() string {
-	return fmt.Sprintf("%d", 1000000+len("mock"))
-}
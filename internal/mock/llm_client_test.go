@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+)
+
+func TestSummarizeAnswer(t *testing.T) {
+	question := "What message should I echo back?"
+	inputRequired := []sdk.ChatCompletionMessageToolCall{toolCall("call_1", inputRequiredTool, `{"message":"What message should I echo back?"}`)}
+	asked := &questions{}
+	asked.record(inputRequired)
+	echo := []sdk.ChatCompletionMessageToolCall{toolCall("call_2", "echo", `{"message":"hi"}`)}
+
+	tests := []struct {
+		name        string
+		msgs        []sdk.Message
+		wantRequest string
+		wantAnswer  string
+	}{
+		{
+			"input_required call",
+			[]sdk.Message{{Role: sdk.User, Content: "echo"}, {Role: sdk.Assistant, ToolCalls: &inputRequired}, {Role: sdk.User, Content: "hi"}},
+			"echo", "hi",
+		},
+		{
+			"question asked through input_required",
+			[]sdk.Message{{Role: sdk.User, Content: "echo"}, {Role: sdk.Assistant, Content: question}, {Role: sdk.User, Content: "hi"}},
+			"echo", "hi",
+		},
+		{
+			"reply ending in a question mark",
+			[]sdk.Message{{Role: sdk.User, Content: "echo"}, {Role: sdk.Assistant, Content: "Anything else?"}, {Role: sdk.User, Content: "hi"}},
+			"hi", "",
+		},
+		{
+			"answered question",
+			[]sdk.Message{{Role: sdk.User, Content: "echo"}, {Role: sdk.Assistant, Content: question}, {Role: sdk.User, Content: "hi"}, {Role: sdk.Assistant, ToolCalls: &echo}, {Role: sdk.User, Content: "bye"}},
+			"bye", "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := summarize(tt.msgs, asked.asked)
+			if conv.request != tt.wantRequest || conv.answer != tt.wantAnswer {
+				t.Errorf("request = %q, answer = %q, want %q, %q", conv.request, conv.answer, tt.wantRequest, tt.wantAnswer)
+			}
+		})
+	}
+}
+
+func TestMatchToolCalls(t *testing.T) {
+	var tools []sdk.ChatCompletionTool
+	for _, name := range []string{"echo", "error", "delay", "validate", "random_data", "long_running"} {
+		tools = append(tools, sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: name}})
+	}
+
+	tests := []struct {
+		message  string
+		wantTool string
+		wantArgs string
+	}{
+		{"start a long running task", "long_running", `{}`},
+		{"run a long-running import with progress updates", "long_running", `{}`},
+		{"process the files and report progress", "long_running", `{}`},
+		{"run a 10 step job with 2 seconds per step that fails at step 7", "long_running", `{"fail_at_step":7,"step_duration_seconds":2,"steps":10}`},
+		{"validate the job config", "validate", ""},
+		{"simulate a timeout error for the nightly job", "error", ""},
+		{"wait for the job to make progress", "delay", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			calls := NewMockLLMClient().matchToolCalls(tools, tt.message)
+			if len(calls) != 1 {
+				t.Fatalf("matchToolCalls() = %+v, want one call", calls)
+			}
+			if calls[0].Function.Name != tt.wantTool {
+				t.Errorf("matchToolCalls() calls %s, want %s", calls[0].Function.Name, tt.wantTool)
+			}
+			if tt.wantArgs != "" && calls[0].Function.Arguments != tt.wantArgs {
+				t.Errorf("arguments = %s, want %s", calls[0].Function.Arguments, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package mock
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inference-gateway/sdk"
+)
+
+// maxParallelCalls caps the tool calls a single planned round may emit
+const maxParallelCalls = 32
+
+var (
+	// roundSeparator splits a request into sequential rounds, e.g.
+	// "generate 3 uuids then validate each"
+	roundSeparator = regexp.MustCompile(`(?i)\s*(?:,\s*)?\b(?:and\s+)?then\b\s*`)
+	// parallelSeparator splits a round into parallel tool calls, e.g.
+	// "echo hi; validate a@b.com" or "echo hi and also validate a@b.com"
+	parallelSeparator = regexp.MustCompile(`(?i)\s*(?:;|\band also\b)\s*`)
+	// repeatPattern asks for the same call several times in one round
+	repeatPattern = regexp.MustCompile(`(?i)\b(\d+)\s*(?:times|x)\b`)
+	// eachPattern fans a call out over the items returned by the previous round
+	eachPattern = regexp.MustCompile(`(?i)\b(?:each|every one|all of them)\b`)
+)
+
+// planStep is one clause of a planned round
+type planStep struct {
+	clause string
+	times  int
+	each   bool
+}
+
+// planRequest reads a multi-step intent from the user message. It returns
+// nil for requests that are a single plain tool call.
+func planRequest(message string) [][]planStep {
+	var rounds [][]planStep
+	multi := false
+
+	for i, part := range roundSeparator.Split(message, -1) {
+		var round []planStep
+		for _, clause := range parallelSeparator.Split(part, -1) {
+			step := planStep{clause: strings.TrimSpace(clause), times: 1}
+			if match := repeatPattern.FindStringSubmatch(step.clause); match != nil {
+				n, _ := strconv.Atoi(match[1])
+				step.times = min(max(n, 1), maxParallelCalls)
+				step.clause = strings.TrimSpace(repeatPattern.ReplaceAllString(step.clause, ""))
+			}
+			step.each = i > 0 && eachPattern.MatchString(step.clause)
+			if step.clause == "" {
+				continue
+			}
+
+			multi = multi || step.times > 1 || step.each
+			round = append(round, step)
+		}
+
+		if len(round) > 0 {
+			multi = multi || len(round) > 1
+			rounds = append(rounds, round)
+		}
+	}
+
+	if !multi && len(rounds) < 2 {
+		return nil
+	}
+	return rounds
+}
+
+// planToolCalls answers requests that ask for parallel or sequential tool
+// calls. Each assistant reply since the user message advances one round;
+// once all rounds ran it returns no calls so the model gives its final
+// answer. planned is false when the request is not a multi-step plan.
+func (m *MockLLMClient) planToolCalls(conv conversation, messages []sdk.Message, tools []sdk.ChatCompletionTool) (calls []sdk.ChatCompletionMessageToolCall, planned bool) {
+	if len(tools) == 0 {
+		return nil, false
+	}
+
+	plan := planRequest(conv.userMessage)
+	if plan == nil {
+		return nil, false
+	}
+
+	// Drop clauses that do not ask for any of the offered tools
+	var rounds [][]planStep
+	for _, round := range plan {
+		var steps []planStep
+		for _, step := range round {
+			if m.matchToolCalls(tools, step.clause) != nil {
+				steps = append(steps, step)
+			}
+		}
+		if len(steps) > 0 {
+			rounds = append(rounds, steps)
+		}
+	}
+	if len(rounds) == 0 {
+		return nil, false
+	}
+	if len(rounds) == 1 && len(rounds[0]) == 1 && rounds[0][0].times == 1 {
+		// A single call is left to the built-in rules
+		return nil, false
+	}
+
+	if conv.turn >= len(rounds) {
+		return nil, true
+	}
+
+	var hints []string
+	if conv.turn > 0 {
+		for _, step := range rounds[conv.turn-1] {
+			hints = append(hints, step.clause)
+		}
+	}
+
+	for _, step := range rounds[conv.turn] {
+		if step.each {
+			if items := latestToolItems(messages); len(items) > 0 {
+				calls = append(calls, m.fanOut(tools, step.clause, strings.Join(hints, " "), items)...)
+				continue
+			}
+		}
+
+		for range step.times {
+			calls = append(calls, m.matchToolCalls(tools, step.clause)...)
+		}
+	}
+
+	if len(calls) > maxParallelCalls {
+		calls = calls[:maxParallelCalls]
+	}
+
+	return calls, true
+}
+
+// fanOut emits one call of the tool the clause asks for per item. The
+// previous round's clauses serve as hints for the arguments, so "validate
+// each" after "generate 3 uuids" validates UUIDs.
+func (m *MockLLMClient) fanOut(tools []sdk.ChatCompletionTool, clause, hints string, items []string) []sdk.ChatCompletionMessageToolCall {
+	var calls []sdk.ChatCompletionMessageToolCall
+	for _, item := range items {
+		call := m.matchToolCalls(tools, clause)
+		if hinted := m.matchToolCalls(tools, clause+" "+hints); len(hinted) > 0 && hinted[0].Function.Name == call[0].Function.Name {
+			call = hinted
+		}
+		call[0].Function.Arguments = substituteText(call[0].Function.Arguments, item)
+		calls = append(calls, call[0])
+	}
+	return calls
+}
+
+// latestToolItems returns the values produced by the latest round of tool
+// calls: the items of the first array in each JSON result, or the whole
+// result when there is none
+func latestToolItems(messages []sdk.Message) []string {
+	start := len(messages)
+	for start > 0 && messages[start-1].Role == sdk.Tool {
+		start--
+	}
+
+	var items []string
+	for _, msg := range messages[start:] {
+		var result map[string]any
+		if err := json.Unmarshal([]byte(msg.Content), &result); err != nil {
+			items = append(items, msg.Content)
+			continue
+		}
+
+		keys := make([]string, 0, len(result))
+		for k := range result {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		found := false
+		for _, k := range keys {
+			if values, ok := result[k].([]any); ok && len(values) > 0 {
+				for _, v := range values {
+					if s, ok := v.(string); ok {
+						items = append(items, s)
+					} else {
+						b, _ := json.Marshal(v)
+						items = append(items, string(b))
+					}
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			items = append(items, msg.Content)
+		}
+	}
+
+	return items
+}
+
+// substituteText replaces the free-text arguments of a call with text
+func substituteText(arguments, text string) string {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return arguments
+	}
+
+	for name := range args {
+		if textProperties[name] {
+			args[name] = text
+		}
+	}
+
+	b, err := json.Marshal(args)
+	if err != nil {
+		return arguments
+	}
+	return string(b)
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+)
+
+func TestPlanRequest(t *testing.T) {
+	tests := []struct {
+		message string
+		want    [][]planStep
+	}{
+		{"echo hi", nil},
+		{"echo hi; echo bye", [][]planStep{{{"echo hi", 1, false}, {"echo bye", 1, false}}}},
+		{"echo hi and also validate a@b.com", [][]planStep{{{"echo hi", 1, false}, {"validate a@b.com", 1, false}}}},
+		{"echo hi 3 times", [][]planStep{{{"echo hi", 3, false}}}},
+		{"echo hi 100x", [][]planStep{{{"echo hi", maxParallelCalls, false}}}},
+		{"generate 3 uuids then validate each", [][]planStep{{{"generate 3 uuids", 1, false}}, {{"validate each", 1, true}}}},
+		{"echo each, then echo bye", [][]planStep{{{"echo each", 1, false}}, {{"echo bye", 1, false}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := planRequest(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanToolCalls(t *testing.T) {
+	var tools []sdk.ChatCompletionTool
+	for _, name := range []string{"echo", "validate", "random_data"} {
+		tools = append(tools, sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: name}})
+	}
+
+	request := "generate 3 uuids then validate each"
+	generate := []sdk.ChatCompletionMessageToolCall{toolCall("call_1", "random_data", `{"count":3,"data_type":"uuid"}`)}
+	validate := []sdk.ChatCompletionMessageToolCall{toolCall("call_2", "validate", `{"input":"u1","validation_type":"uuid"}`)}
+	generated := []sdk.Message{
+		{Role: sdk.User, Content: request},
+		{Role: sdk.Assistant, ToolCalls: &generate},
+		{Role: sdk.Tool, Content: `{"status":"success","data":["u1","u2","u3"]}`, ToolCallId: &generate[0].Id},
+	}
+
+	type call struct{ name, arguments string }
+	tests := []struct {
+		name        string
+		msgs        []sdk.Message
+		tools       []sdk.ChatCompletionTool
+		wantPlanned bool
+		want        []call
+	}{
+		{"single call", []sdk.Message{{Role: sdk.User, Content: "echo hi"}}, tools, false, nil},
+		{"no tools", []sdk.Message{{Role: sdk.User, Content: "echo hi; echo bye"}}, nil, false, nil},
+		{"unknown clause dropped", []sdk.Message{{Role: sdk.User, Content: "echo hi then dance"}}, tools, false, nil},
+		{
+			"parallel calls",
+			[]sdk.Message{{Role: sdk.User, Content: "echo hi and also validate a@b.com"}}, tools, true,
+			[]call{{"echo", "{}"}, {"validate", `{"input":"validate a@b.com","validation_type":"email"}`}},
+		},
+		{"repeated call", []sdk.Message{{Role: sdk.User, Content: "echo hi 3 times"}}, tools, true, []call{{"echo", "{}"}, {"echo", "{}"}, {"echo", "{}"}}},
+		{"first round", []sdk.Message{{Role: sdk.User, Content: request}}, tools, true, []call{{"random_data", `{"count":3,"data_type":"uuid"}`}}},
+		{
+			"fan out over previous results", generated, tools, true,
+			[]call{
+				{"validate", `{"input":"u1","validation_type":"uuid"}`},
+				{"validate", `{"input":"u2","validation_type":"uuid"}`},
+				{"validate", `{"input":"u3","validation_type":"uuid"}`},
+			},
+		},
+		{
+			"all rounds ran",
+			append(generated[:3:3], sdk.Message{Role: sdk.Assistant, ToolCalls: &validate}, sdk.Message{Role: sdk.Tool, Content: `{"status":"success","valid":true}`, ToolCallId: &validate[0].Id}),
+			tools, true, nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMockLLMClient()
+			calls, planned := m.planToolCalls(summarize(tt.msgs, nil), tt.msgs, tt.tools)
+			if planned != tt.wantPlanned {
+				t.Fatalf("planned = %v, want %v", planned, tt.wantPlanned)
+			}
+			var got []call
+			for _, c := range calls {
+				got = append(got, call{c.Function.Name, c.Function.Arguments})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/inference-gateway/adk/server"
+	"github.com/inference-gateway/sdk"
+	"go.uber.org/zap"
+)
+
+// RecordingLLMClient forwards requests to a real LLM client and writes
+// every request/response pair, streamed chunks included, to a fixtures directory
+type RecordingLLMClient struct {
+	upstream server.LLMClient
+	dir      string
+	logger   *zap.Logger
+	mu       sync.Mutex
+}
+
+// NewRecordingLLMClient creates a client that records upstream traffic into dir
+func NewRecordingLLMClient(upstream server.LLMClient, dir string, logger *zap.Logger) (*RecordingLLMClient, error) {
+	if upstream == nil {
+		return nil, fmt.Errorf("upstream llm client is required")
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("fixtures directory is required")
+	}
+
+	return &RecordingLLMClient{
+		upstream: upstream,
+		dir:      dir,
+		logger:   logger,
+	}, nil
+}
+
+func (r *RecordingLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	resp, err := r.upstream.CreateChatCompletion(ctx, messages, tools...)
+
+	fixture := &Fixture{
+		Key:      FixtureKey(false, messages, tools),
+		Messages: messages,
+		Tools:    tools,
+		Response: resp,
+	}
+	if err != nil {
+		fixture.Error = err.Error()
+	}
+	// A request the caller gave up on says nothing about the provider
+	if ctx.Err() == nil {
+		r.save(fixture)
+	}
+
+	return resp, err
+}
+
+func (r *RecordingLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	upstreamResp, upstreamErr := r.upstream.CreateStreamingChatCompletion(ctx, messages, tools...)
+
+	// Unbuffered, see MockLLMClient.CreateStreamingChatCompletion
+	respChan := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(respChan)
+		defer close(errChan)
+
+		fixture := &Fixture{
+			Key:      FixtureKey(true, messages, tools),
+			Stream:   true,
+			Messages: messages,
+			Tools:    tools,
+		}
+
+		// Only complete streams are saved; one cut short by the caller
+		// would replay as a truncated response
+		complete := func() {
+			if ctx.Err() == nil {
+				r.save(fixture)
+			}
+		}
+
+		for {
+			select {
+			case chunk, ok := <-upstreamResp:
+				if !ok {
+					select {
+					case err := <-upstreamErr:
+						if err != nil {
+							fixture.Error = err.Error()
+							errChan <- err
+						}
+					default:
+					}
+					complete()
+					return
+				}
+				fixture.Chunks = append(fixture.Chunks, chunk)
+				select {
+				case respChan <- chunk:
+				case <-ctx.Done():
+					return
+				}
+
+			case err, ok := <-upstreamErr:
+				if !ok {
+					upstreamErr = nil
+					continue
+				}
+				if err != nil {
+					fixture.Error = err.Error()
+					errChan <- err
+					complete()
+					return
+				}
+			}
+		}
+	}()
+
+	return respChan, errChan
+}
+
+func (r *RecordingLLMClient) save(fixture *Fixture) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := SaveFixture(r.dir, fixture); err != nil {
+		r.logger.Error("failed to record fixture", zap.String("key", fixture.Key), zap.Error(err))
+		return
+	}
+
+	r.logger.Debug("recorded fixture",
+		zap.String("key", fixture.Key),
+		zap.Bool("stream", fixture.Stream),
+		zap.Int("chunks", len(fixture.Chunks)))
+}
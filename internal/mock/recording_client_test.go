@@ -0,0 +1,102 @@
+package mock
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+	"go.uber.org/zap"
+)
+
+// streamingUpstream streams a fixed number of chunks, each on demand
+type streamingUpstream struct {
+	chunks int
+}
+
+func (u streamingUpstream) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	return &sdk.CreateChatCompletionResponse{Id: "resp"}, ctx.Err()
+}
+
+func (u streamingUpstream) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	chunks := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+		for range u.chunks {
+			select {
+			case chunks <- &sdk.CreateChatCompletionStreamResponse{ID: "chunk"}:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return chunks, errs
+}
+
+func TestRecordingStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		read       int
+		wantChunks int
+		wantSaved  bool
+	}{
+		{"complete stream", 3, 3, true},
+		{"canceled mid-stream", 1, 0, false},
+	}
+
+	messages := []sdk.Message{{Role: sdk.User, Content: "hi"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			client, err := NewRecordingLLMClient(streamingUpstream{chunks: 3}, dir, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			chunks, errs := client.CreateStreamingChatCompletion(ctx, messages)
+			for range tt.read {
+				<-chunks
+			}
+			if tt.read < 3 {
+				cancel()
+			}
+			for range chunks {
+			}
+			for range errs {
+			}
+			cancel()
+
+			fixtures, err := LoadFixtures(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fixture, saved := fixtures[FixtureKey(true, messages, nil)]
+			if saved != tt.wantSaved {
+				t.Fatalf("saved = %v, want %v (%d fixtures)", saved, tt.wantSaved, len(fixtures))
+			}
+			if saved && len(fixture.Chunks) != tt.wantChunks {
+				t.Errorf("fixture has %d chunks, want %d", len(fixture.Chunks), tt.wantChunks)
+			}
+		})
+	}
+}
+
+func TestRecordingCanceledRequest(t *testing.T) {
+	dir := t.TempDir()
+	client, err := NewRecordingLLMClient(streamingUpstream{}, dir, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = client.CreateChatCompletion(ctx, []sdk.Message{{Role: sdk.User, Content: "hi"}})
+
+	if paths, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(paths) > 0 {
+		t.Errorf("saved %v for a canceled request", paths)
+	}
+}
@@ -0,0 +1,89 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/inference-gateway/adk/server"
+	"github.com/inference-gateway/sdk"
+)
+
+// ReplayLLMClient serves responses recorded by RecordingLLMClient,
+// looked up by the normalized hash of the request messages and tools
+type ReplayLLMClient struct {
+	fixtures map[string]*Fixture
+	fallback server.LLMClient
+}
+
+// NewReplayLLMClient loads the fixtures in dir. Requests without a fixture are
+// passed to fallback, or fail when fallback is nil.
+func NewReplayLLMClient(dir string, fallback server.LLMClient) (*ReplayLLMClient, error) {
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayLLMClient{
+		fixtures: fixtures,
+		fallback: fallback,
+	}, nil
+}
+
+// Len returns the number of loaded fixtures
+func (r *ReplayLLMClient) Len() int {
+	return len(r.fixtures)
+}
+
+func (r *ReplayLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	key := FixtureKey(false, messages, tools)
+	fixture, ok := r.fixtures[key]
+	if !ok {
+		if r.fallback != nil {
+			return r.fallback.CreateChatCompletion(ctx, messages, tools...)
+		}
+		return nil, fmt.Errorf("no fixture recorded for request %s", key)
+	}
+
+	if fixture.Error != "" {
+		return nil, errors.New(fixture.Error)
+	}
+
+	return fixture.Response, nil
+}
+
+func (r *ReplayLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	key := FixtureKey(true, messages, tools)
+	fixture, ok := r.fixtures[key]
+	if !ok && r.fallback != nil {
+		return r.fallback.CreateStreamingChatCompletion(ctx, messages, tools...)
+	}
+
+	// Unbuffered, see MockLLMClient.CreateStreamingChatCompletion
+	respChan := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(respChan)
+		defer close(errChan)
+
+		if !ok {
+			errChan <- fmt.Errorf("no fixture recorded for request %s", key)
+			return
+		}
+
+		for _, chunk := range fixture.Chunks {
+			select {
+			case respChan <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if fixture.Error != "" {
+			errChan <- errors.New(fixture.Error)
+		}
+	}()
+
+	return respChan, errChan
+}
@@ -0,0 +1,264 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/inference-gateway/sdk"
+	"gopkg.in/yaml.v3"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// ScenarioSet is an ordered list of scripted conversation scenarios.
+// The first scenario whose match rules accept the conversation and which
+// still has a turn left for the current round wins.
+type ScenarioSet struct {
+	Scenarios []Scenario `yaml:"scenarios" json:"scenarios"`
+}
+
+// Scenario pins the exact assistant output for each round of a conversation
+type Scenario struct {
+	Name  string         `yaml:"name" json:"name"`
+	Match ScenarioMatch  `yaml:"match" json:"match"`
+	Turns []ScenarioTurn `yaml:"turns" json:"turns"`
+}
+
+// ScenarioMatch holds the conditions a conversation must satisfy.
+// Empty fields are not checked.
+type ScenarioMatch struct {
+	// UserMessage is a regular expression matched against the latest user message
+	UserMessage string `yaml:"user_message" json:"user_message,omitempty"`
+	// MinMessages and MaxMessages bound the number of messages in the request, system prompt included
+	MinMessages int `yaml:"min_messages" json:"min_messages,omitempty"`
+	MaxMessages int `yaml:"max_messages" json:"max_messages,omitempty"`
+	// Tools lists tool names that must all be offered to the model
+	Tools []string `yaml:"tools" json:"tools,omitempty"`
+	// HasToolResults requires the presence or absence of tool results since the latest user message
+	HasToolResults *bool `yaml:"has_tool_results" json:"has_tool_results,omitempty"`
+	// ToolResult is a regular expression matched against the latest tool result
+	ToolResult string `yaml:"tool_result" json:"tool_result,omitempty"`
+
+	userMessage *regexp.Regexp
+	toolResult  *regexp.Regexp
+}
+
+// ScenarioTurn is the assistant response returned for one round
+type ScenarioTurn struct {
+	Content      string             `yaml:"content" json:"content,omitempty"`
+	ToolCalls    []ScenarioToolCall `yaml:"tool_calls" json:"tool_calls,omitempty"`
+	FinishReason string             `yaml:"finish_reason" json:"finish_reason,omitempty"`
+	// Fault injects one of the fault types into this turn
+	Fault string `yaml:"fault" json:"fault,omitempty"`
+	// Repeat answers this many consecutive rounds with the turn (default 1)
+	Repeat int `yaml:"repeat" json:"repeat,omitempty"`
+}
+
+// ScenarioToolCall is a tool call emitted by a scenario turn.
+// Arguments may be a mapping, which is encoded as JSON, or a string, which is sent verbatim.
+type ScenarioToolCall struct {
+	ID        string `yaml:"id" json:"id,omitempty"`
+	Name      string `yaml:"name" json:"name"`
+	Arguments any    `yaml:"arguments" json:"arguments,omitempty"`
+}
+
+// LoadScenarios reads and compiles a scenario file
+func LoadScenarios(path string) (*ScenarioSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenarios file: %w", err)
+	}
+
+	return ParseScenarios(data)
+}
+
+// ParseScenarios decodes scenarios from YAML (or JSON) and compiles their match rules
+func ParseScenarios(data []byte) (*ScenarioSet, error) {
+	var set ScenarioSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse scenarios: %w", err)
+	}
+
+	if err := set.compile(); err != nil {
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+func (s *ScenarioSet) compile() error {
+	for i := range s.Scenarios {
+		sc := &s.Scenarios[i]
+		if sc.Name == "" {
+			sc.Name = fmt.Sprintf("scenario-%d", i+1)
+		}
+
+		if len(sc.Turns) == 0 {
+			return fmt.Errorf("scenario %q must declare at least one turn", sc.Name)
+		}
+
+		for j := range sc.Turns {
+			if err := sc.Turns[j].validate(); err != nil {
+				return fmt.Errorf("scenario %q turn %d: %w", sc.Name, j+1, err)
+			}
+		}
+
+		if sc.Match.UserMessage != "" {
+			re, err := regexp.Compile(sc.Match.UserMessage)
+			if err != nil {
+				return fmt.Errorf("scenario %q has invalid user_message pattern: %w", sc.Name, err)
+			}
+			sc.Match.userMessage = re
+		}
+
+		if sc.Match.ToolResult != "" {
+			re, err := regexp.Compile(sc.Match.ToolResult)
+			if err != nil {
+				return fmt.Errorf("scenario %q has invalid tool_result pattern: %w", sc.Name, err)
+			}
+			sc.Match.toolResult = re
+		}
+	}
+
+	return nil
+}
+
+// Match returns the scenario and turn that apply to the conversation, if any
+func (s *ScenarioSet) Match(messages []sdk.Message, tools []sdk.ChatCompletionTool) (*Scenario, *ScenarioTurn) {
+	if s == nil {
+		return nil, nil
+	}
+
+	conv := summarize(messages, nil)
+	for i := range s.Scenarios {
+		sc := &s.Scenarios[i]
+		turn := sc.turn(conv.turn)
+		if turn == nil {
+			continue
+		}
+		if sc.Match.matches(conv, len(messages), tools) {
+			return sc, turn
+		}
+	}
+
+	return nil, nil
+}
+
+// turn returns the turn that answers the given round, counting repeats
+func (sc *Scenario) turn(round int) *ScenarioTurn {
+	for i := range sc.Turns {
+		n := sc.Turns[i].rounds()
+		if round < n {
+			return &sc.Turns[i]
+		}
+		round -= n
+	}
+	return nil
+}
+
+func (m *ScenarioMatch) matches(conv conversation, messageCount int, tools []sdk.ChatCompletionTool) bool {
+	if m.userMessage != nil && !m.userMessage.MatchString(conv.userMessage) {
+		return false
+	}
+	if m.MinMessages > 0 && messageCount < m.MinMessages {
+		return false
+	}
+	if m.MaxMessages > 0 && messageCount > m.MaxMessages {
+		return false
+	}
+	if m.HasToolResults != nil && *m.HasToolResults != conv.hasToolResults {
+		return false
+	}
+	if m.toolResult != nil && !m.toolResult.MatchString(conv.lastToolResult) {
+		return false
+	}
+
+	for _, name := range m.Tools {
+		if !hasTool(tools, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *ScenarioTurn) validate() error {
+	if t.Fault != "" && !faultTypes[t.Fault] {
+		return fmt.Errorf("unknown fault %q", t.Fault)
+	}
+
+	if t.Repeat < 0 {
+		return fmt.Errorf("repeat must not be negative")
+	}
+
+	for _, call := range t.ToolCalls {
+		if call.Name == "" {
+			return fmt.Errorf("tool call without a name")
+		}
+	}
+
+	return nil
+}
+
+// rounds is the number of rounds the turn answers
+func (t *ScenarioTurn) rounds() int {
+	return max(t.Repeat, 1)
+}
+
+// completion converts the turn into the response the client returns
+func (t *ScenarioTurn) completion(source *rng.Source) (*completion, error) {
+	result := &completion{
+		content:      t.Content,
+		finishReason: sdk.ChatCompletionChoiceFinishReason(t.FinishReason),
+		fault:        t.Fault,
+	}
+
+	for _, call := range t.ToolCalls {
+		args := "{}"
+		switch v := call.Arguments.(type) {
+		case nil:
+		case string:
+			args = v
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode arguments for tool %q: %w", call.Name, err)
+			}
+			args = string(b)
+		}
+
+		id := call.ID
+		if id == "" {
+			id = "call-" + source.ID()
+		}
+
+		result.toolCalls = append(result.toolCalls, sdk.ChatCompletionMessageToolCall{
+			Id:   id,
+			Type: sdk.Function,
+			Function: sdk.ChatCompletionMessageToolCallFunction{
+				Name:      call.Name,
+				Arguments: args,
+			},
+		})
+	}
+
+	if result.finishReason == "" {
+		result.finishReason = sdk.Stop
+		if len(result.toolCalls) > 0 {
+			result.finishReason = sdk.ToolCalls
+		}
+	}
+
+	return result, nil
+}
+
+func hasTool(tools []sdk.ChatCompletionTool, name string) bool {
+	for _, tool := range tools {
+		if tool.Function.Name == name {
+			return true
+		}
+	}
+	return false
+}
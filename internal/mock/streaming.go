@@ -0,0 +1,223 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Chunking strategies for streamed content and tool call arguments
+const (
+	ChunkNone   = "none"
+	ChunkWord   = "word"
+	ChunkRune   = "rune"
+	ChunkBytes  = "bytes"
+	ChunkRandom = "random"
+)
+
+// StreamProfile controls how streaming responses are cut into chunks and paced
+type StreamProfile struct {
+	// Chunking is one of none, word, rune, bytes or random
+	Chunking string `json:"chunking"`
+	// ChunkSize is the chunk length in bytes for "bytes" and the upper bound for "random"
+	ChunkSize int `json:"chunk_size"`
+	// Latency is the pause before every chunk after the first
+	Latency time.Duration `json:"latency"`
+	// Jitter randomly shifts each pause by up to this much in either direction
+	Jitter time.Duration `json:"jitter"`
+}
+
+// Validate checks the strategy name and sizes
+func (p StreamProfile) Validate() error {
+	switch p.Chunking {
+	case "", ChunkNone, ChunkWord, ChunkRune:
+	case ChunkBytes, ChunkRandom:
+		if p.ChunkSize < 1 {
+			return fmt.Errorf("chunk size must be at least 1 for %q chunking", p.Chunking)
+		}
+	default:
+		return fmt.Errorf("unknown chunking strategy %q: must be one of (none, word, rune, bytes, random)", p.Chunking)
+	}
+
+	if p.Latency < 0 || p.Jitter < 0 {
+		return fmt.Errorf("stream latency and jitter must not be negative")
+	}
+
+	return nil
+}
+
+// chunked reports whether text is split at all
+func (p StreamProfile) chunked() bool {
+	return p.Chunking != "" && p.Chunking != ChunkNone
+}
+
+// split cuts text into chunks. The bytes and random strategies cut at byte
+// offsets and may split multi-byte runes on purpose.
+func (p StreamProfile) split(text string, source *rng.Source) []string {
+	if text == "" || !p.chunked() {
+		return []string{text}
+	}
+
+	var chunks []string
+	switch p.Chunking {
+	case ChunkWord:
+		start := 0
+		inSpace := false
+		for i, r := range text {
+			space := unicode.IsSpace(r)
+			if inSpace && !space && i > start {
+				chunks = append(chunks, text[start:i])
+				start = i
+			}
+			inSpace = space
+		}
+		chunks = append(chunks, text[start:])
+
+	case ChunkRune:
+		for len(text) > 0 {
+			_, size := utf8.DecodeRuneInString(text)
+			chunks = append(chunks, text[:size])
+			text = text[size:]
+		}
+
+	case ChunkBytes, ChunkRandom:
+		for len(text) > 0 {
+			size := p.ChunkSize
+			if p.Chunking == ChunkRandom {
+				size = 1 + source.Intn(p.ChunkSize)
+			}
+			size = min(size, len(text))
+			chunks = append(chunks, text[:size])
+			text = text[size:]
+		}
+	}
+
+	return chunks
+}
+
+// pause returns the delay before the next chunk
+func (p StreamProfile) pause(source *rng.Source) time.Duration {
+	d := p.Latency
+	if p.Jitter > 0 {
+		d += time.Duration(source.Float64()*float64(2*p.Jitter)) - p.Jitter
+	}
+	return max(d, 0)
+}
+
+// deltas renders the content and tool calls of a completion as stream
+// deltas, without the final finish delta
+func (p StreamProfile) deltas(result *completion, source *rng.Source) []sdk.ChatCompletionStreamResponseDelta {
+	var deltas []sdk.ChatCompletionStreamResponseDelta
+
+	if result.content != "" || len(result.toolCalls) == 0 {
+		for _, piece := range p.split(result.content, source) {
+			deltas = append(deltas, sdk.ChatCompletionStreamResponseDelta{Content: piece})
+		}
+	}
+
+	for idx, toolCall := range result.toolCalls {
+		for _, chunk := range p.toolCallChunks(idx, toolCall, source) {
+			deltas = append(deltas, sdk.ChatCompletionStreamResponseDelta{
+				ToolCalls: []sdk.ChatCompletionMessageToolCallChunk{chunk},
+			})
+		}
+	}
+
+	return deltas
+}
+
+// cutShort returns the first half of a chunk, as a dropped connection leaves it
+func cutShort(delta sdk.ChatCompletionStreamResponseDelta) sdk.ChatCompletionStreamResponseDelta {
+	content := []rune(delta.Content)
+	delta.Content = string(content[:len(content)/2])
+
+	calls := slices.Clone(delta.ToolCalls)
+	for i := range calls {
+		args := []rune(calls[i].Function.Arguments)
+		calls[i].Function.Arguments = string(args[:len(args)/2])
+	}
+	delta.ToolCalls = calls
+	return delta
+}
+
+// toolCallChunks renders a tool call the way providers stream it: a header
+// with the ID and function name, followed by the arguments in pieces. Without
+// chunking the whole call is sent at once.
+func (p StreamProfile) toolCallChunks(index int, call sdk.ChatCompletionMessageToolCall, source *rng.Source) []sdk.ChatCompletionMessageToolCallChunk {
+	header := sdk.ChatCompletionMessageToolCallChunk{
+		Index: index,
+		ID:    call.Id,
+		Type:  string(call.Type),
+	}
+	header.Function.Name = call.Function.Name
+
+	if !p.chunked() {
+		header.Function.Arguments = call.Function.Arguments
+		return []sdk.ChatCompletionMessageToolCallChunk{header}
+	}
+
+	chunks := []sdk.ChatCompletionMessageToolCallChunk{header}
+	for _, piece := range p.split(call.Function.Arguments, source) {
+		chunk := sdk.ChatCompletionMessageToolCallChunk{Index: index}
+		chunk.Function.Arguments = piece
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// chunkStream sends stream responses that share one completion ID, pausing
+// between chunks according to the profile
+type chunkStream struct {
+	ctx     context.Context
+	out     chan<- *sdk.CreateChatCompletionStreamResponse
+	id      string
+	created int
+	profile StreamProfile
+	source  *rng.Source
+	sent    int
+}
+
+// send delivers one chunk and reports false once the context is done. Usage
+// is only reported on the final chunk.
+func (s *chunkStream) send(delta sdk.ChatCompletionStreamResponseDelta, finishReason string, usage *sdk.CompletionUsage) bool {
+	if s.sent > 0 {
+		if d := s.profile.pause(s.source); d > 0 {
+			select {
+			case <-time.After(d):
+			case <-s.ctx.Done():
+				return false
+			}
+		}
+	}
+	s.sent++
+
+	resp := &sdk.CreateChatCompletionStreamResponse{
+		ID:      s.id,
+		Model:   "mock-model",
+		Object:  "chat.completion.chunk",
+		Created: s.created,
+		Choices: []sdk.ChatCompletionStreamChoice{
+			{
+				Index:        0,
+				Delta:        delta,
+				FinishReason: finishReason,
+			},
+		},
+		Usage: usage,
+	}
+
+	select {
+	case s.out <- resp:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,252 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/inference-gateway/sdk"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+func TestStreamFaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		fault      string
+		chunking   string
+		wantErr    error
+		wantFinish bool
+		// complete tells whether the whole content arrives
+		complete bool
+	}{
+		{"no fault", "none", "none", nil, true, true},
+		{"no_finish", "no_finish", "none", nil, false, true},
+		{"drop_stream", "drop_stream", "none", ErrDroppedStream, false, false},
+		{"drop_stream chunked", "drop_stream", "word", ErrDroppedStream, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewMockLLMClient(
+				WithFaults(FaultConfig{Markers: true}),
+				WithStreamProfile(StreamProfile{Chunking: tt.chunking}),
+				WithArgumentsFromMessage(false),
+			)
+			messages := []sdk.Message{{Role: sdk.User, Content: "hello [[fault:" + tt.fault + "]]"}}
+			respChan, errChan := client.CreateStreamingChatCompletion(context.Background(), messages)
+
+			var content strings.Builder
+			finished := false
+			for chunk := range respChan {
+				for _, choice := range chunk.Choices {
+					content.WriteString(choice.Delta.Content)
+					finished = finished || choice.FinishReason != ""
+				}
+			}
+			err := <-errChan
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if finished != tt.wantFinish {
+				t.Errorf("finished = %v, want %v", finished, tt.wantFinish)
+			}
+			got := content.String()
+			want := generateMockResponse(messages[0].Content)
+			if complete := got == want; complete != tt.complete {
+				t.Errorf("content = %q, want complete = %v", got, tt.complete)
+			}
+			if !strings.HasPrefix(want, got) || (!tt.complete && got == "") {
+				t.Errorf("content = %q, want a prefix of %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile StreamProfile
+		text    string
+		want    []string
+	}{
+		{"none", StreamProfile{Chunking: ChunkNone}, "hello big world", []string{"hello big world"}},
+		{"unset", StreamProfile{}, "hello big world", []string{"hello big world"}},
+		{"empty text", StreamProfile{Chunking: ChunkWord}, "", []string{""}},
+		{"word", StreamProfile{Chunking: ChunkWord}, "hello  big\nworld ", []string{"hello  ", "big\n", "world "}},
+		{"word with leading space", StreamProfile{Chunking: ChunkWord}, " hi there", []string{" ", "hi ", "there"}},
+		{"rune", StreamProfile{Chunking: ChunkRune}, "héllo", []string{"h", "é", "l", "l", "o"}},
+		{"bytes", StreamProfile{Chunking: ChunkBytes, ChunkSize: 4}, "hello world", []string{"hell", "o wo", "rld"}},
+		{"bytes split a rune", StreamProfile{Chunking: ChunkBytes, ChunkSize: 2}, "héllo", []string{"h\xc3", "\xa9l", "lo"}},
+		{"bytes larger than text", StreamProfile{Chunking: ChunkBytes, ChunkSize: 64}, "hello", []string{"hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.profile.split(tt.text, rng.New(1, nil))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("split(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitRandom(t *testing.T) {
+	profile := StreamProfile{Chunking: ChunkRandom, ChunkSize: 5}
+	text := strings.Repeat("lorem ipsum dolor sit amet ", 10)
+
+	chunks := profile.split(text, rng.New(3, nil))
+	sizes := map[int]bool{}
+	for _, chunk := range chunks {
+		if len(chunk) < 1 || len(chunk) > profile.ChunkSize {
+			t.Fatalf("chunk %q is not 1 to %d bytes long", chunk, profile.ChunkSize)
+		}
+		sizes[len(chunk)] = true
+	}
+	if strings.Join(chunks, "") != text {
+		t.Error("chunks do not add up to the text")
+	}
+	if len(sizes) < 2 {
+		t.Errorf("chunks all have the same size: %v", sizes)
+	}
+	if again := profile.split(text, rng.New(3, nil)); !reflect.DeepEqual(chunks, again) {
+		t.Error("seed 3 split the text differently")
+	}
+}
+
+func TestStreamProfileValidate(t *testing.T) {
+	tests := []struct {
+		profile StreamProfile
+		wantErr bool
+	}{
+		{StreamProfile{}, false},
+		{StreamProfile{Chunking: ChunkWord, Latency: time.Millisecond, Jitter: time.Millisecond}, false},
+		{StreamProfile{Chunking: ChunkRandom, ChunkSize: 1}, false},
+		{StreamProfile{Chunking: ChunkBytes}, true},
+		{StreamProfile{Chunking: ChunkRandom, ChunkSize: -1}, true},
+		{StreamProfile{Chunking: "sentence"}, true},
+		{StreamProfile{Latency: -time.Second}, true},
+		{StreamProfile{Jitter: -time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.profile.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, want error %v", tt.profile, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPause(t *testing.T) {
+	profile := StreamProfile{Latency: 10 * time.Millisecond, Jitter: 4 * time.Millisecond}
+	source := rng.New(5, nil)
+	for range 100 {
+		if d := profile.pause(source); d < 6*time.Millisecond || d > 14*time.Millisecond {
+			t.Fatalf("pause = %v, want 6ms to 14ms", d)
+		}
+	}
+
+	// Jitter larger than the latency never makes a negative pause
+	profile = StreamProfile{Latency: time.Millisecond, Jitter: 10 * time.Millisecond}
+	for range 100 {
+		if d := profile.pause(source); d < 0 {
+			t.Fatalf("pause = %v, want none negative", d)
+		}
+	}
+}
+
+func TestToolCallChunks(t *testing.T) {
+	call := sdk.ChatCompletionMessageToolCall{Id: "call_1", Type: sdk.Function}
+	call.Function.Name = "echo"
+	call.Function.Arguments = `{"message": "hi there"}`
+
+	tests := []struct {
+		name    string
+		profile StreamProfile
+		want    int
+	}{
+		{"none", StreamProfile{Chunking: ChunkNone}, 1},
+		{"word", StreamProfile{Chunking: ChunkWord}, 1 + 3},
+		{"bytes", StreamProfile{Chunking: ChunkBytes, ChunkSize: 8}, 1 + 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := tt.profile.toolCallChunks(2, call, rng.New(1, nil))
+			if len(chunks) != tt.want {
+				t.Fatalf("got %d chunks, want %d", len(chunks), tt.want)
+			}
+			if header := chunks[0]; header.ID != "call_1" || header.Function.Name != "echo" {
+				t.Errorf("header = %+v, want the call ID and function name", header)
+			}
+
+			var arguments strings.Builder
+			for i, chunk := range chunks {
+				if chunk.Index != 2 {
+					t.Errorf("chunk %d has index %d, want 2", i, chunk.Index)
+				}
+				if i > 0 && (chunk.ID != "" || chunk.Function.Name != "") {
+					t.Errorf("chunk %d repeats the header", i)
+				}
+				arguments.WriteString(chunk.Function.Arguments)
+			}
+			if arguments.String() != call.Function.Arguments {
+				t.Errorf("arguments = %q, want %q", arguments.String(), call.Function.Arguments)
+			}
+		})
+	}
+}
+
+func TestStreamChunking(t *testing.T) {
+	profiles := []StreamProfile{
+		{Chunking: ChunkNone},
+		{Chunking: ChunkWord},
+		{Chunking: ChunkRune},
+		{Chunking: ChunkBytes, ChunkSize: 3},
+		{Chunking: ChunkRandom, ChunkSize: 7},
+	}
+
+	for _, profile := range profiles {
+		t.Run(profile.Chunking, func(t *testing.T) {
+			client := NewMockLLMClient(WithStreamProfile(profile), WithSource(rng.New(1, nil)))
+			messages := []sdk.Message{{Role: sdk.User, Content: "Grüße aus dem Test"}}
+			respChan, errChan := client.CreateStreamingChatCompletion(context.Background(), messages)
+
+			var content strings.Builder
+			chunks, ids := 0, map[string]bool{}
+			for chunk := range respChan {
+				ids[chunk.ID] = true
+				for _, choice := range chunk.Choices {
+					if choice.Delta.Content != "" {
+						chunks++
+					}
+					content.WriteString(choice.Delta.Content)
+				}
+			}
+			if err := <-errChan; err != nil {
+				t.Fatal(err)
+			}
+
+			want := generateMockResponse(messages[0].Content)
+			if content.String() != want {
+				t.Errorf("content = %q, want %q", content.String(), want)
+			}
+			if !utf8.ValidString(content.String()) {
+				t.Error("content is not valid UTF-8 once put together")
+			}
+			if len(ids) != 1 {
+				t.Errorf("chunks have %d completion IDs, want 1", len(ids))
+			}
+			if wantChunks := len(profile.split(want, rng.New(1, nil))); profile.Chunking != ChunkRandom && chunks != wantChunks {
+				t.Errorf("got %d content chunks, want %d", chunks, wantChunks)
+			}
+			if profile.Chunking != ChunkNone && chunks < 2 {
+				t.Errorf("got %d content chunks, want the content split", chunks)
+			}
+		})
+	}
+}
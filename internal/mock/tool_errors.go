@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inference-gateway/sdk"
+
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// Actions the mock model takes when a tool call fails
+const (
+	// ToolErrorFail fails the completion, which fails the task
+	ToolErrorFail = "fail"
+	// ToolErrorRetry calls the failed tool again with the same arguments
+	ToolErrorRetry = "retry"
+	// ToolErrorExplain answers the user with an explanation of the failure
+	ToolErrorExplain = "explain"
+	// ToolErrorAuto retries errors marked retryable and explains the rest
+	ToolErrorAuto = "auto"
+)
+
+var toolErrorActions = map[string]bool{
+	ToolErrorFail:    true,
+	ToolErrorRetry:   true,
+	ToolErrorExplain: true,
+	ToolErrorAuto:    true,
+}
+
+// ToolErrorPolicy decides how the mock model reacts to failed tool results
+type ToolErrorPolicy struct {
+	// Default is the action for error codes without an entry in Actions
+	Default string `json:"default"`
+	// Actions maps error codes, e.g. timeout or invalid_arguments, to an action
+	Actions map[string]string `json:"actions,omitempty"`
+	// MaxRetries bounds how often one tool is retried after the latest user
+	// message; once exhausted the failure is explained instead
+	MaxRetries int `json:"max_retries"`
+}
+
+// Validate checks the action names
+func (p ToolErrorPolicy) Validate() error {
+	if p.Default != "" && !toolErrorActions[p.Default] {
+		return fmt.Errorf("unknown tool error action %q: must be one of (fail, retry, explain, auto)", p.Default)
+	}
+	for code, action := range p.Actions {
+		if !toolErrorActions[action] {
+			return fmt.Errorf("unknown tool error action %q for code %q: must be one of (fail, retry, explain, auto)", action, code)
+		}
+	}
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("tool error retries must not be negative")
+	}
+	return nil
+}
+
+// action resolves what to do about a failure of a tool that already failed
+// attempts times since the latest user message
+func (p ToolErrorPolicy) action(failure toolFailure, attempts int) string {
+	action, ok := p.Actions[failure.result.Code]
+	if !ok {
+		action = p.Default
+	}
+
+	switch action {
+	case "":
+		return ToolErrorFail
+	case ToolErrorAuto:
+		action = ToolErrorExplain
+		if failure.result.Retryable {
+			action = ToolErrorRetry
+		}
+	}
+
+	if action == ToolErrorRetry && attempts > p.MaxRetries {
+		return ToolErrorExplain
+	}
+	return action
+}
+
+// toolFailure is a failed tool result of the latest round with the call that produced it
+type toolFailure struct {
+	call   sdk.ChatCompletionMessageToolCall
+	result toolresult.Result
+}
+
+// handleToolFailures applies the policy to the failures of the latest round.
+// Any failure resolved to fail fails the completion; otherwise the failed
+// calls to retry are issued again, and only when nothing is retried does
+// the model explain the failures.
+func (m *MockLLMClient) handleToolFailures(conv conversation) (*completion, error) {
+	var retries []sdk.ChatCompletionMessageToolCall
+	var explanations []string
+
+	for _, failure := range conv.failures {
+		name := failure.call.Function.Name
+		action := m.toolErrors.action(failure, conv.failedAttempts[name])
+		if action == ToolErrorRetry && name == "" {
+			// The result does not belong to a known call, so there is nothing to retry
+			action = ToolErrorExplain
+			name = "unknown"
+		}
+
+		switch action {
+		case ToolErrorFail:
+			return nil, fmt.Errorf("tool execution failed: %s returned %s: %s", name, failure.result.Code, failure.result.Message)
+
+		case ToolErrorRetry:
+			call := failure.call
+			call.Id = "call-" + m.source.ID()
+			retries = append(retries, call)
+
+		default:
+			explanations = append(explanations, fmt.Sprintf("The %s tool failed with %s: %s", name, failure.result.Code, failure.result.Message))
+		}
+	}
+
+	if len(retries) > 0 {
+		return &completion{toolCalls: retries, finishReason: sdk.ToolCalls}, nil
+	}
+
+	content := "I could not complete the request.\n" + strings.Join(explanations, "\n")
+	return &completion{content: content, finishReason: sdk.Stop}, nil
+}
@@ -0,0 +1,119 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/inference-gateway/sdk"
+)
+
+// Tokenizer approximations for usage accounting
+const (
+	TokenizerChars = "chars"
+	TokenizerWords = "words"
+)
+
+// Token overheads of the chat format, following OpenAI's accounting
+const (
+	tokensPerMessage = 3
+	tokensPerTool    = 8
+	tokensPerReply   = 3
+)
+
+// Tokenizer estimates how many tokens a model would see in a text
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// TokenizerFunc adapts a function to the Tokenizer interface
+type TokenizerFunc func(text string) int
+
+func (f TokenizerFunc) Count(text string) int {
+	return f(text)
+}
+
+// CharTokenizer counts one token per four bytes, the usual rule of thumb for English text
+var CharTokenizer = TokenizerFunc(func(text string) int {
+	return (len(text) + 3) / 4
+})
+
+// WordTokenizer counts words and punctuation, with long words split every four characters
+var WordTokenizer = TokenizerFunc(func(text string) int {
+	count := 0
+	for _, field := range strings.FieldsFunc(text, unicode.IsSpace) {
+		letters := 0
+		for _, r := range field {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				letters++
+				continue
+			}
+			count++
+		}
+		count += (letters + 3) / 4
+	}
+	return count
+})
+
+// NewTokenizer returns the tokenizer approximation with the given name
+func NewTokenizer(name string) (Tokenizer, error) {
+	switch name {
+	case "", TokenizerChars:
+		return CharTokenizer, nil
+	case TokenizerWords:
+		return WordTokenizer, nil
+	default:
+		return nil, fmt.Errorf("unknown tokenizer %q: must be one of (chars, words)", name)
+	}
+}
+
+// ContextLengthError is returned when the prompt does not fit the context window
+type ContextLengthError struct {
+	Tokens int
+	Limit  int
+}
+
+func (e *ContextLengthError) Error() string {
+	return fmt.Sprintf("context length exceeded: the request has %d tokens but the model's context window is %d tokens", e.Tokens, e.Limit)
+}
+
+// promptTokens estimates the tokens of the messages and tool definitions sent to the model
+func promptTokens(tokenizer Tokenizer, messages []sdk.Message, tools []sdk.ChatCompletionTool) int {
+	total := tokensPerReply
+	for _, msg := range messages {
+		total += tokensPerMessage + tokenizer.Count(string(msg.Role)) + tokenizer.Count(msg.Content)
+		if msg.ToolCalls != nil {
+			total += toolCallTokens(tokenizer, *msg.ToolCalls)
+		}
+	}
+
+	for _, tool := range tools {
+		definition, _ := json.Marshal(tool.Function)
+		total += tokensPerTool + tokenizer.Count(string(definition))
+	}
+
+	return total
+}
+
+// completionTokens estimates the tokens of the generated content and tool calls
+func completionTokens(tokenizer Tokenizer, result *completion) int {
+	return tokenizer.Count(result.content) + toolCallTokens(tokenizer, result.toolCalls)
+}
+
+func toolCallTokens(tokenizer Tokenizer, calls []sdk.ChatCompletionMessageToolCall) int {
+	total := 0
+	for _, call := range calls {
+		total += tokensPerMessage + tokenizer.Count(call.Function.Name) + tokenizer.Count(call.Function.Arguments)
+	}
+	return total
+}
+
+// usage builds the usage report of a completion
+func usage(prompt, completion int) *sdk.CompletionUsage {
+	return &sdk.CompletionUsage{
+		PromptTokens:     int64(prompt),
+		CompletionTokens: int64(completion),
+		TotalTokens:      int64(prompt + completion),
+	}
+}
@@ -0,0 +1,190 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/inference-gateway/sdk"
+)
+
+func TestTokenizers(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer Tokenizer
+		text      string
+		want      int
+	}{
+		{"chars empty", CharTokenizer, "", 0},
+		{"chars short", CharTokenizer, "abc", 1},
+		{"chars exact", CharTokenizer, "abcdefgh", 2},
+		{"chars rounded up", CharTokenizer, "abcde", 2},
+		{"chars multi-byte", CharTokenizer, "héllo", 2},
+		{"words empty", WordTokenizer, "", 0},
+		{"words", WordTokenizer, "a b c", 3},
+		{"words of five letters", WordTokenizer, "hello world", 4},
+		{"words with punctuation", WordTokenizer, "Hi, there!", 5},
+		{"long word", WordTokenizer, "internationalization", 5},
+		{"words across whitespace", WordTokenizer, "one\ttwo\nsix", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tokenizer.Count(tt.text); got != tt.want {
+				t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTokenizer(t *testing.T) {
+	for name, want := range map[string]Tokenizer{"": CharTokenizer, "chars": CharTokenizer, "words": WordTokenizer} {
+		tokenizer, err := NewTokenizer(name)
+		if err != nil {
+			t.Fatalf("NewTokenizer(%q) error = %v", name, err)
+		}
+		if tokenizer.Count("hello, world") != want.Count("hello, world") {
+			t.Errorf("NewTokenizer(%q) counts differently from the tokenizer it names", name)
+		}
+	}
+	if _, err := NewTokenizer("bpe"); err == nil {
+		t.Error("NewTokenizer(bpe) accepted an unknown tokenizer")
+	}
+}
+
+func TestPromptTokens(t *testing.T) {
+	// none counts no text, so only the chat format overheads are left
+	none := TokenizerFunc(func(string) int { return 0 })
+
+	call := sdk.ChatCompletionMessageToolCall{Id: "call_1", Type: sdk.Function}
+	call.Function.Name = "echo"
+	call.Function.Arguments = `{"a":1}`
+	tool := sdk.ChatCompletionTool{Type: sdk.Function, Function: sdk.FunctionObject{Name: "echo"}}
+
+	user := sdk.Message{Role: sdk.User, Content: "hello"}
+	assistant := sdk.Message{Role: sdk.Assistant, ToolCalls: &[]sdk.ChatCompletionMessageToolCall{call}}
+
+	tests := []struct {
+		name      string
+		tokenizer Tokenizer
+		messages  []sdk.Message
+		tools     []sdk.ChatCompletionTool
+		want      int
+	}{
+		{"reply overhead", none, nil, nil, tokensPerReply},
+		{"message overhead", none, []sdk.Message{user, user}, nil, tokensPerReply + 2*tokensPerMessage},
+		{"tool overhead", none, []sdk.Message{user}, []sdk.ChatCompletionTool{tool, tool}, tokensPerReply + tokensPerMessage + 2*tokensPerTool},
+		{"tool call overhead", none, []sdk.Message{assistant}, nil, tokensPerReply + 2*tokensPerMessage},
+		// role user is 1 token, hello 2
+		{"message text", CharTokenizer, []sdk.Message{user}, nil, tokensPerReply + tokensPerMessage + 1 + 2},
+		// role assistant is 3 tokens, the call's name 1 and its arguments 2
+		{"tool call text", CharTokenizer, []sdk.Message{assistant}, nil, tokensPerReply + tokensPerMessage + 3 + tokensPerMessage + 1 + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := promptTokens(tt.tokenizer, tt.messages, tt.tools); got != tt.want {
+				t.Errorf("promptTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletionTokens(t *testing.T) {
+	call := sdk.ChatCompletionMessageToolCall{}
+	call.Function.Name = "echo"
+	call.Function.Arguments = `{"a":1}`
+
+	tests := []struct {
+		name   string
+		result completion
+		want   int
+	}{
+		{"empty", completion{}, 0},
+		{"content", completion{content: "hello world"}, 3},
+		{"tool call", completion{toolCalls: []sdk.ChatCompletionMessageToolCall{call}}, tokensPerMessage + 1 + 2},
+		{"both", completion{content: "hello world", toolCalls: []sdk.ChatCompletionMessageToolCall{call, call}}, 3 + 2*(tokensPerMessage+1+2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := completionTokens(CharTokenizer, &tt.result); got != tt.want {
+				t.Errorf("completionTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	messages := []sdk.Message{{Role: sdk.User, Content: "hello"}}
+	prompt := promptTokens(CharTokenizer, messages, nil)
+	client := NewMockLLMClient(WithTokenizer(CharTokenizer))
+
+	resp, err := client.CreateChatCompletion(context.Background(), messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sdk.CompletionUsage{
+		PromptTokens:     int64(prompt),
+		CompletionTokens: int64(CharTokenizer.Count(resp.Choices[0].Message.Content)),
+	}
+	want.TotalTokens = want.PromptTokens + want.CompletionTokens
+	if resp.Usage == nil || *resp.Usage != want {
+		t.Errorf("usage = %+v, want %+v", resp.Usage, want)
+	}
+
+	// A stream reports the same usage, on its final chunk only
+	respChan, errChan := client.CreateStreamingChatCompletion(context.Background(), messages)
+	var reported []*sdk.CompletionUsage
+	last := -1
+	chunks := 0
+	for chunk := range respChan {
+		if chunk.Usage != nil {
+			reported = append(reported, chunk.Usage)
+			last = chunks
+		}
+		chunks++
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+	if len(reported) != 1 || last != chunks-1 || *reported[0] != want {
+		t.Errorf("stream reported usage %v on chunk %d of %d, want %+v on the last", reported, last, chunks, want)
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	messages := []sdk.Message{{Role: sdk.User, Content: "hello"}}
+	prompt := promptTokens(CharTokenizer, messages, nil)
+
+	tests := []struct {
+		name    string
+		limit   int
+		wantErr bool
+	}{
+		{"unlimited", 0, false},
+		{"fits exactly", prompt, false},
+		{"one token short", prompt - 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewMockLLMClient(WithTokenizer(CharTokenizer), WithContextWindow(tt.limit))
+			_, err := client.CreateChatCompletion(context.Background(), messages)
+
+			var contextLength *ContextLengthError
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("CreateChatCompletion() error = %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &contextLength) {
+				t.Fatalf("CreateChatCompletion() error = %v, want a ContextLengthError", err)
+			}
+			if contextLength.Tokens != prompt || contextLength.Limit != tt.limit {
+				t.Errorf("error = %+v, want %d tokens over a limit of %d", contextLength, prompt, tt.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// maxBodySize limits chat completion request bodies
+const maxBodySize = 32 << 20
+
+// Server exposes an LLM client as an OpenAI-compatible provider, so other
+// services can use the mock model as a stand-in for a real one
+type Server struct {
+	cfg        *config.OpenAIConfig
+	client     server.LLMClient
+	logger     *zap.Logger
+	httpServer *http.Server
+	started    time.Time
+}
+
+// NewServer creates the OpenAI-compatible server for client. The models
+// are listed as created when clock first reads.
+func NewServer(cfg *config.OpenAIConfig, client server.LLMClient, clock rng.Clock, logger *zap.Logger) *Server {
+	s := &Server{
+		cfg:     cfg,
+		client:  client,
+		logger:  logger,
+		started: clock.Now(),
+	}
+
+	s.httpServer = &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// Handler returns the HTTP routes of the OpenAI-compatible API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /v1/models", s.handleListModels)
+	mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	return mux
+}
+
+// Start serves the API until Stop is called
+func (s *Server) Start(ctx context.Context) error {
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully shuts the API down
+func (s *Server) Stop(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+func (s *Server) handleListModels(w http.ResponseWriter, r *http.Request) {
+	models := make([]sdk.Model, 0, len(s.cfg.Models))
+	for _, id := range s.cfg.Models {
+		models = append(models, sdk.Model{
+			Id:       id,
+			Object:   "model",
+			Created:  s.started.Unix(),
+			OwnedBy:  "mock-agent",
+			ServedBy: "mock",
+		})
+	}
+
+	writeJSON(w, http.StatusOK, sdk.ListModelsResponse{Object: "list", Data: models})
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req sdk.CreateChatCompletionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", errors.New("messages must not be empty"))
+		return
+	}
+
+	var tools []sdk.ChatCompletionTool
+	if req.Tools != nil {
+		tools = *req.Tools
+	}
+
+	if req.Stream != nil && *req.Stream {
+		s.streamChatCompletion(w, r, &req, tools)
+		return
+	}
+
+	resp, err := s.client.CreateChatCompletion(r.Context(), req.Messages, tools...)
+	if err != nil {
+		s.writeClientError(w, err)
+		return
+	}
+	if req.Model != "" {
+		resp.Model = req.Model
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// streamChatCompletion relays the client's stream as server-sent events.
+// The stream ends with "data: [DONE]" unless it was cut off, e.g. by an
+// injected drop_stream or no_finish fault.
+func (s *Server) streamChatCompletion(w http.ResponseWriter, r *http.Request, req *sdk.CreateChatCompletionRequest, tools []sdk.ChatCompletionTool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "server_error", errors.New("streaming is not supported by the connection"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	respChan, errChan := s.client.CreateStreamingChatCompletion(ctx, req.Messages, tools...)
+
+	started := false
+	finished := false
+	for {
+		select {
+		case chunk, ok := <-respChan:
+			if !ok {
+				// The error channel is closed before the response channel,
+				// so a pending error is always visible here
+				if errChan != nil {
+					if err, ok := <-errChan; ok && err != nil {
+						s.failStream(w, flusher, started, err)
+						return
+					}
+				}
+				if !started {
+					startStream(w)
+				}
+				if finished {
+					fmt.Fprint(w, "data: [DONE]\n\n")
+				}
+				flusher.Flush()
+				return
+			}
+
+			if !started {
+				startStream(w)
+				started = true
+			}
+			if req.Model != "" {
+				chunk.Model = req.Model
+			}
+			for _, choice := range chunk.Choices {
+				if choice.FinishReason != "" {
+					finished = true
+				}
+			}
+
+			data, err := json.Marshal(chunk)
+			if err != nil {
+				s.logger.Error("failed to encode stream chunk", zap.Error(err))
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+
+		case err, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			if err != nil {
+				s.failStream(w, flusher, started, err)
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// failStream reports an error before the stream started as a regular HTTP
+// error, and as a final error event once it has. A dropped stream loses its
+// connection instead.
+func (s *Server) failStream(w http.ResponseWriter, flusher http.Flusher, started bool, err error) {
+	if !started {
+		s.writeClientError(w, err)
+		return
+	}
+	if errors.Is(err, mock.ErrDroppedStream) {
+		// Drop the connection after the chunks sent so far
+		flusher.Flush()
+		panic(http.ErrAbortHandler)
+	}
+
+	data, _ := json.Marshal(errorBody("server_error", err))
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	flusher.Flush()
+}
+
+// writeClientError maps LLM client errors to provider-style HTTP errors
+func (s *Server) writeClientError(w http.ResponseWriter, err error) {
+	var rateLimit *mock.RateLimitError
+	var contextLength *mock.ContextLengthError
+	switch {
+	case errors.As(err, &contextLength):
+		writeError(w, http.StatusBadRequest, "context_length_exceeded", err)
+	case errors.As(err, &rateLimit):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimit.RetryAfter.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "rate_limit_exceeded", err)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, "timeout", err)
+	default:
+		s.logger.Debug("chat completion failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
+
+func startStream(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+}
+
+func errorBody(errorType string, err error) map[string]any {
+	return map[string]any{
+		"error": map[string]any{
+			"message": err.Error(),
+			"type":    errorType,
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, errorType string, err error) {
+	writeJSON(w, status, errorBody(errorType, err))
+}
@@ -0,0 +1,259 @@
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+var epoch = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newTestServer(t *testing.T, options ...mock.Option) *httptest.Server {
+	t.Helper()
+	options = append([]mock.Option{
+		mock.WithSource(rng.New(1, nil)),
+		mock.WithFaults(mock.FaultConfig{Markers: true, RetryAfter: 1500 * time.Millisecond}),
+		mock.WithStreamProfile(mock.StreamProfile{Chunking: mock.ChunkWord}),
+	}, options...)
+	cfg := &config.OpenAIConfig{Models: []string{"mock-model", "gpt-test"}}
+	s := NewServer(cfg, mock.NewMockLLMClient(options...), rng.NewStepClock(epoch, time.Second), zap.NewNop())
+
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, server *httptest.Server, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(server.URL+"/v1/chat/completions", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// events reads the data of the server-sent events of a stream
+func events(t *testing.T, resp *http.Response) []string {
+	t.Helper()
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if payload, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			data = append(data, payload)
+		}
+	}
+	return data
+}
+
+func TestChatCompletion(t *testing.T) {
+	server := newTestServer(t)
+	resp := post(t, server, `{"model": "gpt-test", "messages": [{"role": "user", "content": "hello"}]}`)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	var completion sdk.CreateChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		t.Fatal(err)
+	}
+	if completion.Model != "gpt-test" {
+		t.Errorf("model = %q, want the requested gpt-test", completion.Model)
+	}
+	if len(completion.Choices) != 1 || completion.Choices[0].Message.Content == "" {
+		t.Fatalf("choices = %+v, want one with content", completion.Choices)
+	}
+	if completion.Choices[0].FinishReason != sdk.Stop {
+		t.Errorf("finish reason = %q, want stop", completion.Choices[0].FinishReason)
+	}
+	if completion.Usage == nil || completion.Usage.TotalTokens == 0 {
+		t.Errorf("usage = %+v, want token counts", completion.Usage)
+	}
+}
+
+func TestChatCompletionStream(t *testing.T) {
+	server := newTestServer(t)
+
+	// The same request unstreamed tells what the stream should add up to
+	var completion sdk.CreateChatCompletionResponse
+	if err := json.NewDecoder(post(t, server, `{"messages": [{"role": "user", "content": "hello there"}]}`).Body).Decode(&completion); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := post(t, server, `{"model": "gpt-test", "stream": true, "messages": [{"role": "user", "content": "hello there"}]}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", contentType)
+	}
+
+	data := events(t, resp)
+	if len(data) < 3 || data[len(data)-1] != "[DONE]" {
+		t.Fatalf("events = %q, want chunks ending with [DONE]", data)
+	}
+
+	var content strings.Builder
+	finish := ""
+	for _, payload := range data[:len(data)-1] {
+		var chunk sdk.CreateChatCompletionStreamResponse
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			t.Fatalf("event %q is not a chunk: %v", payload, err)
+		}
+		if chunk.Model != "gpt-test" || chunk.Object != "chat.completion.chunk" {
+			t.Errorf("chunk = %+v, want a chat.completion.chunk of gpt-test", chunk)
+		}
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+			if choice.FinishReason != "" {
+				finish = choice.FinishReason
+			}
+		}
+	}
+	if want := completion.Choices[0].Message.Content; content.String() != want {
+		t.Errorf("streamed content = %q, want %q", content.String(), want)
+	}
+	if finish != string(sdk.Stop) {
+		t.Errorf("finish reason = %q, want stop", finish)
+	}
+}
+
+func TestChatCompletionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    []mock.Option
+		body       string
+		wantStatus int
+		wantType   string
+		retryAfter string
+	}{
+		{
+			name:       "invalid body",
+			body:       `{"messages": `,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "invalid_request_error",
+		},
+		{
+			name:       "no messages",
+			body:       `{"messages": []}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "invalid_request_error",
+		},
+		{
+			name:       "rate limit",
+			body:       `{"messages": [{"role": "user", "content": "hi [[fault:rate_limit]]"}]}`,
+			wantStatus: http.StatusTooManyRequests,
+			wantType:   "rate_limit_exceeded",
+			retryAfter: "2",
+		},
+		{
+			name:       "rate limit of a stream",
+			body:       `{"stream": true, "messages": [{"role": "user", "content": "hi [[fault:rate_limit]]"}]}`,
+			wantStatus: http.StatusTooManyRequests,
+			wantType:   "rate_limit_exceeded",
+			retryAfter: "2",
+		},
+		{
+			name:       "context length",
+			options:    []mock.Option{mock.WithContextWindow(5)},
+			body:       `{"messages": [{"role": "user", "content": "a prompt far longer than the five tokens of the window"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "context_length_exceeded",
+		},
+		{
+			name:       "provider error",
+			body:       `{"messages": [{"role": "user", "content": "hi [[fault:error]]"}]}`,
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "server_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, newTestServer(t, tt.options...), tt.body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+
+			var body struct {
+				Error struct {
+					Message string `json:"message"`
+					Type    string `json:"type"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Type != tt.wantType || body.Error.Message == "" {
+				t.Errorf("error = %+v, want type %s with a message", body.Error, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestChatCompletionStreamFaults(t *testing.T) {
+	tests := []struct {
+		fault    string
+		wantDone bool
+	}{
+		{"no_finish", false},
+		{"drop_stream", false},
+		{"none", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fault, func(t *testing.T) {
+			resp := post(t, newTestServer(t), `{"stream": true, "messages": [{"role": "user", "content": "hello there [[fault:`+tt.fault+`]]"}]}`)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+			data := events(t, resp)
+			if len(data) == 0 {
+				t.Fatal("stream sent no events")
+			}
+			if done := data[len(data)-1] == "[DONE]"; done != tt.wantDone {
+				t.Errorf("stream ended with %q, want [DONE] %v", data[len(data)-1], tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestListModels(t *testing.T) {
+	server := newTestServer(t)
+	resp, err := http.Get(server.URL + "/v1/models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var models sdk.ListModelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		t.Fatal(err)
+	}
+	if len(models.Data) != 2 || models.Data[0].Id != "mock-model" || models.Data[1].Id != "gpt-test" {
+		t.Fatalf("models = %+v, want mock-model and gpt-test", models.Data)
+	}
+	for _, model := range models.Data {
+		if model.Created != epoch.Unix() {
+			t.Errorf("model %s created at %d, want %d from the clock", model.Id, model.Created, epoch.Unix())
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package outcome
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	relay "github.com/inference-gateway/mock-agent/internal/relay"
+)
+
+// Agent ends runs whose skills set an outcome. Once a skill sets one, the
+// rest of the wrapped run is dropped and the task gets the outcome's state.
+type Agent struct {
+	inner server.OpenAICompatibleAgent
+	store *Store
+}
+
+// WrapAgent returns an agent that lets the skills of inner decide how their
+// task ends, recording the outcomes in store
+func WrapAgent(inner server.OpenAICompatibleAgent, store *Store) *Agent {
+	return &Agent{inner: inner, store: store}
+}
+
+func (a *Agent) RunWithStream(ctx context.Context, messages []types.Message) (<-chan cloudevents.Event, error) {
+	task, _ := ctx.Value(server.TaskContextKey).(*types.Task)
+	if task != nil {
+		// A resumed task starts over
+		a.store.Delete(task.ID)
+	}
+
+	decided := make(chan Outcome, 1)
+	setter := func(o Outcome) {
+		if task != nil {
+			a.store.Put(task.ID, o)
+		}
+		select {
+		case decided <- o:
+		default:
+		}
+	}
+
+	runCtx, stop := context.WithCancel(WithSetter(ctx, setter))
+	events, err := a.inner.RunWithStream(runCtx, messages)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	out := make(chan cloudevents.Event)
+	go func() {
+		defer close(out)
+		defer stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				relay.Event(ctx, out, event)
+			case o := <-decided:
+				// Stop the run and let it wind down unread
+				stop()
+				go func() {
+					for range events {
+					}
+				}()
+				for _, event := range a.end(task, o) {
+					relay.Event(ctx, out, event)
+				}
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// end reports the outcome as the final status of the task. The background
+// handler saves the task once it sees a failed status; the streaming handler
+// only saves a failure, so the run also fails the stream, which the gateway
+// hides unless the outcome asks for a JSON-RPC error.
+func (a *Agent) end(task *types.Task, o Outcome) []cloudevents.Event {
+	message := statusMessage(task, o)
+	id := message.MessageID
+
+	status := cloudevents.NewEvent()
+	status.SetID(id)
+	status.SetSource("mock-agent/outcome")
+	status.SetType(types.EventTaskStatusChanged)
+	_ = status.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: o.State, Message: message})
+
+	return []cloudevents.Event{
+		status,
+		types.NewMessageEvent(types.EventStreamFailed, id, message),
+	}
+}
+
+// statusMessage is the status message of a task that ends with o
+func statusMessage(task *types.Task, o Outcome) *types.Message {
+	message := &types.Message{
+		Kind:      "message",
+		MessageID: "outcome",
+		Role:      "assistant",
+		Parts:     []types.Part{types.NewTextPart(o.Message)},
+		Metadata:  map[string]any{"outcome": o},
+	}
+	if task != nil {
+		message.MessageID = "outcome-" + task.ID
+		message.TaskID = &task.ID
+		message.ContextID = &task.ContextID
+	}
+	return message
+}
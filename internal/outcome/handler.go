@@ -0,0 +1,34 @@
+package outcome
+
+import (
+	"context"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// BackgroundHandler saves tasks with the state their outcome asks for. The
+// wrapped handler only stops at completed, failed and canceled states and
+// saves any other final status as completed.
+type BackgroundHandler struct {
+	server.TaskHandler
+	store *Store
+}
+
+// WrapBackgroundHandler returns a task handler that applies the outcomes in store
+func WrapBackgroundHandler(inner server.TaskHandler, store *Store) *BackgroundHandler {
+	return &BackgroundHandler{TaskHandler: inner, store: store}
+}
+
+func (h *BackgroundHandler) HandleTask(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
+	result, err := h.TaskHandler.HandleTask(ctx, task, message)
+	if err != nil || result == nil {
+		return result, err
+	}
+
+	if o, ok := h.store.Get(result.ID); ok && result.Status.State != o.State {
+		result.Status.State = o.State
+		result.Status.Message = statusMessage(result, o)
+	}
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package outcome
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// A2A error codes on top of the JSON-RPC ones
+const (
+	CodeTaskNotFound                 = -32001
+	CodeTaskNotCancelable            = -32002
+	CodePushNotificationNotSupported = -32003
+	CodeUnsupportedOperation         = -32004
+	CodeContentTypeNotSupported      = -32005
+	CodeInvalidAgentResponse         = -32006
+	CodeExtendedCardNotConfigured    = -32007
+)
+
+// CodeMessages are the standard messages of the JSON-RPC and A2A error codes
+var CodeMessages = map[int]string{
+	-32700:                           "Parse error",
+	-32600:                           "Invalid Request",
+	-32601:                           "Method not found",
+	-32602:                           "Invalid params",
+	-32603:                           "Internal error",
+	CodeTaskNotFound:                 "Task not found",
+	CodeTaskNotCancelable:            "Task cannot be canceled",
+	CodePushNotificationNotSupported: "Push Notification is not supported",
+	CodeUnsupportedOperation:         "This operation is not supported",
+	CodeContentTypeNotSupported:      "Incompatible content types",
+	CodeInvalidAgentResponse:         "Invalid agent response",
+	CodeExtendedCardNotConfigured:    "Authenticated Extended Card is not configured",
+}
+
+// Outcome is how a skill asks its task to end instead of completing
+type Outcome struct {
+	// State is the final state of the task: failed, rejected or auth-required
+	State   types.TaskState `json:"state"`
+	Message string          `json:"message"`
+	// Code makes the task's stream end with this JSON-RPC error, and
+	// tasks/get answer with it
+	Code int `json:"code,omitempty"`
+	// HTTPStatus makes tasks/get answer with this HTTP status
+	HTTPStatus int `json:"http_status,omitempty"`
+	// RetryAfter is sent as Retry-After with HTTPStatus
+	RetryAfter time.Duration `json:"-"`
+	// Times limits the Code or HTTPStatus to the first polls of the task (0 = all)
+	Times int `json:"-"`
+}
+
+// Wire tells whether the outcome shows at the transport, not only in the task state
+func (o Outcome) Wire() bool {
+	return o.Code != 0 || o.HTTPStatus != 0
+}
+
+// Setter records the outcome of the running task
+type Setter func(o Outcome)
+
+type setterKey struct{}
+
+// WithSetter returns a context whose skills set the outcome of their task with setter
+func WithSetter(ctx context.Context, setter Setter) context.Context {
+	return context.WithValue(ctx, setterKey{}, setter)
+}
+
+// Set ends the running task with o once the skill returns. Outside of an
+// agent wrapped with WrapAgent it does nothing and returns false.
+func Set(ctx context.Context, o Outcome) bool {
+	setter, ok := ctx.Value(setterKey{}).(Setter)
+	if ok {
+		setter(o)
+	}
+	return ok
+}
+
+// Store keeps the outcomes of tasks for the task handlers and the gateway
+type Store struct {
+	mu         sync.Mutex
+	outcomes   map[string]*entry
+	order      []string
+	maxEntries int
+}
+
+type entry struct {
+	outcome Outcome
+	// polls counts the tasks/get requests answered with the wire error
+	polls int
+}
+
+// NewStore creates an empty outcome store that keeps the outcomes of the
+// latest maxEntries tasks (0 = unlimited)
+func NewStore(maxEntries int) *Store {
+	return &Store{outcomes: map[string]*entry{}, maxEntries: maxEntries}
+}
+
+// Put records the outcome of a task, forgetting the oldest outcome when the
+// store is full
+func (s *Store) Put(taskID string, o Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(taskID)
+	s.outcomes[taskID] = &entry{outcome: o}
+	s.order = append(s.order, taskID)
+	if s.maxEntries > 0 && len(s.order) > s.maxEntries {
+		delete(s.outcomes, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Get returns the outcome of a task
+func (s *Store) Get(taskID string) (Outcome, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.outcomes[taskID]
+	if !ok {
+		return Outcome{}, false
+	}
+	return e.outcome, true
+}
+
+// Poll returns the outcome of a task for a tasks/get request and whether
+// the request is answered with its wire error
+func (s *Store) Poll(taskID string) (Outcome, bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.outcomes[taskID]
+	if !ok {
+		return Outcome{}, false, false
+	}
+	if !e.outcome.Wire() || (e.outcome.Times > 0 && e.polls >= e.outcome.Times) {
+		return e.outcome, true, false
+	}
+	e.polls++
+	return e.outcome, true, true
+}
+
+// Delete forgets the outcome of a task, e.g. when it is resumed
+func (s *Store) Delete(taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(taskID)
+}
+
+func (s *Store) remove(taskID string) {
+	if _, ok := s.outcomes[taskID]; !ok {
+		return
+	}
+	delete(s.outcomes, taskID)
+	s.order = slices.DeleteFunc(s.order, func(id string) bool { return id == taskID })
+}
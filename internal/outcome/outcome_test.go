@@ -0,0 +1,71 @@
+package outcome
+
+import (
+	"testing"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestStorePoll(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome Outcome
+		// want tells for every poll whether it gets the wire error
+		want []bool
+	}{
+		{"state only", Outcome{State: types.TaskStateRejected}, []bool{false, false}},
+		{"every poll", Outcome{State: types.TaskStateFailed, Code: CodeTaskNotFound}, []bool{true, true, true}},
+		{"first polls", Outcome{State: types.TaskStateFailed, HTTPStatus: 503, Times: 2}, []bool{true, true, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(0)
+			store.Put("task", tt.outcome)
+			for i, want := range tt.want {
+				o, found, wire := store.Poll("task")
+				if !found || o.State != tt.outcome.State {
+					t.Fatalf("poll %d = %+v, %v, want the outcome", i, o, found)
+				}
+				if wire != want {
+					t.Errorf("poll %d wire = %v, want %v", i, wire, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStorePutResetsPolls(t *testing.T) {
+	store := NewStore(0)
+	o := Outcome{State: types.TaskStateFailed, Code: CodeTaskNotFound, Times: 1}
+	store.Put("task", o)
+	store.Poll("task")
+	store.Put("task", o)
+	if _, _, wire := store.Poll("task"); !wire {
+		t.Error("poll after Put did not get the wire error")
+	}
+}
+
+func TestStoreMaxEntries(t *testing.T) {
+	store := NewStore(2)
+	failed := Outcome{State: types.TaskStateFailed}
+	store.Put("a", failed)
+	store.Put("b", failed)
+	store.Put("a", failed)
+	store.Put("c", failed)
+
+	for id, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, found := store.Get(id); found != want {
+			t.Errorf("Get(%q) found = %v, want %v", id, found, want)
+		}
+	}
+	if len(store.outcomes) != 2 || len(store.order) != 2 {
+		t.Errorf("store keeps %d outcomes in order %v, want 2", len(store.outcomes), store.order)
+	}
+
+	store.Delete("a")
+	store.Put("d", failed)
+	if _, found := store.Get("c"); !found {
+		t.Error("Put evicted c although a was deleted")
+	}
+}
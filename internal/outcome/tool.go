@@ -0,0 +1,48 @@
+package outcome
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// CodePanic is the error code of a skill that panicked
+const CodePanic = "panic"
+
+// Tool fails the task of a skill that panics instead of letting the panic
+// take the process down
+type Tool struct {
+	server.Tool
+	logger *zap.Logger
+}
+
+// WrapTool returns a tool that recovers from panics of inner
+func WrapTool(inner server.Tool, logger *zap.Logger) *Tool {
+	return &Tool{Tool: inner, logger: logger}
+}
+
+func (t *Tool) Execute(ctx context.Context, arguments map[string]any) (result string, err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		message := fmt.Sprintf("skill %s panicked: %v", t.GetName(), recovered)
+		t.logger.Error("skill panicked",
+			zap.String("tool", t.GetName()),
+			zap.Any("panic", recovered),
+			zap.ByteString("stack", debug.Stack()))
+
+		Set(ctx, Outcome{State: types.TaskStateFailed, Message: message})
+		result, err = "", toolresult.NewError(CodePanic, message, false)
+	}()
+
+	return t.Tool.Execute(ctx, arguments)
+}
@@ -0,0 +1,177 @@
+package outcome
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+)
+
+// Middleware shows the outcomes in store at the wire, which the ADK cannot.
+// tasks/get answers with the outcome's JSON-RPC error or HTTP status, or
+// with the task in the outcome's state: streamed tasks are saved as failed
+// by the ADK whatever state their outcome asks for. The ADK fails the stream
+// of a task with an outcome with a generic JSON-RPC error, which becomes the
+// outcome's error, or is dropped when the outcome only sets a task state.
+// Status updates to rejected or auth-required are marked final, which the
+// ADK only does for completed, failed and canceled.
+func Middleware(store *Store, logger *zap.Logger) gateway.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := gateway.RPC(r)
+			switch req.Method {
+			case "tasks/get":
+				handleTaskGet(w, r, next, store, logger)
+			case "message/stream", "tasks/resubscribe":
+				stream := &stream{store: store}
+				events := gateway.NewEventWriter(w, stream.rewrite)
+				next.ServeHTTP(events, r)
+				events.Finish()
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// handleTaskGet answers with the wire error of the task's outcome, or with
+// the task in the state of its outcome
+func handleTaskGet(w http.ResponseWriter, r *http.Request, next http.Handler, store *Store, logger *zap.Logger) {
+	req := gateway.RPC(r)
+	o, found, wire := store.Poll(req.Params.ID)
+	if !found {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	if wire {
+		status := http.StatusOK
+		if o.HTTPStatus != 0 {
+			status = o.HTTPStatus
+			if o.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(o.RetryAfter.Seconds()))))
+			}
+		}
+		logger.Info("answering tasks/get with the task outcome",
+			zap.String("task_id", req.Params.ID),
+			zap.Int("code", errorCode(o)),
+			zap.Int("http_status", status))
+		gateway.WriteJSON(w, status, errorResponse(req.ID, req.Params.ID, o))
+		return
+	}
+
+	recorder := gateway.NewRecorder()
+	next.ServeHTTP(recorder, r)
+
+	body := recorder.Body.Bytes()
+	var response map[string]any
+	if json.Unmarshal(body, &response) == nil {
+		if task, ok := response["result"].(map[string]any); ok {
+			if status, ok := task["status"].(map[string]any); ok && status["state"] != string(o.State) {
+				status["state"] = o.State
+				if patched, err := json.Marshal(response); err == nil {
+					body = patched
+				}
+			}
+		}
+	}
+
+	recorder.Send(w, body)
+}
+
+// stream rewrites the events of a stream that end its task with an outcome
+type stream struct {
+	store  *Store
+	taskID string
+}
+
+// rewrite returns the event to send in place of event, or nothing to drop it
+func (s *stream) rewrite(event []byte) []byte {
+	payload, ok := gateway.EventPayload(event)
+	if !ok {
+		return event
+	}
+
+	var response struct {
+		ID     any             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(payload, &response) != nil {
+		return event
+	}
+
+	if response.Result != nil {
+		var result struct {
+			Kind   string           `json:"kind"`
+			ID     string           `json:"id"`
+			TaskID string           `json:"taskId"`
+			Status types.TaskStatus `json:"status"`
+		}
+		if json.Unmarshal(response.Result, &result) != nil {
+			return event
+		}
+		if s.taskID == "" {
+			s.taskID = result.TaskID
+			if result.Kind == "task" {
+				s.taskID = result.ID
+			}
+		}
+		if result.Kind != "status-update" {
+			return event
+		}
+		if state := result.Status.State; state != types.TaskStateRejected && state != types.TaskStateAuthRequired {
+			return event
+		}
+
+		var update map[string]any
+		if json.Unmarshal(response.Result, &update) != nil {
+			return event
+		}
+		update["final"] = true
+		return gateway.Event(map[string]any{"jsonrpc": "2.0", "id": response.ID, "result": update}, event)
+	}
+
+	if response.Error == nil || s.taskID == "" {
+		return event
+	}
+	o, found := s.store.Get(s.taskID)
+	if !found {
+		return event
+	}
+	if !o.Wire() {
+		return nil
+	}
+	return gateway.Event(errorResponse(response.ID, s.taskID, o), event)
+}
+
+// errorCode is the JSON-RPC error code a wire outcome answers with
+func errorCode(o Outcome) int {
+	if o.Code != 0 {
+		return o.Code
+	}
+	return -32603
+}
+
+// errorResponse is the JSON-RPC error for the outcome of a task
+func errorResponse(id any, taskID string, o Outcome) types.JSONRPCErrorResponse {
+	details := map[string]any{"taskId": taskID, "state": o.State}
+	if o.HTTPStatus != 0 {
+		details["http_status"] = o.HTTPStatus
+	}
+	var data any = details
+	return types.JSONRPCErrorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: &types.JSONRPCError{
+			Code:    errorCode(o),
+			Message: o.Message,
+			Data:    &data,
+		},
+	}
+}
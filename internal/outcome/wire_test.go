@@ -0,0 +1,210 @@
+package outcome
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	adkconfig "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+)
+
+// upstream is a fake ADK server that is ready from the start
+type upstream struct {
+	port  string
+	ready chan struct{}
+}
+
+func (u *upstream) Port() string           { return u.port }
+func (u *upstream) Ready() <-chan struct{} { return u.ready }
+
+// newTestServer serves the middleware in front of a fake ADK server, which
+// answers with handler
+func newTestServer(t *testing.T, outcomes *Store, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	adk := httptest.NewServer(handler)
+	t.Cleanup(adk.Close)
+
+	_, port, _ := net.SplitHostPort(adk.Listener.Addr().String())
+	u := &upstream{port: port, ready: make(chan struct{})}
+	close(u.ready)
+	s := gateway.NewServer(adkconfig.ServerConfig{}, adkconfig.CapabilitiesConfig{Streaming: true}, u, zap.NewNop())
+	s.Use(Middleware(outcomes, zap.NewNop()))
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// completedTask answers every request with one completed task
+func completedTask(w http.ResponseWriter, r *http.Request) {
+	gateway.WriteResult(w, 1, types.Task{
+		ID:     "task-1",
+		Kind:   "task",
+		Status: types.TaskStatus{State: types.TaskStateCompleted},
+	})
+}
+
+// poll is the expected answer to a tasks/get request: an HTTP status and a
+// task state or JSON-RPC error code
+type poll struct {
+	status int
+	state  types.TaskState
+	code   int
+}
+
+func TestMiddlewareTaskGet(t *testing.T) {
+	tests := []struct {
+		name       string
+		outcome    *Outcome
+		polls      []poll
+		retryAfter string
+	}{
+		{
+			name:  "no outcome",
+			polls: []poll{{status: 200, state: types.TaskStateCompleted}},
+		},
+		{
+			name:    "state",
+			outcome: &Outcome{State: types.TaskStateRejected},
+			polls:   []poll{{status: 200, state: types.TaskStateRejected}, {status: 200, state: types.TaskStateRejected}},
+		},
+		{
+			name:    "JSON-RPC error for the first poll",
+			outcome: &Outcome{State: types.TaskStateFailed, Code: CodeUnsupportedOperation, Times: 1},
+			polls:   []poll{{status: 200, code: CodeUnsupportedOperation}, {status: 200, state: types.TaskStateFailed}},
+		},
+		{
+			name:       "HTTP status",
+			outcome:    &Outcome{State: types.TaskStateFailed, HTTPStatus: 429, RetryAfter: 1500 * time.Millisecond},
+			retryAfter: "2",
+			polls:      []poll{{status: 429, code: -32603}, {status: 429, code: -32603}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcomes := NewStore(0)
+			if tt.outcome != nil {
+				outcomes.Put("task-1", *tt.outcome)
+			}
+			server := newTestServer(t, outcomes, completedTask)
+
+			for i, want := range tt.polls {
+				resp, err := http.Post(server.URL+"/a2a", "application/json",
+					strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"task-1"}}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var response struct {
+					Result *types.Task         `json:"result"`
+					Error  *types.JSONRPCError `json:"error"`
+				}
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("poll %d: %v", i, err)
+				}
+
+				if resp.StatusCode != want.status {
+					t.Errorf("poll %d status = %d, want %d", i, resp.StatusCode, want.status)
+				}
+				if got := resp.Header.Get("Retry-After"); got != tt.retryAfter {
+					t.Errorf("poll %d Retry-After = %q, want %q", i, got, tt.retryAfter)
+				}
+				switch {
+				case want.code != 0:
+					if response.Error == nil || response.Error.Code != want.code {
+						t.Errorf("poll %d error = %+v, want code %d", i, response.Error, want.code)
+					}
+				case response.Result == nil:
+					t.Errorf("poll %d error = %+v, want a task", i, response.Error)
+				case response.Result.Status.State != want.state:
+					t.Errorf("poll %d state = %s, want %s", i, response.Result.Status.State, want.state)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddlewareStream(t *testing.T) {
+	events := []string{
+		`{"jsonrpc":"2.0","id":1,"result":{"kind":"task","id":"task-1","status":{"state":"working"}}}`,
+		`{"jsonrpc":"2.0","id":1,"result":{"kind":"status-update","taskId":"task-1","status":{"state":"rejected"},"final":false}}`,
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"streaming failed"}}`,
+	}
+	stream := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			_, _ = w.Write([]byte("data: " + event + "\n\n"))
+		}
+	}
+
+	tests := []struct {
+		name    string
+		outcome *Outcome
+		// final is whether the rejected status update is final
+		final bool
+		// code is the error the stream ends with, 0 for none
+		code int
+	}{
+		{"no outcome", nil, true, -32603},
+		{"state", &Outcome{State: types.TaskStateRejected}, true, 0},
+		{"JSON-RPC error", &Outcome{State: types.TaskStateFailed, Code: CodeUnsupportedOperation}, true, CodeUnsupportedOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcomes := NewStore(0)
+			if tt.outcome != nil {
+				outcomes.Put("task-1", *tt.outcome)
+			}
+			server := newTestServer(t, outcomes, stream)
+
+			resp, err := http.Post(server.URL+"/a2a", "application/json",
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"message/stream","params":{}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var final bool
+			code := 0
+			for _, event := range strings.Split(strings.TrimSpace(string(body)), "\n\n") {
+				var response struct {
+					Result *struct {
+						Kind  string `json:"kind"`
+						Final bool   `json:"final"`
+					} `json:"result"`
+					Error *types.JSONRPCError `json:"error"`
+				}
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(event, "data: ")), &response); err != nil {
+					t.Fatalf("event %q: %v", event, err)
+				}
+				if response.Result != nil && response.Result.Kind == "status-update" {
+					final = response.Result.Final
+				}
+				if response.Error != nil {
+					code = response.Error.Code
+				}
+			}
+			if final != tt.final {
+				t.Errorf("rejected status update final = %v, want %v", final, tt.final)
+			}
+			if code != tt.code {
+				t.Errorf("stream error code = %d, want %d", code, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package persona
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	adkconfig "github.com/inference-gateway/adk/server/config"
+	"gopkg.in/yaml.v3"
+)
+
+// reservedPrefixes are paths the main agent serves itself
+var reservedPrefixes = []string{"/a2a", "/health", "/.well-known"}
+
+// namePattern keeps persona names usable in URLs, log fields and rng forks
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Persona is an independently configured mock agent served by the same
+// process as the main agent, on its own port or under a path prefix of the
+// main agent's port
+type Persona struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+
+	// Port serves the persona on a port of its own
+	Port string `yaml:"port"`
+	// PathPrefix serves the persona under this prefix, e.g. /billing
+	PathPrefix string `yaml:"path_prefix"`
+
+	// Skills limits the persona to these skills (empty = all skills)
+	Skills []string `yaml:"skills"`
+	// Capabilities override the A2A_CAPABILITIES_* settings
+	Capabilities Capabilities `yaml:"capabilities"`
+	// ScenariosPath points to the scripted scenarios of the persona's mock LLM client
+	ScenariosPath string `yaml:"scenarios_path"`
+	// SystemPrompt replaces the system prompt listing the persona's skills
+	SystemPrompt string `yaml:"system_prompt"`
+	// CardPath points to an agent card file laid over the generated card
+	CardPath string `yaml:"card_path"`
+}
+
+// Capabilities are the A2A capabilities of a persona; unset ones keep the
+// configured default
+type Capabilities struct {
+	Streaming              *bool `yaml:"streaming"`
+	PushNotifications      *bool `yaml:"push_notifications"`
+	StateTransitionHistory *bool `yaml:"state_transition_history"`
+}
+
+// Load reads the personas of a YAML file
+func Load(path string) ([]Persona, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read personas file: %w", err)
+	}
+
+	var file struct {
+		Personas []Persona `yaml:"personas"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse personas file: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Personas))
+	ports := make(map[string]string, len(file.Personas))
+	for i := range file.Personas {
+		p := &file.Personas[i]
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("persona %d: %w", i+1, err)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("persona %q is declared twice", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.PathPrefix != "" {
+			for _, other := range file.Personas[:i] {
+				if other.PathPrefix != "" && (underPath(p.PathPrefix, other.PathPrefix) || underPath(other.PathPrefix, p.PathPrefix)) {
+					return nil, fmt.Errorf("personas %q and %q are both served under %s", other.Name, p.Name, min(p.PathPrefix, other.PathPrefix))
+				}
+			}
+			continue
+		}
+		if other, ok := ports[p.Port]; ok {
+			return nil, fmt.Errorf("personas %q and %q are both served on port %s", other, p.Name, p.Port)
+		}
+		ports[p.Port] = p.Name
+	}
+	return file.Personas, nil
+}
+
+func (p *Persona) validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("name %q must be letters, digits, '.', '_' or '-'", p.Name)
+	}
+	if (p.Port == "") == (p.PathPrefix == "") {
+		return fmt.Errorf("persona %q must declare either a port or a path_prefix", p.Name)
+	}
+	if p.PathPrefix != "" {
+		p.PathPrefix = "/" + strings.Trim(p.PathPrefix, "/")
+		if p.PathPrefix == "/" {
+			return fmt.Errorf("persona %q has an empty path_prefix", p.Name)
+		}
+		for _, reserved := range reservedPrefixes {
+			if underPath(p.PathPrefix, reserved) {
+				return fmt.Errorf("persona %q cannot use the path_prefix %s of the main agent", p.Name, reserved)
+			}
+		}
+	}
+	return nil
+}
+
+// underPath reports whether path is prefix or lies below it
+func underPath(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// Apply overrides the capabilities of cfg that the persona sets
+func (c Capabilities) Apply(cfg *adkconfig.CapabilitiesConfig) {
+	if c.Streaming != nil {
+		cfg.Streaming = *c.Streaming
+	}
+	if c.PushNotifications != nil {
+		cfg.PushNotifications = *c.PushNotifications
+	}
+	if c.StateTransitionHistory != nil {
+		cfg.StateTransitionHistory = *c.StateTransitionHistory
+	}
+}
@@ -0,0 +1,149 @@
+package persona
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		persona    Persona
+		wantPrefix string
+		wantErr    string
+	}{
+		{"port", Persona{Name: "billing", Port: "9001"}, "", ""},
+		{"prefix is normalized", Persona{Name: "billing", PathPrefix: "billing/"}, "/billing", ""},
+		{"prefix sharing a reserved name", Persona{Name: "a2a-proxy", PathPrefix: "/a2a-proxy"}, "/a2a-proxy", ""},
+		{"invalid name", Persona{Name: "bill ing", Port: "9001"}, "", "must be letters"},
+		{"neither port nor prefix", Persona{Name: "billing"}, "", "either a port or a path_prefix"},
+		{"both port and prefix", Persona{Name: "billing", Port: "9001", PathPrefix: "/billing"}, "", "either a port or a path_prefix"},
+		{"empty prefix", Persona{Name: "billing", PathPrefix: "/"}, "", "empty path_prefix"},
+		{"reserved prefix", Persona{Name: "billing", PathPrefix: "/a2a"}, "", "path_prefix /a2a of the main agent"},
+		{"below a reserved prefix", Persona{Name: "billing", PathPrefix: "/.well-known/billing"}, "", "path_prefix /.well-known of the main agent"},
+		{"below health", Persona{Name: "billing", PathPrefix: "/health/billing"}, "", "path_prefix /health of the main agent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.persona
+			err := p.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			if p.PathPrefix != tt.wantPrefix {
+				t.Errorf("PathPrefix = %q, want %q", p.PathPrefix, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name: "ports and prefixes",
+			yaml: `
+personas:
+  - name: billing
+    port: "9001"
+    skills: [echo]
+    capabilities:
+      streaming: false
+  - name: support
+    path_prefix: /support
+`,
+			wantNames: []string{"billing", "support"},
+		},
+		{
+			name:    "not YAML",
+			yaml:    "personas: [",
+			wantErr: "failed to parse personas file",
+		},
+		{
+			name: "invalid persona",
+			yaml: `
+personas:
+  - name: billing
+`,
+			wantErr: "persona 1:",
+		},
+		{
+			name: "duplicate name",
+			yaml: `
+personas:
+  - name: billing
+    port: "9001"
+  - name: billing
+    port: "9002"
+`,
+			wantErr: `persona "billing" is declared twice`,
+		},
+		{
+			name: "shared port",
+			yaml: `
+personas:
+  - name: billing
+    port: "9001"
+  - name: support
+    port: "9001"
+`,
+			wantErr: "both served on port 9001",
+		},
+		{
+			name: "nested prefixes",
+			yaml: `
+personas:
+  - name: billing
+    path_prefix: /billing
+  - name: invoices
+    path_prefix: /billing/invoices
+`,
+			wantErr: "both served under /billing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "personas.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			personas, err := Load(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			var names []string
+			for _, p := range personas {
+				names = append(names, p.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("Load() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil || !strings.Contains(err.Error(), "failed to read personas file") {
+		t.Errorf("Load(missing) = %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	relay "github.com/inference-gateway/mock-agent/internal/relay"
+)
+
+// Agent relays the progress skills report while they run as working status
+// updates, interleaved with the events of the wrapped agent. Streaming
+// clients receive them as status-update events with the progress in the
+// message metadata. Artifact chunks travel the same way, under
+// ArtifactUpdateKey.
+type Agent struct {
+	inner server.OpenAICompatibleAgent
+}
+
+// WrapAgent returns an agent that lets the skills of inner report progress
+func WrapAgent(inner server.OpenAICompatibleAgent) *Agent {
+	return &Agent{inner: inner}
+}
+
+func (a *Agent) RunWithStream(ctx context.Context, messages []types.Message) (<-chan cloudevents.Event, error) {
+	var taskID, contextID *string
+	if task, ok := ctx.Value(server.TaskContextKey).(*types.Task); ok && task != nil {
+		taskID = &task.ID
+		contextID = &task.ContextID
+	}
+
+	// Unbuffered, so an update is relayed before the skill carries on and
+	// never overtakes the events of its own tool call
+	updates := make(chan cloudevents.Event)
+	var sequence atomic.Int64
+	send := func(message *types.Message) {
+		n := sequence.Add(1)
+		message.MessageID = fmt.Sprintf("progress-%d", n)
+		if taskID != nil {
+			message.MessageID = fmt.Sprintf("progress-%s-%d", *taskID, n)
+		}
+		message.TaskID = taskID
+		message.ContextID = contextID
+		select {
+		case updates <- statusEvent(message):
+		case <-ctx.Done():
+		}
+	}
+	reporter := func(update Update) {
+		send(&types.Message{
+			Parts:    []types.Part{types.NewTextPart(update.Message)},
+			Metadata: map[string]any{"progress": update},
+		})
+	}
+	chunkSender := func(chunk Chunk) {
+		update := types.TaskArtifactUpdateEvent{
+			Kind:      "artifact-update",
+			Artifact:  chunk.Artifact,
+			Append:    &chunk.Append,
+			LastChunk: &chunk.LastChunk,
+		}
+		if taskID != nil {
+			update.TaskID = *taskID
+			update.ContextID = *contextID
+		}
+		send(&types.Message{
+			Parts:    []types.Part{types.NewTextPart(fmt.Sprintf("Delivered a chunk of artifact %s", chunk.Artifact.ArtifactID))},
+			Metadata: map[string]any{ArtifactUpdateKey: update},
+		})
+	}
+
+	runCtx := WithChunkSender(WithReporter(ctx, reporter), chunkSender)
+	events, err := a.inner.RunWithStream(runCtx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan cloudevents.Event)
+	go func() {
+		defer close(out)
+
+		// Runs until the inner agent closes its channel, which it does
+		// once the run finishes or the context is canceled
+		for {
+			var event cloudevents.Event
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				event = e
+			case event = <-updates:
+			}
+			relay.Event(ctx, out, event)
+		}
+	}()
+
+	return out, nil
+}
+
+// statusEvent reports message as a status change that keeps the task working
+func statusEvent(message *types.Message) cloudevents.Event {
+	message.Kind = "message"
+	message.Role = "assistant"
+
+	event := cloudevents.NewEvent()
+	event.SetID(message.MessageID)
+	event.SetSource("mock-agent/progress")
+	event.SetType(types.EventTaskStatusChanged)
+	_ = event.SetData(cloudevents.ApplicationJSON, types.TaskStatus{
+		State:   types.TaskStateWorking,
+		Message: message,
+	})
+	return event
+}
@@ -0,0 +1,67 @@
+package progress
+
+import (
+	"context"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Update is an intermediate status of a long-running skill
+type Update struct {
+	// Step is the current step, counting from 1; 0 before the first step
+	Step       int `json:"step"`
+	TotalSteps int `json:"total_steps"`
+	// Percent is the share of the job that is done, from 0 to 100
+	Percent int    `json:"percent"`
+	Message string `json:"message"`
+	// Data is a part of the result delivered ahead of it, e.g. a batch of records
+	Data any `json:"data,omitempty"`
+}
+
+// Reporter delivers progress updates to the client of the running task
+type Reporter func(update Update)
+
+type reporterKey struct{}
+
+// WithReporter returns a context whose skills report progress to reporter
+func WithReporter(ctx context.Context, reporter Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, reporter)
+}
+
+// Report sends an update to the client of the running task. Outside of an
+// agent wrapped with WrapAgent it does nothing.
+func Report(ctx context.Context, update Update) {
+	if reporter, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		reporter(update)
+	}
+}
+
+// ArtifactUpdateKey is the message metadata key of artifact chunks relayed
+// as status updates, which Middleware turns into artifact-update events
+const ArtifactUpdateKey = "artifact_update"
+
+// Chunk is a piece of an artifact delivered while the skill still runs
+type Chunk struct {
+	Artifact types.Artifact
+	// Append adds the parts to those of earlier chunks of the artifact
+	Append    bool
+	LastChunk bool
+}
+
+// ChunkSender delivers artifact chunks to the client of the running task
+type ChunkSender func(chunk Chunk)
+
+type chunkSenderKey struct{}
+
+// WithChunkSender returns a context whose skills deliver artifact chunks to sender
+func WithChunkSender(ctx context.Context, sender ChunkSender) context.Context {
+	return context.WithValue(ctx, chunkSenderKey{}, sender)
+}
+
+// SendChunk delivers a chunk to the client of the running task. Outside of
+// an agent wrapped with WrapAgent it does nothing.
+func SendChunk(ctx context.Context, chunk Chunk) {
+	if sender, ok := ctx.Value(chunkSenderKey{}).(ChunkSender); ok {
+		sender(chunk)
+	}
+}
@@ -0,0 +1,90 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// reportingAgent reports an update and sends a chunk as its skill would,
+// then emits one event of its own
+type reportingAgent struct{}
+
+func (reportingAgent) RunWithStream(ctx context.Context, _ []types.Message) (<-chan cloudevents.Event, error) {
+	events := make(chan cloudevents.Event)
+	go func() {
+		defer close(events)
+		Report(ctx, Update{Step: 1, TotalSteps: 2, Percent: 50, Message: "step 1/2"})
+		SendChunk(ctx, Chunk{Artifact: types.Artifact{ArtifactID: "a1"}, LastChunk: true})
+
+		done := cloudevents.NewEvent()
+		done.SetType(types.EventIterationCompleted)
+		events <- done
+	}()
+	return events, nil
+}
+
+func TestReportWithoutReporter(t *testing.T) {
+	// Skills run outside the agent in tests and must not block or panic
+	Report(context.Background(), Update{Message: "ignored"})
+	SendChunk(context.Background(), Chunk{})
+}
+
+func TestWrapAgent(t *testing.T) {
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+	ctx := context.WithValue(context.Background(), server.TaskContextKey, task)
+
+	events, err := WrapAgent(reportingAgent{}).RunWithStream(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []cloudevents.Event
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("run did not end, got %d events", len(got))
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want the update, the chunk and the inner event", len(got))
+	}
+
+	var update types.TaskStatus
+	if err := got[0].DataAs(&update); err != nil || got[0].Type() != types.EventTaskStatusChanged {
+		t.Fatalf("first event = %s, %v", got[0].Type(), err)
+	}
+	message := update.Message
+	if update.State != types.TaskStateWorking || message.MessageID != "progress-task-1-1" || *message.TaskID != "task-1" || *message.ContextID != "ctx-1" || message.Role != "assistant" {
+		t.Errorf("update = %+v, message = %+v", update, message)
+	}
+	if p, ok := message.Metadata["progress"].(map[string]any); !ok || p["percent"] != float64(50) || p["message"] != "step 1/2" {
+		t.Errorf("progress metadata = %v", message.Metadata)
+	}
+
+	var chunk types.TaskStatus
+	if err := got[1].DataAs(&chunk); err != nil {
+		t.Fatal(err)
+	}
+	if chunk.Message.MessageID != "progress-task-1-2" {
+		t.Errorf("chunk message ID = %s", chunk.Message.MessageID)
+	}
+	if artifact, ok := chunk.Message.Metadata[ArtifactUpdateKey].(map[string]any); !ok || artifact["taskId"] != "task-1" || artifact["lastChunk"] != true {
+		t.Errorf("chunk metadata = %v", chunk.Message.Metadata)
+	}
+
+	if got[2].Type() != types.EventIterationCompleted {
+		t.Errorf("last event = %s, want the inner agent's", got[2].Type())
+	}
+}
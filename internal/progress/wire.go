@@ -0,0 +1,56 @@
+package progress
+
+import (
+	"encoding/json"
+	"net/http"
+
+	types "github.com/inference-gateway/adk/types"
+
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+)
+
+// Middleware turns the status updates of a stream that carry an artifact
+// chunk into the artifact-update events they carry
+func Middleware() gateway.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch gateway.RPC(r).Method {
+			case "message/stream", "tasks/resubscribe":
+				events := gateway.NewEventWriter(w, unwrapChunk)
+				next.ServeHTTP(events, r)
+				events.Finish()
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// unwrapChunk returns the artifact-update event a status update carries, or
+// the event itself
+func unwrapChunk(event []byte) []byte {
+	payload, ok := gateway.EventPayload(event)
+	if !ok {
+		return event
+	}
+
+	var response struct {
+		ID     any `json:"id"`
+		Result *struct {
+			Kind   string           `json:"kind"`
+			Status types.TaskStatus `json:"status"`
+		} `json:"result"`
+	}
+	if json.Unmarshal(payload, &response) != nil || response.Result == nil || response.Result.Kind != "status-update" {
+		return event
+	}
+	message := response.Result.Status.Message
+	if message == nil {
+		return event
+	}
+	update, ok := message.Metadata[ArtifactUpdateKey]
+	if !ok {
+		return event
+	}
+	return gateway.Event(map[string]any{"jsonrpc": "2.0", "id": response.ID, "result": update}, event)
+}
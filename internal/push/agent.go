@@ -0,0 +1,140 @@
+package push
+
+import (
+	"context"
+	"slices"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	relay "github.com/inference-gateway/mock-agent/internal/relay"
+)
+
+// Agent hands the updates of the tasks it runs to the notifier as the run
+// reports them: the start of the run, status changes, input requests and
+// failures. How the task ends is reported by the task handler or the wire.
+type Agent struct {
+	inner    server.OpenAICompatibleAgent
+	notifier *Notifier
+}
+
+// WrapAgent returns an agent that reports the updates of the runs of inner
+// to notifier
+func WrapAgent(inner server.OpenAICompatibleAgent, notifier *Notifier) *Agent {
+	return &Agent{inner: inner, notifier: notifier}
+}
+
+func (a *Agent) RunWithStream(ctx context.Context, messages []types.Message) (<-chan cloudevents.Event, error) {
+	task, _ := ctx.Value(server.TaskContextKey).(*types.Task)
+	if task == nil {
+		return a.inner.RunWithStream(ctx, messages)
+	}
+	// The run's task changes while it runs, so updates are built on a copy
+	update := snapshot(task)
+	for _, message := range slices.Backward(messages) {
+		if message.Role == "user" {
+			a.notifier.Claim(message.MessageID, task.ID)
+			break
+		}
+	}
+
+	events, err := a.inner.RunWithStream(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan cloudevents.Event)
+	go func() {
+		defer close(out)
+
+		update.Status.State = types.TaskStateWorking
+		a.notifier.Update(snapshot(update))
+		for event := range events {
+			if a.apply(update, event) && ctx.Err() == nil {
+				a.notifier.Update(snapshot(update))
+			}
+			relay.Event(ctx, out, event)
+		}
+	}()
+
+	return out, nil
+}
+
+// apply changes the task the way the task handlers do for event, and tells
+// whether its status changed. A failure after a final status, which is how
+// outcomes end a run, leaves the status alone.
+func (a *Agent) apply(task *types.Task, event cloudevents.Event) bool {
+	switch event.Type() {
+	case types.EventTaskStatusChanged:
+		var status types.TaskStatus
+		if event.DataAs(&status) != nil {
+			return false
+		}
+		task.Status = status
+		return true
+	case types.EventInputRequired:
+		var message types.Message
+		if event.DataAs(&message) != nil {
+			return false
+		}
+		task.History = append(task.History, message)
+		task.Status = types.TaskStatus{State: types.TaskStateInputRequired, Message: &message}
+		return true
+	case types.EventStreamFailed:
+		var message types.Message
+		if event.DataAs(&message) != nil || final(task.Status.State) {
+			return false
+		}
+		task.Status = types.TaskStatus{State: types.TaskStateFailed, Message: &message}
+		return true
+	case types.EventIterationCompleted:
+		var message types.Message
+		if event.DataAs(&message) == nil {
+			task.History = append(task.History, message)
+		}
+	}
+	return false
+}
+
+// snapshot copies the parts of a task a notification carries
+func snapshot(task *types.Task) *types.Task {
+	return &types.Task{
+		ID:        task.ID,
+		ContextID: task.ContextID,
+		Kind:      task.Kind,
+		Status:    task.Status,
+		History:   slices.Clone(task.History),
+		Artifacts: slices.Clone(task.Artifacts),
+		Metadata:  task.Metadata,
+	}
+}
+
+// BackgroundHandler hands the tasks it finishes to the notifier. Canceled
+// runs are left to the tasks/cancel request that ended them.
+type BackgroundHandler struct {
+	server.TaskHandler
+	notifier *Notifier
+}
+
+// WrapBackgroundHandler returns a task handler that reports the tasks inner
+// finishes to notifier
+func WrapBackgroundHandler(inner server.TaskHandler, notifier *Notifier) *BackgroundHandler {
+	return &BackgroundHandler{TaskHandler: inner, notifier: notifier}
+}
+
+func (h *BackgroundHandler) HandleTask(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
+	result, err := h.TaskHandler.HandleTask(ctx, task, message)
+	if err == nil && result != nil && ctx.Err() == nil {
+		h.notifier.Update(snapshot(result))
+	}
+	return result, err
+}
+
+func final(state types.TaskState) bool {
+	switch state {
+	case types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCanceled, types.TaskStateRejected, types.TaskStateUnknown:
+		return true
+	}
+	return false
+}
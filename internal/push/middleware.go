@@ -0,0 +1,287 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	gateway "github.com/inference-gateway/mock-agent/internal/gateway"
+	outcome "github.com/inference-gateway/mock-agent/internal/outcome"
+)
+
+// TaskGetter gets a task in the state of its outcome
+type TaskGetter func(ctx context.Context, taskID string) (*types.Task, error)
+
+type middleware struct {
+	notifier *Notifier
+	task     TaskGetter
+	logger   *zap.Logger
+	// unnamed counts the messages sent without an ID
+	unnamed atomic.Int64
+}
+
+// Middleware answers the push notification config methods with the configs
+// of notifier, or turns them down when notifier is nil. It hands configs
+// sent along with messages to notifier, and the task updates the wire
+// reports: the end of a stream, a cancellation and the state of a task when
+// a config is set for it.
+func Middleware(notifier *Notifier, task TaskGetter, logger *zap.Logger) gateway.Middleware {
+	m := &middleware{notifier: notifier, task: task, logger: logger}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.serve(w, r, next)
+		})
+	}
+}
+
+// messageParams is the part of the params of message methods the
+// middleware looks at
+type messageParams struct {
+	Message struct {
+		MessageID string `json:"messageId"`
+	} `json:"message"`
+	Configuration struct {
+		PushNotificationConfig *types.PushNotificationConfig `json:"pushNotificationConfig"`
+	} `json:"configuration"`
+}
+
+func (m *middleware) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	req := gateway.RPC(r)
+	var params messageParams
+	_ = req.DecodeParams(&params)
+	c := params.Configuration.PushNotificationConfig
+	if m.notifier == nil && c != nil {
+		writeRPCError(w, req.ID, outcome.CodePushNotificationNotSupported, "")
+		return
+	}
+	if m.notifier == nil {
+		if strings.HasPrefix(req.Method, "tasks/pushNotificationConfig/") {
+			writeRPCError(w, req.ID, outcome.CodePushNotificationNotSupported, "")
+			return
+		}
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	switch req.Method {
+	case "message/send":
+		messageID := m.expect(params.Message.MessageID, c)
+		recorder := gateway.NewRecorder()
+		next.ServeHTTP(recorder, r)
+		body := recorder.Body.Bytes()
+		recorder.Send(w, body)
+
+		var response struct {
+			Result struct {
+				Kind string `json:"kind"`
+				ID   string `json:"id"`
+			} `json:"result"`
+		}
+		_ = json.Unmarshal(body, &response)
+		if response.Result.Kind != "task" {
+			response.Result.ID = ""
+		}
+		m.claim(messageID, response.Result.ID)
+
+	case "message/stream", "tasks/resubscribe":
+		messageID := m.expect(params.Message.MessageID, c)
+		var taskID string
+		events := gateway.NewEventWriter(w, func(event []byte) []byte {
+			if taskID == "" {
+				if taskID = eventTaskID(event); taskID != "" {
+					m.claim(messageID, taskID)
+				}
+			}
+			return event
+		})
+		next.ServeHTTP(events, r)
+		events.Finish()
+		m.claim(messageID, "")
+		// The ADK saves the task before it ends the stream
+		m.update(taskID)
+
+	case "tasks/cancel":
+		next.ServeHTTP(w, r)
+		m.update(req.Params.ID)
+
+	case "tasks/pushNotificationConfig/set", "tasks/pushNotificationConfig/get",
+		"tasks/pushNotificationConfig/list", "tasks/pushNotificationConfig/delete":
+		m.handlePushConfig(w, r, req)
+
+	default:
+		next.ServeHTTP(w, r)
+	}
+}
+
+// expect hands the config sent along with a message to the notifier, which
+// keeps it until the task of the message is known. It returns the key the
+// config is kept under, or "" when there is no valid config.
+func (m *middleware) expect(messageID string, c *types.PushNotificationConfig) string {
+	if c == nil {
+		return ""
+	}
+	if err := validateWebhook(c.URL); err != nil {
+		m.logger.Warn("ignoring push notification config of message", zap.String("message_id", messageID), zap.Error(err))
+		return ""
+	}
+	if messageID == "" {
+		// The ADK names the message, so only the answer tells its task
+		messageID = fmt.Sprintf("unnamed-%d", m.unnamed.Add(1))
+	}
+	m.notifier.Expect(messageID, *c)
+	return messageID
+}
+
+// claim stores the config kept under messageID for a task, unless the agent
+// did already
+func (m *middleware) claim(messageID, taskID string) {
+	if messageID != "" {
+		m.notifier.Claim(messageID, taskID)
+	}
+}
+
+// update hands the current state of a task to the notifier
+func (m *middleware) update(taskID string) {
+	if taskID == "" || !m.notifier.Has(taskID) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.notifier.cfg.Timeout)
+	defer cancel()
+	task, err := m.task(ctx, taskID)
+	if err != nil {
+		m.logger.Warn("failed to get task for push notification", zap.String("task_id", taskID), zap.Error(err))
+		return
+	}
+	m.notifier.Update(task)
+}
+
+// eventTaskID is the ID of the task an event of a stream is about
+func eventTaskID(event []byte) string {
+	payload, ok := gateway.EventPayload(event)
+	if !ok {
+		return ""
+	}
+	var response struct {
+		Result struct {
+			Kind   string `json:"kind"`
+			ID     string `json:"id"`
+			TaskID string `json:"taskId"`
+		} `json:"result"`
+	}
+	if json.Unmarshal(payload, &response) != nil {
+		return ""
+	}
+	if response.Result.Kind == "task" {
+		return response.Result.ID
+	}
+	return response.Result.TaskID
+}
+
+// handlePushConfig answers the tasks/pushNotificationConfig methods
+func (m *middleware) handlePushConfig(w http.ResponseWriter, r *http.Request, req gateway.Request) {
+	switch req.Method {
+	case "tasks/pushNotificationConfig/set":
+		var params types.TaskPushNotificationConfig
+		if err := req.DecodeParams(&params); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		if params.TaskID == "" {
+			writeRPCError(w, req.ID, -32602, "taskId is required")
+			return
+		}
+		if err := validateWebhook(params.PushNotificationConfig.URL); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		if _, err := m.task(r.Context(), params.TaskID); err != nil {
+			writeRPCError(w, req.ID, outcome.CodeTaskNotFound, err.Error())
+			return
+		}
+
+		c := m.notifier.Set(params.TaskID, params.PushNotificationConfig)
+		m.logger.Info("push notification config set",
+			zap.String("task_id", params.TaskID),
+			zap.String("config_id", *c.ID),
+			zap.String("url", c.URL))
+		m.update(params.TaskID)
+		gateway.WriteResult(w, req.ID, types.TaskPushNotificationConfig{PushNotificationConfig: c, TaskID: params.TaskID})
+
+	case "tasks/pushNotificationConfig/get":
+		var params types.GetTaskPushNotificationConfigParams
+		if err := req.DecodeParams(&params); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		id := ""
+		if params.PushNotificationConfigID != nil {
+			id = *params.PushNotificationConfigID
+		}
+		c, ok := m.notifier.Get(params.ID, id)
+		if !ok {
+			writeRPCError(w, req.ID, -32602, fmt.Sprintf("task %q has no push notification config %q", params.ID, id))
+			return
+		}
+		gateway.WriteResult(w, req.ID, types.TaskPushNotificationConfig{PushNotificationConfig: c, TaskID: params.ID})
+
+	case "tasks/pushNotificationConfig/list":
+		var params types.ListTaskPushNotificationConfigParams
+		if err := req.DecodeParams(&params); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		configs := m.notifier.List(params.ID)
+		if len(configs) == 0 {
+			if _, err := m.task(r.Context(), params.ID); err != nil {
+				writeRPCError(w, req.ID, outcome.CodeTaskNotFound, err.Error())
+				return
+			}
+		}
+		result := make([]types.TaskPushNotificationConfig, 0, len(configs))
+		for _, c := range configs {
+			result = append(result, types.TaskPushNotificationConfig{PushNotificationConfig: c, TaskID: params.ID})
+		}
+		gateway.WriteResult(w, req.ID, result)
+
+	case "tasks/pushNotificationConfig/delete":
+		var params types.DeleteTaskPushNotificationConfigParams
+		if err := req.DecodeParams(&params); err != nil {
+			writeRPCError(w, req.ID, -32602, err.Error())
+			return
+		}
+		if !m.notifier.Delete(params.ID, params.PushNotificationConfigID) {
+			writeRPCError(w, req.ID, -32602, fmt.Sprintf("task %q has no push notification config %q", params.ID, params.PushNotificationConfigID))
+			return
+		}
+		m.logger.Info("push notification config deleted",
+			zap.String("task_id", params.ID),
+			zap.String("config_id", params.PushNotificationConfigID))
+		gateway.WriteResult(w, req.ID, nil)
+	}
+}
+
+// validateWebhook checks that a push notification URL can be posted to
+func validateWebhook(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url %q is not an absolute http or https URL", rawURL)
+	}
+	return nil
+}
+
+// writeRPCError answers with the standard message of code and the detail
+// as data
+func writeRPCError(w http.ResponseWriter, id any, code int, detail string) {
+	var data any
+	if detail != "" {
+		data = map[string]any{"detail": detail}
+	}
+	gateway.WriteError(w, id, code, outcome.CodeMessages[code], data)
+}
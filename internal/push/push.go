@@ -0,0 +1,334 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Headers of a notification besides the configured ones
+const (
+	TokenHeader   = "X-A2A-Notification-Token"
+	AttemptHeader = "X-Mock-Push-Attempt"
+)
+
+// errSimulated is a delivery attempt failed by the configured failure rate
+var errSimulated = errors.New("simulated delivery failure")
+
+// The ADK task manager delivers push notifications through a
+// PushNotificationSender, but the A2A server offers no way to give it one,
+// so the configs are kept here and the updates of tasks are handed to the
+// Notifier, which is such a sender, as the agent and the task handlers
+// produce them.
+var _ server.PushNotificationSender = (*Notifier)(nil)
+
+// Notifier keeps the push notification configs of tasks and delivers task
+// updates to their webhooks
+type Notifier struct {
+	cfg    *config.PushConfig
+	client *http.Client
+	source *rng.Source
+	logger *zap.Logger
+
+	mu sync.RWMutex
+	// configs holds the configs of every task in the order they were set
+	configs map[string][]types.PushNotificationConfig
+	// expected holds the configs sent along with messages whose task is not
+	// known yet
+	expected map[string]types.PushNotificationConfig
+	// queued holds the updates of every task waiting to be delivered
+	queued map[string][]*types.Task
+	// sent holds what every config of a task was last told about it
+	sent map[string]map[string]string
+}
+
+// NewNotifier creates a notifier delivering with cfg. The source decides
+// which attempts fail when cfg has a failure rate.
+func NewNotifier(cfg *config.PushConfig, source *rng.Source, logger *zap.Logger) (*Notifier, error) {
+	if cfg.FailureRate < 0 || cfg.FailureRate > 1 {
+		return nil, fmt.Errorf("failure rate %v is not between 0 and 1", cfg.FailureRate)
+	}
+	if cfg.Retries < 0 {
+		return nil, fmt.Errorf("retries %d is negative", cfg.Retries)
+	}
+
+	return &Notifier{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		source:   source,
+		logger:   logger,
+		configs:  make(map[string][]types.PushNotificationConfig),
+		expected: make(map[string]types.PushNotificationConfig),
+		queued:   make(map[string][]*types.Task),
+		sent:     make(map[string]map[string]string),
+	}, nil
+}
+
+// Expect keeps a config sent along with a message until the task of the
+// message is known
+func (n *Notifier) Expect(messageID string, c types.PushNotificationConfig) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.expected[messageID] = c
+}
+
+// Claim stores the config sent along with a message for the task of the
+// message. An empty taskID drops the config.
+func (n *Notifier) Claim(messageID, taskID string) {
+	n.mu.Lock()
+	c, ok := n.expected[messageID]
+	delete(n.expected, messageID)
+	n.mu.Unlock()
+
+	if ok && taskID != "" {
+		stored := n.Set(taskID, c)
+		n.logger.Info("push notification config set",
+			zap.String("task_id", taskID),
+			zap.String("config_id", *stored.ID),
+			zap.String("url", stored.URL))
+	}
+}
+
+// Set stores a config for a task, replacing the one with the same ID. A
+// config without an ID gets the ID of the task.
+func (n *Notifier) Set(taskID string, c types.PushNotificationConfig) types.PushNotificationConfig {
+	if c.ID == nil || *c.ID == "" {
+		id := taskID
+		c.ID = &id
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	configs := n.configs[taskID]
+	for i := range configs {
+		if *configs[i].ID == *c.ID {
+			configs[i] = c
+			return c
+		}
+	}
+	n.configs[taskID] = append(configs, c)
+	return c
+}
+
+// Get returns a config of a task, the first one set when id is empty
+func (n *Notifier) Get(taskID, id string) (types.PushNotificationConfig, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, c := range n.configs[taskID] {
+		if id == "" || *c.ID == id {
+			return c, true
+		}
+	}
+	return types.PushNotificationConfig{}, false
+}
+
+// List returns the configs of a task
+func (n *Notifier) List(taskID string) []types.PushNotificationConfig {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return append([]types.PushNotificationConfig{}, n.configs[taskID]...)
+}
+
+// Delete removes a config of a task and tells whether it existed
+func (n *Notifier) Delete(taskID, id string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	configs := n.configs[taskID]
+	for i := range configs {
+		if *configs[i].ID == id {
+			configs = append(configs[:i], configs[i+1:]...)
+			if len(configs) == 0 {
+				delete(n.configs, taskID)
+			} else {
+				n.configs[taskID] = configs
+			}
+			delete(n.sent[taskID], id)
+			return true
+		}
+	}
+	return false
+}
+
+// Has tells whether a task has any configs
+func (n *Notifier) Has(taskID string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.configs[taskID]) > 0
+}
+
+// Update queues an update of a task for the webhooks of its configs. The
+// updates of a task are delivered one after the other, so every webhook sees
+// them in order, and a webhook is not told the same update twice.
+func (n *Notifier) Update(task *types.Task) {
+	if !n.Has(task.ID) {
+		return
+	}
+
+	n.mu.Lock()
+	queued, busy := n.queued[task.ID]
+	n.queued[task.ID] = append(queued, task)
+	n.mu.Unlock()
+	if !busy {
+		go n.flush(task.ID)
+	}
+}
+
+// flush delivers the queued updates of a task until there are none left
+func (n *Notifier) flush(taskID string) {
+	for {
+		n.mu.Lock()
+		queued := n.queued[taskID]
+		if len(queued) == 0 {
+			delete(n.queued, taskID)
+			n.mu.Unlock()
+			return
+		}
+		task := queued[0]
+		n.queued[taskID] = queued[1:]
+		n.mu.Unlock()
+
+		n.notify(context.Background(), task)
+	}
+}
+
+// notify posts task to the webhooks of the configs not told about it yet
+func (n *Notifier) notify(ctx context.Context, task *types.Task) {
+	key := updateKey(task)
+	for _, c := range n.List(task.ID) {
+		n.mu.Lock()
+		sent := n.sent[task.ID]
+		if sent == nil {
+			sent = make(map[string]string)
+			n.sent[task.ID] = sent
+		}
+		told := sent[*c.ID] == key
+		sent[*c.ID] = key
+		n.mu.Unlock()
+		if told {
+			continue
+		}
+
+		if err := n.SendTaskUpdate(ctx, c, task); err != nil {
+			n.logger.Warn("push notification not delivered",
+				zap.String("task_id", task.ID),
+				zap.String("config_id", *c.ID),
+				zap.String("url", c.URL),
+				zap.String("state", string(task.Status.State)),
+				zap.Error(err))
+			continue
+		}
+		n.logger.Debug("push notification delivered",
+			zap.String("task_id", task.ID),
+			zap.String("config_id", *c.ID),
+			zap.String("url", c.URL),
+			zap.String("state", string(task.Status.State)))
+	}
+}
+
+// updateKey identifies what a webhook has been told about a task
+func updateKey(task *types.Task) string {
+	key := string(task.Status.State) + "/" + strconv.Itoa(len(task.Artifacts))
+	if task.Status.Message != nil {
+		key += "/" + task.Status.Message.MessageID
+	}
+	return key
+}
+
+// SendTaskUpdate posts the task to the webhook of c, retrying with a
+// doubling backoff
+func (n *Notifier) SendTaskUpdate(ctx context.Context, c types.PushNotificationConfig, task *types.Task) error {
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal push notification: %w", err)
+	}
+
+	backoff := n.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err = n.attempt(ctx, c, body, attempt)
+		if err == nil {
+			return nil
+		}
+		if attempt > n.cfg.Retries {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+		n.logger.Debug("retrying push notification",
+			zap.String("url", c.URL),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (n *Notifier) attempt(ctx context.Context, c types.PushNotificationConfig, body []byte, attempt int) error {
+	if n.cfg.FailureRate > 0 && n.source.Float64() < n.cfg.FailureRate {
+		return errSimulated
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range n.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AttemptHeader, strconv.Itoa(attempt))
+	if c.Token != nil && *c.Token != "" {
+		req.Header.Set(TokenHeader, *c.Token)
+	}
+	if authorization := authorization(c.Authentication); authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered with HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// authorization is the Authorization header for the first scheme of auth,
+// with user:password credentials of the Basic scheme base64 encoded
+func authorization(auth *types.PushNotificationAuthenticationInfo) string {
+	if auth == nil || auth.Credentials == nil || *auth.Credentials == "" || len(auth.Schemes) == 0 {
+		return ""
+	}
+
+	scheme, credentials := auth.Schemes[0], *auth.Credentials
+	if strings.EqualFold(scheme, "basic") && strings.Contains(credentials, ":") {
+		credentials = base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+	return scheme + " " + credentials
+}
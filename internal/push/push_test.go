@@ -0,0 +1,238 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// delivery is a request received by a webhook
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhook records the requests posted to it and answers them with the
+// statuses in turn, then with 200
+type webhook struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []delivery
+}
+
+func (h *webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests = append(h.requests, delivery{header: r.Header, body: body})
+	status := http.StatusOK
+	if len(h.statuses) > 0 {
+		status, h.statuses = h.statuses[0], h.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (h *webhook) received() []delivery {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]delivery{}, h.requests...)
+}
+
+func newTestNotifier(t *testing.T, cfg config.PushConfig) *Notifier {
+	t.Helper()
+	cfg.RetryBackoff = time.Millisecond
+	cfg.Timeout = time.Second
+	n, err := NewNotifier(&cfg, rng.New(1, nil), zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSendTaskUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.PushConfig
+		statuses []int
+		config   types.PushNotificationConfig
+		wantErr  bool
+		// attempts is the number of requests the webhook gets
+		attempts int
+		headers  map[string]string
+	}{
+		{
+			name:     "delivered",
+			config:   types.PushNotificationConfig{Token: ptr("secret")},
+			attempts: 1,
+			headers:  map[string]string{TokenHeader: "secret", AttemptHeader: "1", "Content-Type": "application/json"},
+		},
+		{
+			name:     "retried until delivered",
+			cfg:      config.PushConfig{Retries: 3},
+			statuses: []int{500, 503},
+			attempts: 3,
+			headers:  map[string]string{AttemptHeader: "3"},
+		},
+		{
+			name:     "retries exhausted",
+			cfg:      config.PushConfig{Retries: 1},
+			statuses: []int{500, 500, 500},
+			wantErr:  true,
+			attempts: 2,
+		},
+		{
+			name: "bearer credentials",
+			config: types.PushNotificationConfig{Authentication: &types.PushNotificationAuthenticationInfo{
+				Schemes: []string{"Bearer", "Basic"}, Credentials: ptr("abc"),
+			}},
+			attempts: 1,
+			headers:  map[string]string{"Authorization": "Bearer abc"},
+		},
+		{
+			name: "basic user and password",
+			config: types.PushNotificationConfig{Authentication: &types.PushNotificationAuthenticationInfo{
+				Schemes: []string{"Basic"}, Credentials: ptr("user:pass"),
+			}},
+			attempts: 1,
+			headers:  map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+		},
+		{
+			name:     "configured headers",
+			cfg:      config.PushConfig{Headers: map[string]string{"X-Api-Key": "key"}},
+			attempts: 1,
+			headers:  map[string]string{"X-Api-Key": "key"},
+		},
+		{
+			name:     "every attempt failed by the failure rate",
+			cfg:      config.PushConfig{Retries: 2, FailureRate: 1},
+			wantErr:  true,
+			attempts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &webhook{statuses: tt.statuses}
+			server := httptest.NewServer(hook)
+			t.Cleanup(server.Close)
+
+			n := newTestNotifier(t, tt.cfg)
+			c := tt.config
+			c.URL = server.URL
+			err := n.SendTaskUpdate(context.Background(), c, &types.Task{ID: "task-1"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendTaskUpdate() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			requests := hook.received()
+			if len(requests) != tt.attempts {
+				t.Fatalf("webhook got %d requests, want %d", len(requests), tt.attempts)
+			}
+			if len(requests) == 0 {
+				return
+			}
+			last := requests[len(requests)-1]
+			for key, want := range tt.headers {
+				if got := last.header.Get(key); got != want {
+					t.Errorf("header %s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSendTaskUpdateFailureRate(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+
+	n := newTestNotifier(t, config.PushConfig{Retries: 100, FailureRate: 0.5})
+	for range 10 {
+		if err := n.SendTaskUpdate(context.Background(), types.PushNotificationConfig{URL: server.URL}, &types.Task{ID: "task-1"}); err != nil {
+			t.Fatalf("SendTaskUpdate() error = %v", err)
+		}
+	}
+
+	// Only the attempts that were not failed reach the webhook
+	requests := hook.received()
+	if len(requests) != 10 {
+		t.Fatalf("webhook got %d requests, want 10", len(requests))
+	}
+	retried := false
+	for _, r := range requests {
+		if r.header.Get(AttemptHeader) != "1" {
+			retried = true
+		}
+	}
+	if !retried {
+		t.Error("no delivery needed a retry at a failure rate of 0.5")
+	}
+}
+
+func TestNewNotifierErrors(t *testing.T) {
+	for _, cfg := range []config.PushConfig{{FailureRate: -0.1}, {FailureRate: 1.5}, {Retries: -1}} {
+		if _, err := NewNotifier(&cfg, nil, zap.NewNop()); err == nil {
+			t.Errorf("NewNotifier(%+v) accepted the configuration", cfg)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+
+	n := newTestNotifier(t, config.PushConfig{})
+	n.Update(&types.Task{ID: "task-1", Status: types.TaskStatus{State: types.TaskStateWorking}})
+	n.Set("task-1", types.PushNotificationConfig{URL: server.URL})
+	n.Expect("message-1", types.PushNotificationConfig{ID: ptr("from-message"), URL: server.URL})
+	n.Claim("message-1", "task-1")
+
+	states := []types.TaskState{
+		types.TaskStateWorking,
+		types.TaskStateWorking,
+		types.TaskStateInputRequired,
+		types.TaskStateCompleted,
+	}
+	for _, state := range states {
+		n.Update(&types.Task{ID: "task-1", Status: types.TaskStatus{State: state}})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(hook.received()) < 6 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// Both configs get every update once, in order
+	var got []string
+	for _, r := range hook.received() {
+		var task types.Task
+		if err := json.Unmarshal(r.body, &task); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(task.Status.State))
+	}
+	want := []string{"working", "working", "input-required", "input-required", "completed", "completed"}
+	if len(got) != len(want) {
+		t.Fatalf("webhook got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("webhook got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Notification is one webhook request received by the sink
+type Notification struct {
+	Seq        int64             `json:"seq"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Path       string            `json:"path"`
+	TaskID     string            `json:"task_id,omitempty"`
+	State      types.TaskState   `json:"state,omitempty"`
+	Headers    map[string]string `json:"headers"`
+	Body       json.RawMessage   `json:"body"`
+}
+
+// Filter selects notifications; empty fields match everything
+type Filter struct {
+	TaskID string
+	Path   string
+	// AfterSeq returns only notifications received after this sequence number
+	AfterSeq int64
+}
+
+func (f Filter) matches(n *Notification) bool {
+	return (f.TaskID == "" || n.TaskID == f.TaskID) &&
+		(f.Path == "" || n.Path == f.Path) &&
+		n.Seq > f.AfterSeq
+}
+
+// Sink is a webhook that records the notifications posted to it, so tests
+// can assert on what the agent delivered
+type Sink struct {
+	mu         sync.RWMutex
+	entries    []Notification
+	maxEntries int
+	seq        int64
+}
+
+// NewSink creates a sink that keeps at most maxEntries notifications (0 = unlimited)
+func NewSink(maxEntries int) *Sink {
+	return &Sink{maxEntries: maxEntries}
+}
+
+// Record stores a webhook request, reading the task from its body
+func (s *Sink) Record(path string, header http.Header, body []byte) Notification {
+	n := Notification{
+		ReceivedAt: time.Now(),
+		Path:       path,
+		Headers:    make(map[string]string, len(header)),
+	}
+	for key, values := range header {
+		n.Headers[key] = strings.Join(values, ", ")
+	}
+
+	var task types.Task
+	if json.Valid(body) {
+		n.Body = json.RawMessage(body)
+		if json.Unmarshal(body, &task) == nil {
+			n.TaskID = task.ID
+			n.State = task.Status.State
+		}
+	} else {
+		n.Body, _ = json.Marshal(string(body))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	n.Seq = s.seq
+	s.entries = append(s.entries, n)
+	if s.maxEntries > 0 && len(s.entries) > s.maxEntries {
+		s.entries = s.entries[len(s.entries)-s.maxEntries:]
+	}
+	return n
+}
+
+// Notifications returns the notifications matching filter, oldest first
+func (s *Sink) Notifications(filter Filter) []Notification {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []Notification{}
+	for i := range s.entries {
+		if filter.matches(&s.entries[i]) {
+			result = append(result, s.entries[i])
+		}
+	}
+	return result
+}
+
+// Reset drops all notifications. Sequence numbers keep increasing.
+func (s *Sink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = nil
+}
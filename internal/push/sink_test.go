@@ -0,0 +1,102 @@
+package push
+
+import (
+	"net/http"
+	"testing"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestSinkRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantTask  string
+		wantState types.TaskState
+		wantBody  string
+	}{
+		{
+			name:      "task",
+			body:      `{"id":"task-1","status":{"state":"completed"}}`,
+			wantTask:  "task-1",
+			wantState: types.TaskStateCompleted,
+			wantBody:  `{"id":"task-1","status":{"state":"completed"}}`,
+		},
+		{
+			name:     "JSON other than a task",
+			body:     `[1,2]`,
+			wantBody: `[1,2]`,
+		},
+		{
+			name:     "not JSON",
+			body:     `hello`,
+			wantBody: `"hello"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := NewSink(0)
+			header := http.Header{TokenHeader: {"secret"}}
+			n := sink.Record("/hook", header, []byte(tt.body))
+			if n.Seq != 1 || n.Path != "/hook" {
+				t.Errorf("Record() = seq %d path %q, want seq 1 path /hook", n.Seq, n.Path)
+			}
+			if n.TaskID != tt.wantTask || n.State != tt.wantState {
+				t.Errorf("Record() = task %q state %q, want task %q state %q", n.TaskID, n.State, tt.wantTask, tt.wantState)
+			}
+			if string(n.Body) != tt.wantBody {
+				t.Errorf("Record() body = %s, want %s", n.Body, tt.wantBody)
+			}
+			if n.Headers[TokenHeader] != "secret" {
+				t.Errorf("Record() headers = %v, want the token header", n.Headers)
+			}
+		})
+	}
+}
+
+func TestSinkNotifications(t *testing.T) {
+	sink := NewSink(3)
+	sink.Record("/a", nil, []byte(`{"id":"task-1"}`))
+	sink.Record("/a", nil, []byte(`{"id":"task-2"}`))
+	sink.Record("/b", nil, []byte(`{"id":"task-1"}`))
+	sink.Record("/b", nil, []byte(`{"id":"task-2"}`))
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantSeq []int64
+	}{
+		{name: "all kept", filter: Filter{}, wantSeq: []int64{2, 3, 4}},
+		{name: "task", filter: Filter{TaskID: "task-2"}, wantSeq: []int64{2, 4}},
+		{name: "path", filter: Filter{Path: "/b"}, wantSeq: []int64{3, 4}},
+		{name: "after seq", filter: Filter{AfterSeq: 3}, wantSeq: []int64{4}},
+		{name: "no match", filter: Filter{TaskID: "task-3"}, wantSeq: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sink.Notifications(tt.filter)
+			if len(got) != len(tt.wantSeq) {
+				t.Fatalf("Notifications() returned %d notifications, want %d", len(got), len(tt.wantSeq))
+			}
+			for i, n := range got {
+				if n.Seq != tt.wantSeq[i] {
+					t.Errorf("Notifications()[%d].Seq = %d, want %d", i, n.Seq, tt.wantSeq[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSinkReset(t *testing.T) {
+	sink := NewSink(0)
+	sink.Record("/a", nil, []byte(`{}`))
+	sink.Reset()
+	if got := sink.Notifications(Filter{}); len(got) != 0 {
+		t.Fatalf("Notifications() after Reset returned %d notifications", len(got))
+	}
+	if n := sink.Record("/a", nil, []byte(`{}`)); n.Seq != 2 {
+		t.Errorf("Record() after Reset = seq %d, want 2", n.Seq)
+	}
+}
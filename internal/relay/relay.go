@@ -0,0 +1,25 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// CancelGrace is how long events are offered to the reader after cancellation
+const CancelGrace = 100 * time.Millisecond
+
+// Event passes event on to the reader of out. After cancellation the reader
+// may be gone, so like the ADK agent it only waits briefly, for the events
+// that report the cancellation.
+func Event(ctx context.Context, out chan<- cloudevents.Event, event cloudevents.Event) {
+	select {
+	case out <- event:
+	case <-ctx.Done():
+		select {
+		case out <- event:
+		case <-time.After(CancelGrace):
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package rng
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	config "github.com/inference-gateway/mock-agent/config"
+)
+
+// DefaultEpoch is where the deterministic clock starts when no start time is configured
+var DefaultEpoch = time.Unix(1234567890, 0).UTC()
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock returns the wall clock
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// StepClock is a deterministic clock that starts at a fixed time and
+// advances by a fixed step every time it is read
+type StepClock struct {
+	mu   sync.Mutex
+	next time.Time
+	step time.Duration
+}
+
+// NewStepClock creates a clock whose first reading is start
+func NewStepClock(start time.Time, step time.Duration) *StepClock {
+	return &StepClock{next: start, step: step}
+}
+
+func (c *StepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.next
+	c.next = c.next.Add(c.step)
+	return now
+}
+
+// Source produces IDs, UUIDs, numbers and timestamps. A seeded source
+// yields the same sequence on every run; an unseeded one is seeded from
+// crypto/rand so IDs are unique across runs.
+type Source struct {
+	mu     sync.Mutex
+	rnd    *rand.Rand
+	seed   int64
+	seeded bool
+	clock  Clock
+}
+
+// New creates a source. A zero seed means unseeded; a nil clock means the wall clock.
+func New(seed int64, clock Clock) *Source {
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	s := &Source{seed: seed, seeded: seed != 0, clock: clock}
+	s.rnd = rand.New(rand.NewPCG(s.streamSeeds("")))
+	return s
+}
+
+// NewFromConfig creates the global source from the mock configuration.
+// Seeded sources default to a step clock so timestamps are reproducible too.
+func NewFromConfig(cfg *config.MockConfig) *Source {
+	var clock Clock
+	switch {
+	case !cfg.ClockStart.IsZero():
+		clock = NewStepClock(cfg.ClockStart, cfg.ClockStep)
+	case cfg.Seed != 0:
+		clock = NewStepClock(DefaultEpoch, cfg.ClockStep)
+	default:
+		clock = SystemClock()
+	}
+
+	return New(cfg.Seed, clock)
+}
+
+// FixedClockConfigured reports whether sources created from cfg read a step
+// clock rather than the wall clock
+func FixedClockConfigured(cfg *config.MockConfig) bool {
+	return !cfg.ClockStart.IsZero() || cfg.Seed != 0
+}
+
+// Fork derives an independent source for a named consumer. Forks of a seeded
+// source are seeded from the parent seed and the name, so the output of one
+// consumer does not depend on how often the others draw from theirs.
+func (s *Source) Fork(name string) *Source {
+	child := &Source{seed: s.seed, seeded: s.seeded, clock: s.clock}
+	child.rnd = rand.New(rand.NewPCG(child.streamSeeds(name)))
+	return child
+}
+
+func (s *Source) streamSeeds(name string) (uint64, uint64) {
+	if !s.seeded {
+		var b [16]byte
+		_, _ = crand.Read(b[:])
+		return binary.LittleEndian.Uint64(b[:8]), binary.LittleEndian.Uint64(b[8:])
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return uint64(s.seed), h.Sum64()
+}
+
+// Seeded reports whether the source is reproducible
+func (s *Source) Seeded() bool {
+	return s.seeded
+}
+
+// Now reads the source clock
+func (s *Source) Now() time.Time {
+	return s.clock.Now()
+}
+
+// FixedClock reports whether the source clock is a step clock rather than
+// the wall clock
+func (s *Source) FixedClock() bool {
+	_, fixed := s.clock.(*StepClock)
+	return fixed
+}
+
+// SeedUUIDs makes uuid.New, which the A2A server uses for task, context and
+// message IDs, draw from the source. It applies to the whole process.
+func (s *Source) SeedUUIDs() {
+	uuid.DisableRandPool()
+	uuid.SetRand(s)
+}
+
+// ResetUUIDs undoes SeedUUIDs, returning uuid.New to crypto/rand
+func ResetUUIDs() {
+	uuid.SetRand(nil)
+}
+
+// Intn returns a number in [0, n)
+func (s *Source) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.IntN(n)
+}
+
+// Float64 returns a number in [0.0, 1.0)
+func (s *Source) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64()
+}
+
+// Read fills p with random bytes, so the source can back uuid generation
+func (s *Source) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < len(p); i += 8 {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], s.rnd.Uint64())
+		copy(p[i:], b[:])
+	}
+	return len(p), nil
+}
+
+// ID returns a 16 character hex identifier
+func (s *Source) ID() string {
+	var b [8]byte
+	_, _ = s.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// UUID returns a version 4 UUID drawn from the source
+func (s *Source) UUID() string {
+	id, err := uuid.NewRandomFromReader(s)
+	if err != nil {
+		return uuid.New().String()
+	}
+	return id.String()
+}
@@ -0,0 +1,80 @@
+package rng
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFork(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Source
+		same bool
+	}{
+		{"same seed and name", New(42, nil).Fork("echo"), New(42, nil).Fork("echo"), true},
+		{"other name", New(42, nil).Fork("echo"), New(42, nil).Fork("random_data"), false},
+		{"other seed", New(42, nil).Fork("echo"), New(43, nil).Fork("echo"), false},
+		{"unseeded", New(0, nil).Fork("echo"), New(0, nil).Fork("echo"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a.ID()+tt.a.UUID(), tt.b.ID()+tt.b.UUID()
+			if (a == b) != tt.same {
+				t.Errorf("got %s and %s, want same = %v", a, b, tt.same)
+			}
+		})
+	}
+}
+
+func TestForkIndependence(t *testing.T) {
+	quiet := New(42, nil)
+	busy := New(42, nil)
+	for range 10 {
+		busy.Fork("llm").ID()
+		busy.Intn(100)
+	}
+
+	if got, want := busy.Fork("echo").ID(), quiet.Fork("echo").ID(); got != want {
+		t.Errorf("fork drew %s after others drew, want %s", got, want)
+	}
+}
+
+func TestStepClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		step time.Duration
+		want []time.Time
+	}{
+		{"one second", time.Second, []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second)}},
+		{"zero step", 0, []time.Time{start, start, start}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewStepClock(start, tt.step)
+			for i, want := range tt.want {
+				if got := clock.Now(); !got.Equal(want) {
+					t.Errorf("reading %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSourceClock(t *testing.T) {
+	source := New(42, NewStepClock(DefaultEpoch, time.Second))
+	fork := source.Fork("echo")
+	if !source.FixedClock() || !fork.FixedClock() {
+		t.Fatal("FixedClock() = false for a step clock")
+	}
+	if New(42, nil).FixedClock() {
+		t.Error("FixedClock() = true for the wall clock")
+	}
+
+	source.Now()
+	if got, want := fork.Now(), DefaultEpoch.Add(time.Second); !got.Equal(want) {
+		t.Errorf("fork reading = %v, want %v shared with its parent", got, want)
+	}
+}
@@ -0,0 +1,308 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Handler is the signature of a skill handler
+type Handler func(ctx context.Context, args map[string]any) (string, error)
+
+// Violation is one way the arguments fail the schema
+type Violation struct {
+	// Field is the path of the offending value, e.g. "count" or "items[2].name"
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when tool arguments do not match the parameters schema
+type ValidationError struct {
+	Tool       string      `json:"tool,omitempty"`
+	Violations []Violation `json:"violations"`
+}
+
+// Error renders the violations as JSON so callers and models can parse them
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		if v.Field == "" {
+			messages = append(messages, v.Message)
+		} else {
+			messages = append(messages, v.Field+" "+v.Message)
+		}
+	}
+
+	data, _ := json.Marshal(struct {
+		Status  string `json:"status"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+		*ValidationError
+	}{"error", "invalid_arguments", strings.Join(messages, "; "), e})
+	return string(data)
+}
+
+// Validated wraps handler so arguments are checked against parameters before it runs
+func Validated(tool string, parameters map[string]any, handler Handler) Handler {
+	return func(ctx context.Context, args map[string]any) (string, error) {
+		if err := Validate(parameters, args); err != nil {
+			err.Tool = tool
+			return "", err
+		}
+		return handler(ctx, args)
+	}
+}
+
+// Validate checks args against a JSON Schema object. It supports the keywords
+// skills use: type, properties, required, additionalProperties, enum, const,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf,
+// minLength, maxLength, pattern, items, minItems and maxItems.
+func Validate(parameters map[string]any, args map[string]any) *ValidationError {
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	// Round trip through JSON so schemas written as Go literals and
+	// arguments decoded from JSON share the same representation
+	schema, err := normalize(parameters)
+	if err != nil {
+		return &ValidationError{Violations: []Violation{{Message: fmt.Sprintf("invalid schema: %v", err)}}}
+	}
+	value, err := normalize(args)
+	if err != nil {
+		return &ValidationError{Violations: []Violation{{Message: fmt.Sprintf("invalid arguments: %v", err)}}}
+	}
+
+	var violations []Violation
+	check(schema, value, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
+
+// ValidateValue checks any JSON value, not only an object of arguments,
+// against a JSON Schema
+func ValidateValue(parameters map[string]any, value any) *ValidationError {
+	schema, err := normalize(parameters)
+	if err != nil {
+		return &ValidationError{Violations: []Violation{{Message: fmt.Sprintf("invalid schema: %v", err)}}}
+	}
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, &value)
+	}
+	if err != nil {
+		return &ValidationError{Violations: []Violation{{Message: fmt.Sprintf("invalid value: %v", err)}}}
+	}
+
+	var violations []Violation
+	check(schema, value, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
+
+func normalize(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]any{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func check(schema map[string]any, value any, path string, violations *[]Violation) {
+	fail := func(format string, args ...any) {
+		*violations = append(*violations, Violation{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !matchesAny(types, value) {
+		fail("must be of type %s", strings.Join(types, " or "))
+		return
+	}
+
+	if c, ok := schema["const"]; ok && !equal(c, value) {
+		fail("must be %s", encode(c))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, option := range enum {
+			if equal(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			options := make([]string, 0, len(enum))
+			for _, option := range enum {
+				options = append(options, encode(option))
+			}
+			fail("must be one of (%s)", strings.Join(options, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		checkObject(schema, v, path, violations)
+
+	case []any:
+		if n, ok := number(schema, "minItems"); ok && float64(len(v)) < n {
+			fail("must contain at least %v items", n)
+		}
+		if n, ok := number(schema, "maxItems"); ok && float64(len(v)) > n {
+			fail("must contain at most %v items", n)
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				check(items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+
+	case string:
+		length := float64(len([]rune(v)))
+		if n, ok := number(schema, "minLength"); ok && length < n {
+			fail("must be at least %v characters long", n)
+		}
+		if n, ok := number(schema, "maxLength"); ok && length > n {
+			fail("must be at most %v characters long", n)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err == nil && !re.MatchString(v) {
+				fail("must match pattern %s", pattern)
+			}
+		}
+
+	case float64:
+		if n, ok := number(schema, "minimum"); ok && v < n {
+			fail("must be at least %v", n)
+		}
+		if n, ok := number(schema, "maximum"); ok && v > n {
+			fail("must be at most %v", n)
+		}
+		if n, ok := number(schema, "exclusiveMinimum"); ok && v <= n {
+			fail("must be greater than %v", n)
+		}
+		if n, ok := number(schema, "exclusiveMaximum"); ok && v >= n {
+			fail("must be less than %v", n)
+		}
+		if n, ok := number(schema, "multipleOf"); ok && n > 0 {
+			if q := v / n; math.Abs(q-math.Round(q)) > 1e-9 {
+				fail("must be a multiple of %v", n)
+			}
+		}
+	}
+}
+
+func checkObject(schema map[string]any, value map[string]any, path string, violations *[]Violation) {
+	field := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := value[name]; !exists {
+					*violations = append(*violations, Violation{Field: field(name), Message: "is required"})
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	// Sorted so violations come out in a stable order
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := properties[name].(map[string]any); ok {
+			check(property, value[name], field(name), violations)
+			continue
+		}
+		if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+			*violations = append(*violations, Violation{Field: field(name), Message: "is not a known parameter"})
+		}
+	}
+}
+
+func schemaTypes(schema map[string]any) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesAny(types []string, value any) bool {
+	for _, t := range types {
+		if matches(t, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(t string, value any) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		v, ok := value.(float64)
+		return ok && v == math.Trunc(v)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+func number(schema map[string]any, key string) (float64, bool) {
+	v, ok := schema[key].(float64)
+	return v, ok
+}
+
+func equal(a, b any) bool {
+	return encode(a) == encode(b)
+}
+
+func encode(v any) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
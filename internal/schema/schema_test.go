@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{"type": "string", "minLength": 1, "maxLength": 5},
+			"count":   map[string]any{"type": "integer", "minimum": 1, "maximum": 10},
+			"ratio":   map[string]any{"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.25},
+			"mode":    map[string]any{"type": "string", "enum": []string{"fast", "slow"}},
+			"code":    map[string]any{"type": "string", "pattern": "^[A-Z]{3}$"},
+			"tags":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": 2},
+			"owner": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"name": map[string]any{"type": "string"}},
+				"required":   []string{"name"},
+			},
+			"note": map[string]any{"type": []string{"string", "null"}},
+		},
+		"required":             []string{"message"},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want []Violation
+	}{
+		{"valid", map[string]any{"message": "hi", "count": 3, "ratio": 0.5, "mode": "fast", "code": "ABC", "tags": []string{"a"}, "owner": map[string]any{"name": "x"}, "note": nil}, nil},
+		{"nil arguments", nil, []Violation{{"message", "is required"}}},
+		{"wrong type", map[string]any{"message": 5}, []Violation{{"message", "must be of type string"}}},
+		{"integer with fraction", map[string]any{"message": "hi", "count": 1.5}, []Violation{{"count", "must be of type integer"}}},
+		{"integer from JSON number", map[string]any{"message": "hi", "count": 2.0}, nil},
+		{"out of range", map[string]any{"message": "hi", "count": 11}, []Violation{{"count", "must be at most 10"}}},
+		{"exclusive minimum", map[string]any{"message": "hi", "ratio": 0}, []Violation{{"ratio", "must be greater than 0"}}},
+		{"multiple of", map[string]any{"message": "hi", "ratio": 0.3}, []Violation{{"ratio", "must be a multiple of 0.25"}}},
+		{"string length", map[string]any{"message": "too long"}, []Violation{{"message", "must be at most 5 characters long"}}},
+		{"enum", map[string]any{"message": "hi", "mode": "medium"}, []Violation{{"mode", `must be one of ("fast", "slow")`}}},
+		{"pattern", map[string]any{"message": "hi", "code": "abc"}, []Violation{{"code", "must match pattern ^[A-Z]{3}$"}}},
+		{"array items", map[string]any{"message": "hi", "tags": []any{"a", 2, "c"}}, []Violation{{"tags", "must contain at most 2 items"}, {"tags[1]", "must be of type string"}}},
+		{"nested required", map[string]any{"message": "hi", "owner": map[string]any{}}, []Violation{{"owner.name", "is required"}}},
+		{"unknown parameter", map[string]any{"message": "hi", "colour": "red"}, []Violation{{"colour", "is not a known parameter"}}},
+		{"several violations", map[string]any{"count": 0, "mode": "x"}, []Violation{{"message", "is required"}, {"count", "must be at least 1"}, {"mode", `must be one of ("fast", "slow")`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(parameters, tt.args)
+			var got []Violation
+			if err != nil {
+				got = err.Violations
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]any
+		value  any
+		valid  bool
+	}{
+		{"array of objects", map[string]any{"type": "array", "items": map[string]any{"type": "object", "required": []string{"id"}}}, []map[string]any{{"id": 1}}, true},
+		{"array item missing field", map[string]any{"type": "array", "items": map[string]any{"type": "object", "required": []string{"id"}}}, []map[string]any{{"id": 1}, {}}, false},
+		{"const", map[string]any{"const": "v1"}, "v1", true},
+		{"const mismatch", map[string]any{"const": "v1"}, "v2", false},
+		{"no type accepts anything", map[string]any{}, 42, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateValue(tt.schema, tt.value); (err == nil) != tt.valid {
+				t.Errorf("ValidateValue() = %v, want valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidated(t *testing.T) {
+	parameters := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"message": map[string]any{"type": "string"}},
+		"required":   []string{"message"},
+	}
+	called := false
+	handler := Validated("echo", parameters, func(ctx context.Context, args map[string]any) (string, error) {
+		called = true
+		return "ok", nil
+	})
+
+	if _, err := handler(context.Background(), map[string]any{}); err == nil {
+		t.Fatal("handler() accepted missing message")
+	} else {
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Tool != "echo" {
+			t.Errorf("handler() error = %v, want a ValidationError of echo", err)
+		}
+
+		var envelope struct {
+			Status     string      `json:"status"`
+			Error      string      `json:"error"`
+			Message    string      `json:"message"`
+			Violations []Violation `json:"violations"`
+		}
+		if err := json.Unmarshal([]byte(err.Error()), &envelope); err != nil {
+			t.Fatalf("Error() = %s, not JSON: %v", err.Error(), err)
+		}
+		if envelope.Status != "error" || envelope.Error != "invalid_arguments" || envelope.Message != "message is required" || len(envelope.Violations) != 1 {
+			t.Errorf("Error() = %s", err.Error())
+		}
+	}
+	if called {
+		t.Error("handler ran with invalid arguments")
+	}
+
+	if result, err := handler(context.Background(), map[string]any{"message": "hi"}); err != nil || result != "ok" || !called {
+		t.Errorf("handler() = %q, %v, want ok", result, err)
+	}
+}
@@ -0,0 +1,107 @@
+package toolresult
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Statuses of the result envelope skills return
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// CodeExecutionFailed classifies tool failures that carry no error code
+const CodeExecutionFailed = "execution_failed"
+
+// failedPrefix is how the ADK agent reports a tool that returned a Go error
+const failedPrefix = "Tool execution failed:"
+
+// Error is a structured skill failure. Returned from a handler, it reaches
+// the model as a JSON envelope the mock can classify.
+type Error struct {
+	Code      string `json:"error"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+	// Details carries extra context about the failure for the client
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// NewError creates a structured skill failure
+func NewError(code, message string, retryable bool) *Error {
+	return &Error{Code: code, Message: message, Retryable: retryable}
+}
+
+// Error renders the failure envelope
+func (e *Error) Error() string {
+	data, _ := json.Marshal(struct {
+		Status string `json:"status"`
+		*Error
+	}{StatusError, e})
+	return string(data)
+}
+
+// Result is the classification of a tool message
+type Result struct {
+	Status    string
+	Code      string
+	Message   string
+	Retryable bool
+}
+
+// Failed reports whether the tool call failed
+func (r Result) Failed() bool {
+	return r.Status == StatusError
+}
+
+// Parse classifies a tool message. Results with a status field are judged
+// by it; results the agent reports as failed Go errors are failures with
+// code execution_failed unless they carry an envelope; everything else is a
+// success, whatever words it contains.
+func Parse(content string) Result {
+	body := strings.TrimSpace(content)
+	rest, failed := strings.CutPrefix(body, failedPrefix)
+	if failed {
+		body = strings.TrimSpace(rest)
+	}
+
+	var envelope struct {
+		Status    string `json:"status"`
+		Error     any    `json:"error"`
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		Retryable bool   `json:"retryable"`
+	}
+	if err := json.Unmarshal([]byte(body), &envelope); err == nil && envelope.Status != "" {
+		switch strings.ToLower(envelope.Status) {
+		case StatusError, "failed", "failure":
+			result := Result{
+				Status:    StatusError,
+				Code:      envelope.Code,
+				Message:   envelope.Message,
+				Retryable: envelope.Retryable,
+			}
+			if code, ok := envelope.Error.(string); ok && code != "" {
+				result.Code = code
+			}
+			if result.Code == "" {
+				result.Code = CodeExecutionFailed
+			}
+			if result.Message == "" {
+				result.Message = body
+			}
+			return result
+
+		default:
+			if !failed {
+				return Result{Status: StatusSuccess}
+			}
+		}
+	}
+
+	if failed {
+		return Result{Status: StatusError, Code: CodeExecutionFailed, Message: body}
+	}
+
+	return Result{Status: StatusSuccess}
+}
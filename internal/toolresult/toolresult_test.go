@@ -0,0 +1,64 @@
+package toolresult
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Result
+	}{
+		{"success envelope", `{"status":"success","echo":"hi"}`, Result{Status: StatusSuccess}},
+		{"empty error field", `{"status":"success","valid":true,"error":""}`, Result{Status: StatusSuccess}},
+		{"plain text mentioning errors", "the error handling failed to surprise anyone", Result{Status: StatusSuccess}},
+		{"JSON without status", `{"error":"not_found"}`, Result{Status: StatusSuccess}},
+		{
+			"error envelope",
+			`{"status":"error","error":"timeout","message":"upstream timed out","retryable":true}`,
+			Result{Status: StatusError, Code: "timeout", Message: "upstream timed out", Retryable: true},
+		},
+		{
+			"code field",
+			`{"status":"Failed","code":"quota","message":"out of quota"}`,
+			Result{Status: StatusError, Code: "quota", Message: "out of quota"},
+		},
+		{
+			"error envelope without code or message",
+			`{"status":"failure"}`,
+			Result{Status: StatusError, Code: CodeExecutionFailed, Message: `{"status":"failure"}`},
+		},
+		{
+			"failed Go error",
+			"Tool execution failed: connection refused",
+			Result{Status: StatusError, Code: CodeExecutionFailed, Message: "connection refused"},
+		},
+		{
+			"failed Go error with envelope",
+			`Tool execution failed: {"status":"error","error":"invalid_arguments","message":"message is required"}`,
+			Result{Status: StatusError, Code: "invalid_arguments", Message: "message is required"},
+		},
+		{
+			"failed Go error with success status",
+			`Tool execution failed: {"status":"success"}`,
+			Result{Status: StatusError, Code: CodeExecutionFailed, Message: `{"status":"success"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.content); got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorRoundTrip(t *testing.T) {
+	err := NewError("rate_limited", "slow down", true)
+	err.Details = map[string]any{"retry_after_seconds": 2}
+
+	want := Result{Status: StatusError, Code: "rate_limited", Message: "slow down", Retryable: true}
+	if got := Parse(failedPrefix + " " + err.Error()); got != want {
+		t.Errorf("Parse(%s) = %+v, want %+v", err.Error(), got, want)
+	}
+}
@@ -0,0 +1,682 @@
+package validation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+)
+
+var builtins = []Definition{
+	{Name: "email", Description: "Email address", Example: "alice@example.com", Validate: validateEmail},
+	{Name: "url", Description: "Absolute URL with a scheme and a host", Example: "https://example.com/docs", Validate: validateURL},
+	{Name: "json", Description: "JSON document", Example: `{"id": 1}`, Validate: validateJSON},
+	{Name: "uuid", Description: "UUID", Example: "123e4567-e89b-12d3-a456-426614174000", Validate: validateUUID},
+	{Name: "phone", Description: "Phone number with 7 to 15 digits", Example: "+1 (555) 010-4477", Validate: validatePhone},
+	{Name: "ipv4", Description: "IPv4 address in dotted decimal notation", Example: "192.0.2.10", Validate: validateIPv4},
+	{Name: "ipv6", Description: "IPv6 address", Example: "2001:db8::1", Validate: validateIPv6},
+	{Name: "semver", Description: "Semantic version", Example: "1.4.0-rc.1+build.7", Validate: validateSemver},
+	{Name: "cron", Description: "Cron expression with five fields or a macro such as @daily", Example: "*/15 9-17 * * MON-FRI", Validate: validateCron},
+	{Name: "base64", Description: "Base64 data, standard or URL-safe, padded or not", Example: "aGVsbG8gd29ybGQ=", Validate: validateBase64},
+	{Name: "date", Description: "ISO 8601 date or date-time", Example: "2024-02-29T13:45:00Z", Validate: validateDate},
+	{Name: "credit_card", Description: "Payment card number that passes the Luhn check", Example: "4111 1111 1111 1111", Validate: validateCreditCard},
+	{Name: "json_schema", Description: "JSON document that conforms to the given schema", Example: `{"name": "Alice"}`, RequiresSchema: true, Validate: validateJSONSchema},
+}
+
+func fail(format string, args ...any) []Failure {
+	return []Failure{{Reason: fmt.Sprintf(format, args...)}}
+}
+
+func failAt(position int, format string, args ...any) []Failure {
+	return []Failure{{Reason: fmt.Sprintf(format, args...), Position: &position}}
+}
+
+// runeOffset converts a byte offset into input to a rune offset
+func runeOffset(input string, byteOffset int) int {
+	return utf8.RuneCountInString(input[:min(max(byteOffset, 0), len(input))])
+}
+
+func isASCIIAlnum(c rune) bool {
+	return c < utf8.RuneSelf && (unicode.IsLetter(c) || unicode.IsDigit(c))
+}
+
+func isHex(c rune) bool {
+	return strings.ContainsRune("0123456789abcdefABCDEF", c)
+}
+
+func validateEmail(input string, _ Options) []Failure {
+	runes := []rune(input)
+	at := -1
+	for i, c := range runes {
+		if c != '@' {
+			continue
+		}
+		if at >= 0 {
+			return failAt(i, "contains a second @")
+		}
+		at = i
+	}
+	if at < 0 {
+		return fail("is missing the @ between the local part and the domain")
+	}
+
+	local, domain := runes[:at], runes[at+1:]
+	switch {
+	case len(local) == 0:
+		return failAt(0, "has nothing before the @")
+	case len(local) > 64:
+		return failAt(64, "has a local part longer than 64 characters")
+	case local[0] == '.':
+		return failAt(0, "starts with a dot")
+	case local[len(local)-1] == '.':
+		return failAt(at-1, "has a dot right before the @")
+	}
+	for i, c := range local {
+		if !isASCIIAlnum(c) && !strings.ContainsRune("._%+-", c) {
+			return failAt(i, "contains %q, which is not allowed before the @", c)
+		}
+		if c == '.' && i > 0 && local[i-1] == '.' {
+			return failAt(i, "contains two dots in a row")
+		}
+	}
+
+	if len(domain) == 0 {
+		return failAt(at+1, "is missing the domain after the @")
+	}
+	for i, c := range domain {
+		if !isASCIIAlnum(c) && c != '-' && c != '.' {
+			return failAt(at+1+i, "contains %q, which is not allowed in a domain", c)
+		}
+	}
+
+	offset := at + 1
+	labels := strings.Split(string(domain), ".")
+	if len(labels) < 2 {
+		return failAt(len(runes), "has a domain without a top-level domain, e.g. example.com")
+	}
+	for i, label := range labels {
+		switch {
+		case label == "":
+			return failAt(offset, "has an empty domain label")
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return failAt(offset, "has a domain label that starts or ends with a hyphen")
+		}
+		if i == len(labels)-1 {
+			for _, c := range label {
+				if !unicode.IsLetter(c) {
+					return failAt(offset, "has a top-level domain with characters other than letters")
+				}
+			}
+			if len(label) < 2 {
+				return failAt(offset, "has a top-level domain shorter than two letters")
+			}
+		}
+		offset += len(label) + 1
+	}
+	return nil
+}
+
+func validateURL(input string, _ Options) []Failure {
+	for i, c := range []rune(input) {
+		if unicode.IsSpace(c) || unicode.IsControl(c) {
+			return failAt(i, "contains whitespace or a control character")
+		}
+	}
+
+	u, err := url.Parse(input)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fail("cannot be parsed: %v", err)
+	}
+	if u.Scheme == "" {
+		return failAt(0, "is missing a scheme such as https://")
+	}
+	if u.Host == "" {
+		return failAt(runeOffset(input, len(u.Scheme)+1), "is missing a host after %s:", u.Scheme)
+	}
+	return nil
+}
+
+func validateJSON(input string, _ Options) []Failure {
+	var document any
+	return jsonFailures(input, json.Unmarshal([]byte(input), &document))
+}
+
+// jsonFailures describes a JSON decoding error, pointing at the offending character
+func jsonFailures(input string, err error) []Failure {
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Input cut short is reported at its end, anything else at the
+		// character that was read last
+		position := runeOffset(input, int(syntaxErr.Offset)-1)
+		if strings.HasPrefix(syntaxErr.Error(), "unexpected end") {
+			position = utf8.RuneCountInString(input)
+		}
+		return failAt(position, "is not valid JSON: %s", syntaxErr.Error())
+	}
+	return fail("is not valid JSON: %v", err)
+}
+
+func validateUUID(input string, _ Options) []Failure {
+	if _, err := uuid.Parse(input); err == nil {
+		return nil
+	}
+
+	runes := []rune(input)
+	for i, c := range runes {
+		if i >= 36 {
+			return failAt(36, "is longer than the 36 characters of a UUID")
+		}
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return failAt(i, "must have a hyphen here, in the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
+			}
+		default:
+			if !isHex(c) {
+				return failAt(i, "contains %q, which is not a hexadecimal digit", c)
+			}
+		}
+	}
+	return failAt(len(runes), "ends after %d of the 36 characters of a UUID", len(runes))
+}
+
+func validatePhone(input string, _ Options) []Failure {
+	digits, open := 0, 0
+	for i, c := range []rune(input) {
+		switch {
+		case unicode.IsDigit(c) && c < utf8.RuneSelf:
+			digits++
+		case c == '+':
+			if strings.TrimSpace(string([]rune(input)[:i])) != "" {
+				return failAt(i, "has a + that is not at the start")
+			}
+		case c == '(':
+			open++
+		case c == ')':
+			if open == 0 {
+				return failAt(i, "closes a parenthesis that was not opened")
+			}
+			open--
+		case c == ' ' || c == '-' || c == '.':
+		default:
+			return failAt(i, "contains %q, which does not belong in a phone number", c)
+		}
+	}
+
+	switch {
+	case open > 0:
+		return fail("has a parenthesis that is not closed")
+	case digits < 7:
+		return fail("has %d digits, fewer than the 7 of the shortest phone numbers", digits)
+	case digits > 15:
+		return fail("has %d digits, more than the 15 of international phone numbers", digits)
+	}
+	return nil
+}
+
+func validateIPv4(input string, _ Options) []Failure {
+	parts := strings.Split(input, ".")
+	if len(parts) != 4 {
+		return fail("must have four numbers separated by dots, got %d parts", len(parts))
+	}
+
+	offset := 0
+	for i, part := range parts {
+		octet := i + 1
+		if part == "" {
+			return failAt(runeOffset(input, offset), "has an empty octet %d", octet)
+		}
+		for j, c := range part {
+			if c < '0' || c > '9' {
+				return failAt(runeOffset(input, offset+j), "has %q in octet %d, which is not a digit", c, octet)
+			}
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return failAt(runeOffset(input, offset), "has a leading zero in octet %d", octet)
+		}
+		if n, err := strconv.Atoi(part); err != nil || n > 255 {
+			return failAt(runeOffset(input, offset), "has octet %d of %s, more than 255", octet, part)
+		}
+		offset += len(part) + 1
+	}
+	return nil
+}
+
+func validateIPv6(input string, _ Options) []Failure {
+	for i, c := range []rune(input) {
+		if !isHex(c) && c != ':' && c != '.' {
+			return failAt(i, "contains %q, which does not belong in an IPv6 address", c)
+		}
+	}
+
+	addr, err := netip.ParseAddr(input)
+	if err != nil {
+		// netip quotes the unparsed rest of the input as (at "...")
+		reason := err.Error()
+		if _, rest, ok := strings.Cut(reason, `(at "`); ok {
+			rest = strings.TrimSuffix(rest, `")`)
+			if strings.HasSuffix(input, rest) {
+				return failAt(utf8.RuneCountInString(input)-utf8.RuneCountInString(rest), "is not a valid IPv6 address: %s", ipError(reason))
+			}
+		}
+		return fail("is not a valid IPv6 address: %s", ipError(reason))
+	}
+	if !addr.Is6() {
+		return fail("is an IPv4 address, not an IPv6 address")
+	}
+	return nil
+}
+
+// ipError strips the input netip repeats from its error messages
+func ipError(message string) string {
+	if _, rest, ok := strings.Cut(message, "): "); ok {
+		message = rest
+	}
+	if before, _, ok := strings.Cut(message, ` (at "`); ok {
+		message = before
+	}
+	return message
+}
+
+func validateSemver(input string, _ Options) []Failure {
+	if strings.HasPrefix(input, "v") || strings.HasPrefix(input, "V") {
+		return failAt(0, "starts with %q; semantic versions are bare numbers like 1.2.3", input[:1])
+	}
+
+	version, build, hasBuild := strings.Cut(input, "+")
+	core, prerelease, hasPrerelease := strings.Cut(version, "-")
+
+	numbers := strings.Split(core, ".")
+	if len(numbers) != 3 {
+		return fail("must have three numbers MAJOR.MINOR.PATCH, got %d", len(numbers))
+	}
+	offset := 0
+	for i, number := range numbers {
+		part := []string{"major", "minor", "patch"}[i]
+		if number == "" {
+			return failAt(runeOffset(input, offset), "has an empty %s version", part)
+		}
+		for j, c := range number {
+			if c < '0' || c > '9' {
+				return failAt(runeOffset(input, offset+j), "has %q in the %s version, which is not a digit", c, part)
+			}
+		}
+		if len(number) > 1 && number[0] == '0' {
+			return failAt(runeOffset(input, offset), "has a leading zero in the %s version", part)
+		}
+		offset += len(number) + 1
+	}
+
+	if hasPrerelease {
+		if failures := semverIdentifiers(input, prerelease, len(core)+1, "pre-release", true); failures != nil {
+			return failures
+		}
+	}
+	if hasBuild {
+		if failures := semverIdentifiers(input, build, len(version)+1, "build metadata", false); failures != nil {
+			return failures
+		}
+	}
+	return nil
+}
+
+// semverIdentifiers checks the dot-separated identifiers of a pre-release
+// or build metadata part that starts at offset
+func semverIdentifiers(input, part string, offset int, name string, numericNoZero bool) []Failure {
+	for _, identifier := range strings.Split(part, ".") {
+		if identifier == "" {
+			return failAt(runeOffset(input, offset), "has an empty %s identifier", name)
+		}
+		numeric := true
+		for j, c := range identifier {
+			if !isASCIIAlnum(c) && c != '-' {
+				return failAt(runeOffset(input, offset+j), "has %q in the %s, which only allows letters, digits and hyphens", c, name)
+			}
+			if c < '0' || c > '9' {
+				numeric = false
+			}
+		}
+		if numericNoZero && numeric && len(identifier) > 1 && identifier[0] == '0' {
+			return failAt(runeOffset(input, offset), "has a numeric %s identifier with a leading zero", name)
+		}
+		offset += len(identifier) + 1
+	}
+	return nil
+}
+
+// cronFields are the fields of a cron expression in order
+var cronFields = []struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{"day of week", 0, 7, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true, "@reboot": true,
+}
+
+func validateCron(input string, _ Options) []Failure {
+	trimmed := strings.TrimSpace(input)
+	if strings.HasPrefix(trimmed, "@") {
+		if cronMacros[trimmed] {
+			return nil
+		}
+		return failAt(runeOffset(input, strings.Index(input, "@")), "uses an unknown macro; known are @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly and @reboot")
+	}
+
+	// Fields with the byte offset they start at
+	type field struct {
+		text   string
+		offset int
+	}
+	var fields []field
+	start := -1
+	for i, c := range input + " " {
+		switch {
+		case c == ' ' || c == '\t':
+			if start >= 0 {
+				fields = append(fields, field{input[start:i], start})
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	if len(fields) != len(cronFields) {
+		return fail("must have 5 fields (minute, hour, day of month, month, day of week), got %d", len(fields))
+	}
+
+	for i, f := range fields {
+		spec := cronFields[i]
+		offset := f.offset
+		for _, item := range strings.Split(f.text, ",") {
+			if item == "" {
+				return failAt(runeOffset(input, offset), "has an empty list item in the %s field", spec.name)
+			}
+
+			base, step, hasStep := strings.Cut(item, "/")
+			if hasStep {
+				if n, err := strconv.Atoi(step); err != nil || n < 1 {
+					return failAt(runeOffset(input, offset+len(base)+1), "has a %s step of %q, which is not a positive number", spec.name, step)
+				}
+			}
+
+			if base != "*" {
+				low, high, isRange := strings.Cut(base, "-")
+				from, ok := cronValue(low, spec.min, spec.max, spec.names)
+				if !ok {
+					return failAt(runeOffset(input, offset), "has a %s of %q, outside %d-%d", spec.name, low, spec.min, spec.max)
+				}
+				if isRange {
+					to, ok := cronValue(high, spec.min, spec.max, spec.names)
+					if !ok {
+						return failAt(runeOffset(input, offset+len(low)+1), "has a %s of %q, outside %d-%d", spec.name, high, spec.min, spec.max)
+					}
+					if from > to {
+						return failAt(runeOffset(input, offset), "has a %s range %s that runs backwards", spec.name, base)
+					}
+				}
+			}
+			offset += len(item) + 1
+		}
+	}
+	return nil
+}
+
+// cronValue parses a number or, for months and weekdays, a name
+func cronValue(value string, lowest, highest int, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i + lowest, true
+		}
+	}
+	n, err := strconv.Atoi(value)
+	return n, err == nil && n >= lowest && n <= highest
+}
+
+func validateBase64(input string, _ Options) []Failure {
+	_, err := base64.StdEncoding.DecodeString(input)
+	if err == nil {
+		return nil
+	}
+	for _, encoding := range []*base64.Encoding{base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if _, alt := encoding.DecodeString(input); alt == nil {
+			return nil
+		}
+	}
+
+	var corrupt base64.CorruptInputError
+	if errors.As(err, &corrupt) {
+		offset := int(corrupt)
+		if offset >= len(input) {
+			return failAt(utf8.RuneCountInString(input), "ends with incomplete padding; the length must be a multiple of 4")
+		}
+		c, _ := utf8.DecodeRuneInString(input[offset:])
+		if c == '=' {
+			return failAt(runeOffset(input, offset), "has padding where data is expected")
+		}
+		return failAt(runeOffset(input, offset), "contains %q, which is not a Base64 character", c)
+	}
+	return fail("is not valid Base64: %v", err)
+}
+
+// dateScanner walks an ISO 8601 date-time, failing at the first character
+// that does not fit
+type dateScanner struct {
+	input []rune
+	pos   int
+}
+
+func (s *dateScanner) digits(n int, what string) (int, []Failure) {
+	value := 0
+	for i := 0; i < n; i++ {
+		if s.pos >= len(s.input) {
+			return 0, failAt(s.pos, "ends before the %s", what)
+		}
+		c := s.input[s.pos]
+		if c < '0' || c > '9' {
+			return 0, failAt(s.pos, "has %q where the %s should be", c, what)
+		}
+		value = value*10 + int(c-'0')
+		s.pos++
+	}
+	return value, nil
+}
+
+func (s *dateScanner) separator(c rune, what string) []Failure {
+	if s.pos >= len(s.input) {
+		return failAt(s.pos, "ends before the %s", what)
+	}
+	if s.input[s.pos] != c {
+		return failAt(s.pos, "has %q where %q should separate the %s", s.input[s.pos], c, what)
+	}
+	s.pos++
+	return nil
+}
+
+func (s *dateScanner) done() bool {
+	return s.pos >= len(s.input)
+}
+
+func validateDate(input string, _ Options) []Failure {
+	s := &dateScanner{input: []rune(input)}
+
+	year, failures := s.digits(4, "year")
+	if failures != nil {
+		return failures
+	}
+	if failures := s.separator('-', "month"); failures != nil {
+		return failures
+	}
+	month, failures := s.digits(2, "month")
+	if failures != nil {
+		return failures
+	}
+	if month < 1 || month > 12 {
+		return failAt(5, "has month %02d, outside 01-12", month)
+	}
+	if failures := s.separator('-', "day"); failures != nil {
+		return failures
+	}
+	day, failures := s.digits(2, "day")
+	if failures != nil {
+		return failures
+	}
+	if last := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); day < 1 || day > last {
+		return failAt(8, "has day %02d, but %04d-%02d has %d days", day, year, month, last)
+	}
+	if s.done() {
+		return nil
+	}
+
+	if failures := s.separator('T', "time"); failures != nil {
+		return failures
+	}
+	for _, part := range []struct {
+		name    string
+		highest int
+	}{{"hour", 23}, {"minute", 59}, {"second", 59}} {
+		if part.name != "hour" {
+			if part.name == "second" && (s.done() || s.input[s.pos] != ':') {
+				break
+			}
+			if failures := s.separator(':', part.name); failures != nil {
+				return failures
+			}
+		}
+		start := s.pos
+		value, failures := s.digits(2, part.name)
+		if failures != nil {
+			return failures
+		}
+		if value > part.highest {
+			return failAt(start, "has %s %02d, outside 00-%02d", part.name, value, part.highest)
+		}
+	}
+
+	if !s.done() && (s.input[s.pos] == '.' || s.input[s.pos] == ',') {
+		s.pos++
+		start := s.pos
+		for !s.done() && s.input[s.pos] >= '0' && s.input[s.pos] <= '9' {
+			s.pos++
+		}
+		if s.pos == start {
+			return failAt(start, "has a decimal point without fractional seconds")
+		}
+	}
+	if s.done() {
+		return nil
+	}
+
+	switch s.input[s.pos] {
+	case 'Z':
+		s.pos++
+	case '+', '-':
+		s.pos++
+		start := s.pos
+		hours, failures := s.digits(2, "offset hours")
+		if failures != nil {
+			return failures
+		}
+		if hours > 23 {
+			return failAt(start, "has an offset of %02d hours, outside 00-23", hours)
+		}
+		if !s.done() && s.input[s.pos] == ':' {
+			s.pos++
+		}
+		start = s.pos
+		minutes, failures := s.digits(2, "offset minutes")
+		if failures != nil {
+			return failures
+		}
+		if minutes > 59 {
+			return failAt(start, "has an offset of %02d minutes, outside 00-59", minutes)
+		}
+	default:
+		return failAt(s.pos, "has %q where the time zone (Z or an offset like +02:00) should be", s.input[s.pos])
+	}
+
+	if !s.done() {
+		return failAt(s.pos, "continues after the time zone")
+	}
+	return nil
+}
+
+func validateCreditCard(input string, _ Options) []Failure {
+	var digits []int
+	for i, c := range []rune(input) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, int(c-'0'))
+		case c == ' ' || c == '-':
+		default:
+			return failAt(i, "contains %q; card numbers only have digits, spaces and hyphens", c)
+		}
+	}
+	if len(digits) < 12 || len(digits) > 19 {
+		return fail("has %d digits; card numbers have 12 to 19", len(digits))
+	}
+
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return fail("fails the Luhn checksum, so a digit is wrong or two are swapped")
+	}
+	return nil
+}
+
+func validateJSONSchema(input string, options Options) []Failure {
+	if options.Schema == nil {
+		return fail("has no schema to be validated against")
+	}
+
+	var document any
+	if failures := jsonFailures(input, json.Unmarshal([]byte(input), &document)); failures != nil {
+		return failures
+	}
+
+	verr := schema.ValidateValue(options.Schema, document)
+	if verr == nil {
+		return nil
+	}
+	failures := make([]Failure, 0, len(verr.Violations))
+	for _, v := range verr.Violations {
+		failures = append(failures, Failure{Reason: v.Message, Path: v.Field})
+	}
+	return failures
+}
@@ -0,0 +1,162 @@
+package validation
+
+import "testing"
+
+// noPosition marks failures that do not point at a character
+const noPosition = -1
+
+func TestBuiltinExamples(t *testing.T) {
+	schema := map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}}
+	for _, d := range NewRegistry().Definitions() {
+		if failures := d.Validate(d.Example, Options{Schema: schema}); failures != nil {
+			t.Errorf("%s: example %q is invalid: %+v", d.Name, d.Example, failures)
+		}
+	}
+}
+
+func TestBuiltinValidators(t *testing.T) {
+	person := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"name": map[string]any{"type": "string"}},
+		"required":   []string{"name"},
+	}
+
+	tests := []struct {
+		validator string
+		input     string
+		schema    map[string]any
+		valid     bool
+		// position is where the first failure points, or noPosition
+		position int
+		path     string
+	}{
+		{validator: "email", input: "alice.smith+tag@mail.example.com", valid: true},
+		{validator: "email", input: "alice", position: noPosition},
+		{validator: "email", input: "alice@@example.com", position: 6},
+		{validator: "email", input: "zoë@@example.com", position: 4},
+		{validator: "email", input: "al ice@example.com", position: 2},
+		{validator: "email", input: "al..ice@example.com", position: 3},
+		{validator: "email", input: "alice@example", position: 13},
+		{validator: "email", input: "alice@-example.com", position: 6},
+
+		{validator: "url", input: "https://example.com/docs?q=1", valid: true},
+		{validator: "url", input: "https://exa mple.com", position: 11},
+		{validator: "url", input: "example.com/docs", position: 0},
+		{validator: "url", input: "mailto:alice", position: 7},
+
+		{validator: "json", input: `[1, {"a": null}]`, valid: true},
+		{validator: "json", input: `{"id": 1,}`, position: 9},
+		{validator: "json", input: `{"id": `, position: 7},
+		{validator: "json", input: `{"id": 1`, position: 8},
+		{validator: "json", input: `["é", x]`, position: 6},
+
+		{validator: "uuid", input: "123e4567-e89b-12d3-a456-426614174000", valid: true},
+		{validator: "uuid", input: "123e4567e89b-12d3-a456-426614174000", position: 8},
+		{validator: "uuid", input: "123e4567-e89b-12d3-a456-42661417400g", position: 35},
+		{validator: "uuid", input: "123e4567-e89b", position: 13},
+		{validator: "uuid", input: "123e4567-e89b-12d3-a456-4266141740001", position: 36},
+
+		{validator: "phone", input: "+1 (555) 010-4477", valid: true},
+		{validator: "phone", input: "555-01x0-447", position: 6},
+		{validator: "phone", input: "1 +555 0104477", position: 2},
+		{validator: "phone", input: "555) 0104477", position: 3},
+		{validator: "phone", input: "(555 0104477", position: noPosition},
+		{validator: "phone", input: "555-01", position: noPosition},
+		{validator: "phone", input: "1234567890123456", position: noPosition},
+
+		{validator: "ipv4", input: "192.168.1.255", valid: true},
+		{validator: "ipv4", input: "192.168.1", position: noPosition},
+		{validator: "ipv4", input: "192.1a8.1.1", position: 5},
+		{validator: "ipv4", input: "192.168.01.1", position: 8},
+		{validator: "ipv4", input: "192.168.1.256", position: 10},
+		{validator: "ipv4", input: "192..1.1", position: 4},
+
+		{validator: "ipv6", input: "2001:db8::1", valid: true},
+		{validator: "ipv6", input: "::ffff:192.0.2.1", valid: true},
+		{validator: "ipv6", input: "2001:db8::g1", position: 10},
+		{validator: "ipv6", input: "192.0.2.10", position: noPosition},
+
+		{validator: "semver", input: "1.4.0-rc.1+build.7", valid: true},
+		{validator: "semver", input: "v1.2.3", position: 0},
+		{validator: "semver", input: "1.2", position: noPosition},
+		{validator: "semver", input: "1.02.3", position: 2},
+		{validator: "semver", input: "1.2.x", position: 4},
+		{validator: "semver", input: "1.2.3-rc.01", position: 9},
+		{validator: "semver", input: "1.2.3-rc..1", position: 9},
+		{validator: "semver", input: "1.2.3+bu_ild", position: 8},
+
+		{validator: "cron", input: "*/15 9-17 * * MON-FRI", valid: true},
+		{validator: "cron", input: "@daily", valid: true},
+		{validator: "cron", input: "@sometimes", position: 0},
+		{validator: "cron", input: "* * *", position: noPosition},
+		{validator: "cron", input: "60 * * * *", position: 0},
+		{validator: "cron", input: "* 5-2 * * *", position: 2},
+		{validator: "cron", input: "*/0 * * * *", position: 2},
+		{validator: "cron", input: "0 0 1,,2 * *", position: 6},
+		{validator: "cron", input: "0 0 * FOO *", position: 6},
+
+		{validator: "base64", input: "aGVsbG8gd29ybGQ=", valid: true},
+		{validator: "base64", input: "aGVsbG8gd29ybGQ", valid: true},
+		{validator: "base64", input: "-_-_", valid: true},
+		{validator: "base64", input: "aGVs*G8=", position: 4},
+
+		{validator: "date", input: "2024-02-29", valid: true},
+		{validator: "date", input: "2024-02-29T13:45:00.123+02:00", valid: true},
+		{validator: "date", input: "2024-02-29T13:45Z", valid: true},
+		{validator: "date", input: "2024/02/29", position: 4},
+		{validator: "date", input: "2024-13-01", position: 5},
+		{validator: "date", input: "2023-02-29", position: 8},
+		{validator: "date", input: "2024-02-29T24:00", position: 11},
+		{validator: "date", input: "2024-02-29T13:45:00.Z", position: 20},
+		{validator: "date", input: "2024-02-29T13:45:00 UTC", position: 19},
+		{validator: "date", input: "2024-02-29T13:45:00+25:00", position: 20},
+		{validator: "date", input: "2024-02", position: 7},
+
+		{validator: "credit_card", input: "4111 1111 1111 1111", valid: true},
+		{validator: "credit_card", input: "4111-1111-x111-1111", position: 10},
+		{validator: "credit_card", input: "4111 1111 1111 1112", position: noPosition},
+		{validator: "credit_card", input: "4111", position: noPosition},
+
+		{validator: "json_schema", input: `{"name": "Alice"}`, schema: person, valid: true},
+		{validator: "json_schema", input: `{"name": 5}`, schema: person, position: noPosition, path: "name"},
+		{validator: "json_schema", input: `{}`, schema: person, position: noPosition, path: "name"},
+		{validator: "json_schema", input: `{"name": }`, schema: person, position: 9},
+		{validator: "json_schema", input: `{"name": "Alice"}`, position: noPosition},
+	}
+
+	registry := NewRegistry()
+	for _, tt := range tests {
+		t.Run(tt.validator+"/"+tt.input, func(t *testing.T) {
+			d, ok := registry.Lookup(tt.validator)
+			if !ok {
+				t.Fatalf("no validator %q", tt.validator)
+			}
+
+			failures := d.Validate(tt.input, Options{Schema: tt.schema})
+			if tt.valid {
+				if failures != nil {
+					t.Fatalf("Validate(%q) = %+v, want valid", tt.input, failures)
+				}
+				return
+			}
+			if len(failures) == 0 {
+				t.Fatalf("Validate(%q) found the input valid", tt.input)
+			}
+
+			first := failures[0]
+			if first.Reason == "" {
+				t.Error("failure has no reason")
+			}
+			position := noPosition
+			if first.Position != nil {
+				position = *first.Position
+			}
+			if position != tt.position {
+				t.Errorf("Validate(%q) points at %d, want %d (%s)", tt.input, position, tt.position, first.Reason)
+			}
+			if first.Path != tt.path {
+				t.Errorf("Validate(%q) path = %q, want %q", tt.input, first.Path, tt.path)
+			}
+		})
+	}
+}
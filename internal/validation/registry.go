@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Failure is one reason an input is invalid
+type Failure struct {
+	Reason string `json:"reason"`
+	// Position is the offset of the offending character in runes, when the
+	// validator can tell
+	Position *int `json:"position,omitempty"`
+	// Path locates the offending value within a JSON document, e.g. items[2].name
+	Path string `json:"path,omitempty"`
+}
+
+// Options carry what a validator needs besides the input
+type Options struct {
+	// Schema is the JSON Schema json_schema validates documents against
+	Schema map[string]any
+}
+
+// Validator checks an input and returns why it is invalid, or nothing when it is valid
+type Validator func(input string, options Options) []Failure
+
+// Definition is a validation type of the registry
+type Definition struct {
+	Name        string
+	Description string
+	// Example is a valid input
+	Example string
+	// RequiresSchema is set for validators that need Options.Schema
+	RequiresSchema bool
+	Validate       Validator
+}
+
+// Registry holds validation types by name
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// NewRegistry creates a registry with the built-in validation types
+func NewRegistry() *Registry {
+	r := &Registry{definitions: map[string]Definition{}}
+	for _, d := range builtins {
+		r.definitions[d.Name] = d
+	}
+	return r
+}
+
+// Register adds a validation type. Names must be unique.
+func (r *Registry) Register(d Definition) error {
+	if d.Name == "" || d.Validate == nil {
+		return fmt.Errorf("validation type needs a name and a validator")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.definitions[d.Name]; exists {
+		return fmt.Errorf("validation type %q is already registered", d.Name)
+	}
+	r.definitions[d.Name] = d
+	return nil
+}
+
+// Lookup returns the validation type with the given name
+func (r *Registry) Lookup(name string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.definitions[name]
+	return d, ok
+}
+
+// Names lists the validation types in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.definitions))
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Definitions lists the validation types in alphabetical order
+func (r *Registry) Definitions() []Definition {
+	names := r.Names()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	definitions := make([]Definition, 0, len(names))
+	for _, name := range names {
+		definitions = append(definitions, r.definitions[name])
+	}
+	return definitions
+}
+
+// CustomType is a validation type defined by a regular expression
+type CustomType struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Pattern     string `yaml:"pattern"`
+	// Message is the failure reason; it defaults to naming the pattern
+	Message string `yaml:"message"`
+	Example string `yaml:"example"`
+}
+
+// LoadCustomTypes reads validation types from a YAML file with a
+// validators list and registers them
+func (r *Registry) LoadCustomTypes(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read validators file: %w", err)
+	}
+
+	var file struct {
+		Validators []CustomType `yaml:"validators"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("failed to parse validators file: %w", err)
+	}
+
+	for _, custom := range file.Validators {
+		if err := r.RegisterPattern(custom); err != nil {
+			return 0, err
+		}
+	}
+	return len(file.Validators), nil
+}
+
+// RegisterPattern adds a validation type that accepts inputs matching a
+// regular expression
+func (r *Registry) RegisterPattern(custom CustomType) error {
+	re, err := regexp.Compile(custom.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern for validation type %q: %w", custom.Name, err)
+	}
+
+	reason := custom.Message
+	if reason == "" {
+		reason = fmt.Sprintf("does not match the pattern %s", custom.Pattern)
+	}
+	description := custom.Description
+	if description == "" {
+		description = fmt.Sprintf("Matches %s", custom.Pattern)
+	}
+
+	return r.Register(Definition{
+		Name:        custom.Name,
+		Description: description,
+		Example:     custom.Example,
+		Validate: func(input string, _ Options) []Failure {
+			if re.MatchString(input) {
+				return nil
+			}
+			return []Failure{{Reason: reason}}
+		},
+	})
+}
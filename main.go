@@ -1,7 +1,3 @@
-// Code generated by ADL CLI v0.23.9. DO NOT EDIT.
-// This file was automatically generated from an ADL (Agent Definition Language) specification.
-// Manual changes to this file may be overwritten during regeneration.
-
 package main
 
 import (
@@ -16,10 +12,15 @@ import (
 	zap "go.uber.org/zap"
 
 	config "github.com/inference-gateway/mock-agent/config"
-	skills "github.com/inference-gateway/mock-agent/skills"
 
+	admin "github.com/inference-gateway/mock-agent/internal/admin"
+	app "github.com/inference-gateway/mock-agent/internal/app"
+	card "github.com/inference-gateway/mock-agent/internal/card"
 	logger "github.com/inference-gateway/mock-agent/internal/logger"
-	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	openai "github.com/inference-gateway/mock-agent/internal/openai"
+	persona "github.com/inference-gateway/mock-agent/internal/persona"
+	push "github.com/inference-gateway/mock-agent/internal/push"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
 )
 
 var (
@@ -44,62 +45,11 @@ func main() {
 
 	l.Info("starting " + AgentName + " agent (version: " + Version + ", environment: " + cfg.Environment + ")")
 
-	// Create toolbox with default tools (like input_required, create_artifact etc)
-	toolBox := server.NewDefaultToolBox(&cfg.A2A.AgentConfig.ToolBoxConfig)
-
-	// Register echo skill
-	echoSkill := skills.NewEchoSkill()
-	toolBox.AddTool(echoSkill)
-	l.Info("registered skill: echo (Echo back the input message (useful for basic connectivity tests))")
-
-	// Register delay skill
-	delaySkill := skills.NewDelaySkill()
-	toolBox.AddTool(delaySkill)
-	l.Info("registered skill: delay (Simulate slow responses with configurable delays)")
-
-	// Register error skill
-	errorSkill := skills.NewErrorSkill()
-	toolBox.AddTool(errorSkill)
-	l.Info("registered skill: error (Simulate error conditions for testing error handling)")
-
-	// Register random_data skill
-	randomDataSkill := skills.NewRandomDataSkill()
-	toolBox.AddTool(randomDataSkill)
-	l.Info("registered skill: random_data (Generate random test data)")
-
-	// Register validate skill
-	validateSkill := skills.NewValidateSkill()
-	toolBox.AddTool(validateSkill)
-	l.Info("registered skill: validate (Validate input against common patterns)")
-
-	llmClient := mock.NewMockLLMClient()
-	l.Info("using mock LLM client (no external API calls)")
-
-	agent, err := server.NewAgentBuilder(l).
-		WithConfig(&cfg.A2A.AgentConfig).
-		WithLLMClient(llmClient).
-		WithToolBox(toolBox).
-		WithMaxChatCompletion(cfg.A2A.AgentConfig.MaxChatCompletionIterations).
-		WithSystemPrompt(`You are a mock AI assistant designed for testing and development purposes.
-
-You have access to several mock skills that demonstrate different testing scenarios:
-- echo: Simply echo back the input message (useful for basic connectivity tests)
-- delay: Simulate slow responses with configurable delays
-- error: Simulate error conditions for testing error handling
-- random_data: Generate random test data
-- validate: Validate input against common patterns
-
-When responding:
-- Be clear and predictable in your responses
-- Include relevant metadata about the request
-- Support both streaming and non-streaming modes
-- Handle edge cases gracefully
-
-Your purpose is to provide consistent, reproducible responses for testing A2A protocol implementations.
-`).
-		Build()
-	if err != nil {
-		l.Fatal("failed to create agent", zap.Error(err))
+	// Seeded runs produce reproducible IDs, random data and timestamps
+	source := rng.NewFromConfig(&cfg.Mock)
+	if source.Seeded() {
+		l.Info("deterministic mode enabled", zap.Int64("seed", cfg.Mock.Seed))
+		source.Fork("a2a").SeedUUIDs()
 	}
 
 	artifactService, err := server.NewArtifactService(&cfg.A2A.ArtifactsConfig, l)
@@ -119,28 +69,74 @@ Your purpose is to provide consistent, reproducible responses for testing A2A pr
 		artifactsServer = nil
 	}
 
-	a2aServer, err := server.NewA2AServerBuilder(cfg.A2A, l).
-		WithAgent(agent).
-		WithAgentCardFromFile(".well-known/agent-card.json", map[string]any{
-			"name":        AgentName,
-			"version":     Version,
-			"description": AgentDescription,
-			"url":         cfg.A2A.AgentURL,
-		}).
-		WithArtifactService(artifactService).
-		WithDefaultBackgroundTaskHandler().
-		WithDefaultStreamingTaskHandler().
-		Build()
+	sh, err := app.NewShared(&cfg, artifactService, source, l)
 	if err != nil {
-		l.Fatal("failed to create A2A server", zap.Error(err))
+		l.Fatal("failed to set up the mock agent", zap.Error(err))
 	}
 
-	go func() {
-		l.Info("starting A2A server", zap.String("port", cfg.A2A.ServerConfig.Port))
-		if err := a2aServer.Start(ctx); err != nil {
-			l.Fatal("server failed to start", zap.Error(err))
+	// The main agent is described by the environment, further personas by
+	// the personas file
+	mainAgent, err := app.NewAgent(sh, persona.Persona{
+		Name:          AgentName,
+		Description:   AgentDescription,
+		Version:       Version,
+		Port:          cfg.A2A.ServerConfig.Port,
+		ScenariosPath: cfg.Mock.ScenariosPath,
+		CardPath:      card.File(cfg.A2A.AgentCardFilePath),
+	}, source, l)
+	if err != nil {
+		l.Fatal("failed to create agent", zap.Error(err))
+	}
+	agents := []*app.Agent{mainAgent}
+
+	if cfg.Mock.PersonasPath != "" {
+		personas, err := persona.Load(cfg.Mock.PersonasPath)
+		if err != nil {
+			l.Fatal("failed to load personas", zap.Error(err))
 		}
-	}()
+		for _, p := range personas {
+			if p.Port == cfg.A2A.ServerConfig.Port {
+				l.Fatal("persona uses the port of the main agent", zap.String("persona", p.Name), zap.String("port", p.Port))
+			}
+			if p.Version == "" {
+				p.Version = Version
+			}
+			if p.Description == "" {
+				p.Description = AgentDescription
+			}
+
+			pl := l.With(zap.String("persona", p.Name))
+			agent, err := app.NewAgent(sh, p, source.Fork("persona/"+p.Name), pl)
+			if err != nil {
+				l.Fatal("failed to create persona", zap.String("persona", p.Name), zap.Error(err))
+			}
+			if p.PathPrefix != "" {
+				mainAgent.Gateway.Mount(p.PathPrefix, agent.Gateway)
+			}
+			agents = append(agents, agent)
+		}
+		l.Info("loaded personas", zap.String("path", cfg.Mock.PersonasPath), zap.Int("count", len(personas)))
+	}
+
+	for _, agent := range agents {
+		go func() {
+			l.Info("starting A2A server", zap.String("persona", agent.Persona.Name), zap.String("port", agent.Upstream.Port()))
+			if err := agent.Upstream.Start(ctx); err != nil {
+				l.Fatal("server failed to start", zap.String("persona", agent.Persona.Name), zap.Error(err))
+			}
+		}()
+
+		if agent.Persona.PathPrefix != "" {
+			l.Info("serving persona under path prefix", zap.String("persona", agent.Persona.Name), zap.String("path_prefix", agent.Persona.PathPrefix))
+			continue
+		}
+		go func() {
+			l.Info("starting A2A gateway", zap.String("persona", agent.Persona.Name), zap.String("port", agent.Persona.Port))
+			if err := agent.Gateway.Start(ctx); err != nil {
+				l.Fatal("A2A gateway failed to start", zap.String("persona", agent.Persona.Name), zap.Error(err))
+			}
+		}()
+	}
 
 	if artifactsServer != nil {
 		go func() {
@@ -151,6 +147,40 @@ Your purpose is to provide consistent, reproducible responses for testing A2A pr
 		}()
 	}
 
+	// Record the notifications posted to the control API's webhook
+	var sink *push.Sink
+	if cfg.Mock.Push.Sink.Enable {
+		if !cfg.Mock.Admin.Enable {
+			l.Warn("push notification sink needs the mock control API - set MOCK_ADMIN_ENABLE=true")
+		}
+		sink = push.NewSink(cfg.Mock.Push.Sink.MaxEntries)
+	}
+
+	var adminServer *admin.Server
+	if cfg.Mock.Admin.Enable {
+		adminServer = admin.NewServer(&cfg.Mock.Admin, mainAgent.MockClient, mainAgent.Injector, sh.Journal, sink, l)
+		for _, agent := range agents[1:] {
+			adminServer.AddPersona(agent.Persona.Name, agent.MockClient, agent.Injector)
+		}
+		go func() {
+			l.Info("starting mock control API server", zap.String("port", cfg.Mock.Admin.Port))
+			if err := adminServer.Start(ctx); err != nil {
+				l.Fatal("mock control API server failed to start", zap.Error(err))
+			}
+		}()
+	}
+
+	var openaiServer *openai.Server
+	if cfg.Mock.OpenAI.Enable {
+		openaiServer = openai.NewServer(&cfg.Mock.OpenAI, mainAgent.LLMClient, source, l)
+		go func() {
+			l.Info("starting OpenAI-compatible API server", zap.String("port", cfg.Mock.OpenAI.Port))
+			if err := openaiServer.Start(ctx); err != nil {
+				l.Fatal("OpenAI-compatible API server failed to start", zap.Error(err))
+			}
+		}()
+	}
+
 	l.Info("mock-agent agent running successfully",
 		zap.String("port", cfg.A2A.ServerConfig.Port),
 		zap.String("environment", cfg.Environment))
@@ -160,9 +190,27 @@ Your purpose is to provide consistent, reproducible responses for testing A2A pr
 	<-quit
 
 	l.Info("shutdown signal received, gracefully stopping server...")
-	a2aServer.Stop(ctx)
+	sh.Close()
+	for _, agent := range agents {
+		if err := agent.Gateway.Stop(ctx); err != nil {
+			l.Warn("failed to stop A2A gateway", zap.String("persona", agent.Persona.Name), zap.Error(err))
+		}
+		if err := agent.Upstream.Stop(ctx); err != nil {
+			l.Warn("failed to stop A2A server", zap.String("persona", agent.Persona.Name), zap.Error(err))
+		}
+	}
 	if artifactsServer != nil {
 		artifactsServer.Stop(ctx)
 	}
+	if adminServer != nil {
+		if err := adminServer.Stop(ctx); err != nil {
+			l.Warn("failed to stop mock control API server", zap.Error(err))
+		}
+	}
+	if openaiServer != nil {
+		if err := openaiServer.Stop(ctx); err != nil {
+			l.Warn("failed to stop OpenAI-compatible API server", zap.Error(err))
+		}
+	}
 	l.Info("mock-agent agent stopped")
 }
@@ -0,0 +1,480 @@
+// Package mocktest runs the mock agent inside a Go test. The agent is the
+// one the binary serves, on an httptest listener, configured in code
+// instead of the environment:
+//
+//	agent := mocktest.New(t).
+//		WithSkills("echo", "long_running").
+//		WithScenarios(mocktest.Scenario{...}).
+//		WithChaos(mocktest.ChaosRateLimit, 0.2).
+//		WithChaosMethods("tasks/get").
+//		Start()
+//
+//	client := agent.Client()
+//	...
+//	task := agent.WaitForTaskState(taskID, types.TaskStateCompleted)
+//	calls := agent.Journal(mocktest.JournalFilter{TaskID: taskID, Kind: mocktest.KindTool})
+//
+// The agent stops when the test ends.
+package mocktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	envconfig "github.com/sethvargo/go-envconfig"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/mock-agent/config"
+
+	admin "github.com/inference-gateway/mock-agent/internal/admin"
+	app "github.com/inference-gateway/mock-agent/internal/app"
+	chaos "github.com/inference-gateway/mock-agent/internal/chaos"
+	journal "github.com/inference-gateway/mock-agent/internal/journal"
+	mock "github.com/inference-gateway/mock-agent/internal/mock"
+	persona "github.com/inference-gateway/mock-agent/internal/persona"
+	push "github.com/inference-gateway/mock-agent/internal/push"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+)
+
+// Types of the mock agent that tests configure it with or read from it
+type (
+	Scenario           = mock.Scenario
+	ScenarioMatch      = mock.ScenarioMatch
+	Turn               = mock.ScenarioTurn
+	ToolCall           = mock.ScenarioToolCall
+	Faults             = mock.FaultConfig
+	Chaos              = chaos.Config
+	JournalEntry       = journal.Entry
+	JournalFilter      = journal.Filter
+	Notification       = push.Notification
+	NotificationFilter = push.Filter
+)
+
+// LLM faults for WithFault
+const (
+	FaultError         = mock.FaultError
+	FaultHang          = mock.FaultHang
+	FaultDropStream    = mock.FaultDropStream
+	FaultNoFinish      = mock.FaultNoFinish
+	FaultMalformedArgs = mock.FaultMalformedArgs
+	FaultRateLimit     = mock.FaultRateLimit
+)
+
+// Wire faults for WithChaos, also selected per request with ChaosHeader
+const (
+	ChaosLatency    = chaos.FaultLatency
+	ChaosHTTPError  = chaos.FaultHTTPError
+	ChaosRateLimit  = chaos.FaultRateLimit
+	ChaosReset      = chaos.FaultReset
+	ChaosTruncate   = chaos.FaultTruncate
+	ChaosStall      = chaos.FaultStall
+	ChaosDropStream = chaos.FaultDropStream
+	ChaosNone       = chaos.FaultNone
+	ChaosHeader     = chaos.Header
+)
+
+// Kinds of journal entries
+const (
+	KindLLM  = journal.KindLLM
+	KindTool = journal.KindTool
+)
+
+const (
+	agentName        = "mock-agent"
+	agentDescription = "A2A agent server for mocking and testing, embedded in a Go test"
+	agentVersion     = "test"
+
+	// pollInterval is how often WaitForTaskState looks at the task
+	pollInterval = 20 * time.Millisecond
+)
+
+// Builder configures a mock agent before it starts
+type Builder struct {
+	t         testing.TB
+	env       map[string]string
+	configure []func(cfg *config.Config)
+	skills    []string
+	scenarios []Scenario
+	responses []Turn
+	logger    *zap.Logger
+	timeout   time.Duration
+}
+
+// New starts configuring a mock agent for t. Settings not given default
+// like those of the binary, except that the push notification sink is on.
+func New(t testing.TB) *Builder {
+	return &Builder{
+		t:       t,
+		env:     map[string]string{"MOCK_PUSH_SINK_ENABLE": "true"},
+		logger:  zap.NewNop(),
+		timeout: 10 * time.Second,
+	}
+}
+
+// WithEnv sets a setting the way its environment variable does, e.g.
+// WithEnv("MOCK_STREAM_CHUNKING", "word")
+func (b *Builder) WithEnv(key, value string) *Builder {
+	b.env[key] = value
+	return b
+}
+
+// Configure changes the configuration once the environment is applied
+func (b *Builder) Configure(fn func(cfg *config.Config)) *Builder {
+	b.configure = append(b.configure, fn)
+	return b
+}
+
+// WithSkills limits the agent to these skills
+func (b *Builder) WithSkills(names ...string) *Builder {
+	b.skills = append(b.skills, names...)
+	return b
+}
+
+// WithScenarios scripts the mock LLM client. The scenarios survive Reset.
+func (b *Builder) WithScenarios(scenarios ...Scenario) *Builder {
+	b.scenarios = append(b.scenarios, scenarios...)
+	return b
+}
+
+// WithResponses queues responses for the first LLM requests
+func (b *Builder) WithResponses(turns ...Turn) *Builder {
+	b.responses = append(b.responses, turns...)
+	return b
+}
+
+// WithFault injects an LLM fault (Fault*) into requests with the
+// probability rate
+func (b *Builder) WithFault(fault string, rate float64) *Builder {
+	return b.Configure(func(cfg *config.Config) {
+		cfg.Mock.FaultRates = withRate(cfg.Mock.FaultRates, fault, rate)
+	})
+}
+
+// WithChaos injects a wire fault (Chaos*) into A2A requests with the
+// probability rate
+func (b *Builder) WithChaos(fault string, rate float64) *Builder {
+	return b.Configure(func(cfg *config.Config) {
+		cfg.Mock.Chaos.Rates = withRate(cfg.Mock.Chaos.Rates, fault, rate)
+	})
+}
+
+// WithChaosMethods limits the wire faults to these JSON-RPC methods
+func (b *Builder) WithChaosMethods(methods ...string) *Builder {
+	return b.Configure(func(cfg *config.Config) {
+		cfg.Mock.Chaos.Methods = append(cfg.Mock.Chaos.Methods, methods...)
+	})
+}
+
+// WithSeed makes IDs, random data and timestamps reproducible. The task IDs
+// of the A2A server come from a process-wide generator, so tests comparing
+// them should not run agents in parallel.
+func (b *Builder) WithSeed(seed int64) *Builder {
+	return b.Configure(func(cfg *config.Config) {
+		cfg.Mock.Seed = seed
+	})
+}
+
+// WithLogger logs the agent to l instead of nowhere
+func (b *Builder) WithLogger(l *zap.Logger) *Builder {
+	b.logger = l
+	return b
+}
+
+// WithWaitTimeout bounds how long the agent takes to start and
+// WaitForTaskState waits (default 10s)
+func (b *Builder) WithWaitTimeout(d time.Duration) *Builder {
+	b.timeout = d
+	return b
+}
+
+func withRate(rates map[string]float64, fault string, rate float64) map[string]float64 {
+	if rates == nil {
+		rates = make(map[string]float64)
+	}
+	rates[fault] = rate
+	return rates
+}
+
+// Start starts the agent, failing the test when it cannot
+func (b *Builder) Start() *Agent {
+	b.t.Helper()
+
+	agent, err := b.start()
+	if err != nil {
+		b.t.Fatalf("mocktest: failed to start the mock agent: %v", err)
+	}
+	b.t.Cleanup(agent.Close)
+	return agent
+}
+
+func (b *Builder) start() (*Agent, error) {
+	var cfg config.Config
+	if err := envconfig.ProcessWith(context.Background(), &envconfig.Config{
+		Target:   &cfg,
+		Lookuper: envconfig.MapLookuper(b.env),
+	}); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// The agent is served on a test listener, and its card points there
+	server := httptest.NewUnstartedServer(nil)
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	cfg.A2A.AgentURL = "http://" + server.Listener.Addr().String()
+	cfg.A2A.ServerConfig.Port = port
+	cfg.A2A.ServerConfig.TLSConfig.Enable = false
+
+	for _, fn := range b.configure {
+		fn(&cfg)
+	}
+
+	if len(b.scenarios) > 0 {
+		path, err := b.writeScenarios()
+		if err != nil {
+			server.Close()
+			return nil, err
+		}
+		cfg.Mock.ScenariosPath = path
+	}
+
+	source := rng.NewFromConfig(&cfg.Mock)
+	if source.Seeded() {
+		source.Fork("a2a").SeedUUIDs()
+	}
+	sh, err := app.NewShared(&cfg, nil, source, b.logger)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	agent, err := app.NewAgent(sh, persona.Persona{
+		Name:          agentName,
+		Description:   agentDescription,
+		Version:       agentVersion,
+		Port:          port,
+		Skills:        b.skills,
+		ScenariosPath: cfg.Mock.ScenariosPath,
+		CardPath:      cfg.A2A.AgentCardFilePath,
+	}, source, b.logger)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	if err := agent.MockClient.QueueResponses(b.responses...); err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	var sink *push.Sink
+	if cfg.Mock.Push.Sink.Enable {
+		sink = push.NewSink(cfg.Mock.Push.Sink.MaxEntries)
+	}
+	control := admin.NewServer(&cfg.Mock.Admin, agent.MockClient, agent.Injector, sh.Journal, sink, b.logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &Agent{
+		t:       b.t,
+		agent:   agent,
+		shared:  sh,
+		journal: sh.Journal,
+		sink:    sink,
+		server:  server,
+		admin:   httptest.NewServer(control.Handler()),
+		cancel:  cancel,
+		timeout: b.timeout,
+		seeded:  source.Seeded(),
+	}
+	// The gateway holds requests back until the ADK server accepts them
+	go func() {
+		if err := agent.Upstream.Start(ctx); err != nil {
+			b.logger.Error("A2A server failed", zap.Error(err))
+		}
+	}()
+
+	server.Config.Handler = agent.Gateway
+	server.Start()
+	return a, nil
+}
+
+// writeScenarios stores the scenarios in a file the agent loads them from,
+// so they are part of what Reset restores
+func (b *Builder) writeScenarios() (string, error) {
+	data, err := json.Marshal(mock.ScenarioSet{Scenarios: b.scenarios})
+	if err != nil {
+		return "", fmt.Errorf("invalid scenarios: %w", err)
+	}
+	path := filepath.Join(b.t.TempDir(), "scenarios.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Agent is a running mock agent
+type Agent struct {
+	t       testing.TB
+	agent   *app.Agent
+	shared  *app.Shared
+	journal *journal.Journal
+	sink    *push.Sink
+	server  *httptest.Server
+	admin   *httptest.Server
+	cancel  context.CancelFunc
+	timeout time.Duration
+	// seeded is set when the agent took over uuid.New, which Close gives back
+	seeded bool
+}
+
+// URL is the base URL of the agent; JSON-RPC requests go to URL()+"/a2a"
+func (a *Agent) URL() string {
+	return a.server.URL
+}
+
+// AdminURL is the base URL of the control API of the agent
+func (a *Agent) AdminURL() string {
+	return a.admin.URL
+}
+
+// WebhookURL is a push notification URL the agent's sink records, e.g.
+// WebhookURL("/orders") or WebhookURL("/flaky?status=503")
+func (a *Agent) WebhookURL(path string) string {
+	return a.admin.URL + "/webhooks" + path
+}
+
+// Client returns an A2A client of the agent
+func (a *Agent) Client() client.A2AClient {
+	return client.NewClient(a.URL())
+}
+
+// SetScenarios replaces the scenarios of the mock LLM client until Reset
+func (a *Agent) SetScenarios(scenarios ...Scenario) {
+	a.t.Helper()
+
+	data, err := json.Marshal(mock.ScenarioSet{Scenarios: scenarios})
+	if err == nil {
+		var set *mock.ScenarioSet
+		if set, err = mock.ParseScenarios(data); err == nil {
+			a.agent.MockClient.SetScenarios(set)
+			return
+		}
+	}
+	a.t.Fatalf("mocktest: invalid scenarios: %v", err)
+}
+
+// QueueResponses makes the next LLM requests answer with turns, in order
+func (a *Agent) QueueResponses(turns ...Turn) {
+	a.t.Helper()
+	if err := a.agent.MockClient.QueueResponses(turns...); err != nil {
+		a.t.Fatalf("mocktest: invalid responses: %v", err)
+	}
+}
+
+// SetFaults replaces the LLM faults until Reset
+func (a *Agent) SetFaults(faults Faults) {
+	a.t.Helper()
+	if err := a.agent.MockClient.SetFaults(faults); err != nil {
+		a.t.Fatalf("mocktest: invalid faults: %v", err)
+	}
+}
+
+// SetChaos replaces the wire faults until Reset. Start from Chaos() to
+// keep the settings not changed.
+func (a *Agent) SetChaos(c Chaos) {
+	a.t.Helper()
+	if err := a.agent.Injector.Set(c); err != nil {
+		a.t.Fatalf("mocktest: invalid chaos: %v", err)
+	}
+}
+
+// Chaos returns the current wire faults
+func (a *Agent) Chaos() Chaos {
+	return a.agent.Injector.Config()
+}
+
+// Reset restores the configuration the agent started with, drops queued
+// responses and clears the journal and the recorded notifications
+func (a *Agent) Reset() {
+	a.agent.MockClient.Reset()
+	a.agent.Injector.Reset()
+	if a.journal != nil {
+		a.journal.Reset()
+	}
+	if a.sink != nil {
+		a.sink.Reset()
+	}
+}
+
+// Journal returns the recorded LLM calls and skill invocations matching filter
+func (a *Agent) Journal(filter JournalFilter) []JournalEntry {
+	a.t.Helper()
+	if a.journal == nil {
+		a.t.Fatal("mocktest: the journal is disabled")
+	}
+	return a.journal.Entries(filter)
+}
+
+// Notifications returns the push notifications posted to WebhookURL
+// matching filter
+func (a *Agent) Notifications(filter NotificationFilter) []Notification {
+	a.t.Helper()
+	if a.sink == nil {
+		a.t.Fatal("mocktest: the push notification sink is disabled")
+	}
+	return a.sink.Notifications(filter)
+}
+
+// Task gets a task in the state of its outcome, without wire faults
+func (a *Agent) Task(taskID string) (*types.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+	return a.agent.Task(ctx, taskID)
+}
+
+// WaitForTaskState waits until a task is in one of states and returns it,
+// failing the test when it does not get there in time
+func (a *Agent) WaitForTaskState(taskID string, states ...types.TaskState) *types.Task {
+	a.t.Helper()
+
+	deadline := time.Now().Add(a.timeout)
+	var last string
+	for {
+		task, err := a.Task(taskID)
+		if err == nil && slices.Contains(states, task.Status.State) {
+			return task
+		}
+		if err != nil {
+			last = err.Error()
+		} else {
+			last = "state " + string(task.Status.State)
+		}
+		if time.Now().After(deadline) {
+			a.t.Fatalf("mocktest: task %q did not reach %v within %s: %s", taskID, states, a.timeout, last)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Close stops the agent. Tests need not call it; it runs when they end.
+func (a *Agent) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Stopping the gateway first ends stalled responses
+	a.shared.Close()
+	_ = a.agent.Gateway.Stop(ctx)
+	a.server.Close()
+	a.admin.Close()
+	_ = a.agent.Upstream.Stop(ctx)
+	a.cancel()
+	if a.seeded {
+		rng.ResetUUIDs()
+	}
+}
@@ -0,0 +1,127 @@
+package mocktest_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	types "github.com/inference-gateway/adk/types"
+
+	"github.com/inference-gateway/mock-agent/mocktest"
+)
+
+// send sends text to the agent and returns the task it answered with
+func send(t *testing.T, agent *mocktest.Agent, text string) *types.Task {
+	t.Helper()
+
+	resp, err := agent.Client().SendTask(context.Background(), types.MessageSendParams{
+		Message: types.Message{
+			Kind:      "message",
+			MessageID: "msg-" + strings.ReplaceAll(text, " ", "-"),
+			Role:      "user",
+			Parts:     []types.Part{types.TextPart{Kind: "text", Text: text}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendTask() error = %v", err)
+	}
+
+	data, err := json.Marshal(resp.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var task types.Task
+	if err := json.Unmarshal(data, &task); err != nil || task.ID == "" {
+		t.Fatalf("SendTask() = %s, want a task", data)
+	}
+	return &task
+}
+
+// text returns the text of a task's status message
+func text(task *types.Task) string {
+	if task.Status.Message == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, part := range task.Status.Message.Parts {
+		data, _ := json.Marshal(part)
+		var p struct {
+			Text string `json:"text"`
+		}
+		_ = json.Unmarshal(data, &p)
+		b.WriteString(p.Text)
+	}
+	return b.String()
+}
+
+func TestSkillCall(t *testing.T) {
+	agent := mocktest.New(t).WithSkills("echo").Start()
+
+	task := send(t, agent, "echo hello")
+	task = agent.WaitForTaskState(task.ID, types.TaskStateCompleted)
+
+	calls := agent.Journal(mocktest.JournalFilter{TaskID: task.ID, Kind: mocktest.KindTool})
+	if len(calls) != 1 || calls[0].Tool != "echo" {
+		t.Fatalf("tool calls = %+v, want one echo call", calls)
+	}
+	if llm := agent.Journal(mocktest.JournalFilter{TaskID: task.ID, Kind: mocktest.KindLLM}); len(llm) != 2 {
+		t.Errorf("LLM calls = %d, want 2", len(llm))
+	}
+}
+
+func TestScenarioWithChaos(t *testing.T) {
+	agent := mocktest.New(t).
+		WithSkills("echo").
+		WithScenarios(mocktest.Scenario{
+			Name:  "greet",
+			Match: mocktest.ScenarioMatch{UserMessage: "(?i)hello"},
+			Turns: []mocktest.Turn{{Content: "Hello from the mock"}},
+		}).
+		WithChaos(mocktest.ChaosRateLimit, 1).
+		WithChaosMethods("tasks/get").
+		Start()
+
+	task := send(t, agent, "hello there")
+	task = agent.WaitForTaskState(task.ID, types.TaskStateCompleted)
+	if got := text(task); got != "Hello from the mock" {
+		t.Errorf("status message = %q, want the scenario turn", got)
+	}
+
+	if _, err := agent.Client().GetTask(context.Background(), types.TaskQueryParams{ID: task.ID}); err == nil {
+		t.Error("GetTask() succeeded, want the rate limit of the wire faults")
+	}
+
+	calm := agent.Chaos()
+	calm.Rates = nil
+	agent.SetChaos(calm)
+	if _, err := agent.Client().GetTask(context.Background(), types.TaskQueryParams{ID: task.ID}); err != nil {
+		t.Errorf("GetTask() without wire faults error = %v", err)
+	}
+
+	agent.Reset()
+	if _, err := agent.Client().GetTask(context.Background(), types.TaskQueryParams{ID: task.ID}); err == nil {
+		t.Error("GetTask() after Reset succeeded, want the wire faults the agent started with")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	run := func() (string, string) {
+		agent := mocktest.New(t).WithSkills("random_data").WithSeed(42).Start()
+		defer agent.Close()
+
+		task := send(t, agent, "generate 2 uuids")
+		task = agent.WaitForTaskState(task.ID, types.TaskStateCompleted)
+		calls := agent.Journal(mocktest.JournalFilter{TaskID: task.ID, Tool: "random_data"})
+		if len(calls) != 1 {
+			t.Fatalf("random_data calls = %d, want 1", len(calls))
+		}
+		return task.ID, calls[0].Result
+	}
+
+	firstID, firstData := run()
+	secondID, secondData := run()
+	if firstID != secondID || firstData != secondData {
+		t.Errorf("seeded runs differ: %s %s and %s %s", firstID, firstData, secondID, secondData)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	server "github.com/inference-gateway/adk/server"
+
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
 )
 
 // DelaySkill struct holds the skill with services
@@ -15,14 +17,26 @@ type DelaySkill struct {
 // NewDelaySkill creates a new delay skill
 func NewDelaySkill() server.Tool {
 	skill := &DelaySkill{}
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"duration_seconds": map[string]any{
+				"type":        "number",
+				"description": "Number of seconds to delay (default 2)",
+				"minimum":     0,
+				"default":     2,
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "Message to return after delay",
+			},
+		},
+	}
 	return server.NewBasicTool(
 		"delay",
 		"Simulate slow responses with configurable delays",
-		map[string]any{
-			"type":       "object",
-			"properties": map[string]any{},
-		},
-		skill.DelayHandler,
+		parameters,
+		schema.Validated("delay", parameters, skill.DelayHandler),
 	)
 }
 
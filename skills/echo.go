@@ -5,23 +5,34 @@ import (
 	"fmt"
 
 	server "github.com/inference-gateway/adk/server"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
 )
 
 // EchoSkill struct holds the skill with services
 type EchoSkill struct {
+	source *rng.Source
 }
 
 // NewEchoSkill creates a new echo skill
-func NewEchoSkill() server.Tool {
-	skill := &EchoSkill{}
+func NewEchoSkill(source *rng.Source) server.Tool {
+	skill := &EchoSkill{source: source}
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{
+				"type":        "string",
+				"description": "The message to echo back",
+			},
+		},
+		"required": []string{"message"},
+	}
 	return server.NewBasicTool(
 		"echo",
 		"Echo back the input message (useful for basic connectivity tests)",
-		map[string]any{
-			"type":       "object",
-			"properties": map[string]any{},
-		},
-		skill.EchoHandler,
+		parameters,
+		schema.Validated("echo", parameters, skill.EchoHandler),
 	)
 }
 
@@ -33,5 +44,5 @@ func (s *EchoSkill) EchoHandler(ctx context.Context, args map[string]any) (strin
 	}
 
 	return fmt.Sprintf(`{"status": "success", "echo": %q, "length": %d, "timestamp": %d}`,
-		message, len(message), ctx.Value("timestamp")), nil
+		message, len(message), s.source.Now().Unix()), nil
 }
@@ -2,12 +2,27 @@ package skills
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	outcome "github.com/inference-gateway/mock-agent/internal/outcome"
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
 )
 
+// errorTypes are the failures the error skill simulates: errors the tool
+// returns, outcomes of the task, and failures at the wire
+var errorTypes = []string{
+	"validation", "timeout", "internal", "not_found",
+	"task_failed", "rejected", "auth_required",
+	"jsonrpc", "http", "panic", "partial",
+}
+
 // ErrorSkill struct holds the skill with services
 type ErrorSkill struct {
 }
@@ -15,57 +30,164 @@ type ErrorSkill struct {
 // NewErrorSkill creates a new error skill
 func NewErrorSkill() server.Tool {
 	skill := &ErrorSkill{}
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"error_type": map[string]any{
+				"type":        "string",
+				"description": "Type of error to simulate: a tool error (validation, timeout, internal, not_found), a task that ends failed, rejected or auth_required, a JSON-RPC error (jsonrpc), an HTTP status (http), a panic of the skill, or partial results followed by a failure (partial)",
+				"enum":        errorTypes,
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "Custom error message",
+			},
+			"code": map[string]any{
+				"type":        "integer",
+				"description": "JSON-RPC error code for jsonrpc, e.g. -32001 (task not found) or -32004 (unsupported operation); default -32603",
+				"minimum":     -32768,
+				"maximum":     -32000,
+				"default":     -32603,
+			},
+			"status_code": map[string]any{
+				"type":        "integer",
+				"description": "HTTP status for http, e.g. 401, 429 or 503 (default 503)",
+				"minimum":     400,
+				"maximum":     599,
+				"default":     http.StatusServiceUnavailable,
+			},
+			"retry_after_seconds": map[string]any{
+				"type":        "integer",
+				"description": "Retry-After sent with the HTTP status (default 0, none)",
+				"minimum":     0,
+				"default":     0,
+			},
+			"times": map[string]any{
+				"type":        "integer",
+				"description": "Number of tasks/get polls answered with the jsonrpc or http error before the failed task is returned (default 0, all)",
+				"minimum":     0,
+				"default":     0,
+			},
+			"partial_results": map[string]any{
+				"type":        "integer",
+				"description": "Number of results delivered before the failure for partial (default 3)",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     3,
+			},
+		},
+		"required": []string{"error_type"},
+	}
 	return server.NewBasicTool(
 		"error",
 		"Simulate error conditions for testing error handling",
-		map[string]any{
-			"type":       "object",
-			"properties": map[string]any{},
-		},
-		skill.ErrorHandler,
+		parameters,
+		schema.Validated("error", parameters, skill.ErrorHandler),
 	)
 }
 
 // ErrorHandler handles the error skill execution
 func (s *ErrorSkill) ErrorHandler(ctx context.Context, args map[string]any) (string, error) {
-	errorType, ok := args["error_type"].(string)
-	if !ok {
-		return "", fmt.Errorf("error_type parameter is required and must be a string")
+	errorType, _ := args["error_type"].(string)
+	customMessage, _ := args["message"].(string)
+	message := func(fallback string) string {
+		if customMessage != "" {
+			return customMessage
+		}
+		return fallback
 	}
-
-	customMessage := ""
-	if val, ok := args["message"]; ok {
-		if msg, ok := val.(string); ok {
-			customMessage = msg
+	intArg := func(name string, fallback int) int {
+		if val, ok := args[name].(float64); ok {
+			return int(val)
 		}
+		return fallback
 	}
 
 	switch errorType {
 	case "validation":
-		if customMessage == "" {
-			customMessage = "Validation failed: invalid input format"
-		}
-		return "", fmt.Errorf("validation error: %s", customMessage)
+		return "", toolresult.NewError("validation", message("Validation failed: invalid input format"), false)
 
 	case "timeout":
-		if customMessage == "" {
-			customMessage = "Operation timed out after 30 seconds"
-		}
-		return "", fmt.Errorf("timeout error: %s", customMessage)
+		return "", toolresult.NewError("timeout", message("Operation timed out after 30 seconds"), true)
 
 	case "internal":
-		if customMessage == "" {
-			customMessage = "Internal server error occurred"
-		}
-		return "", fmt.Errorf("internal error: %s", customMessage)
+		return "", toolresult.NewError("internal", message("Internal server error occurred"), true)
 
 	case "not_found":
-		if customMessage == "" {
-			customMessage = "Resource not found"
+		return "", toolresult.NewError("not_found", message("Resource not found"), false)
+
+	case "task_failed":
+		return endTask(ctx, errorType, outcome.Outcome{
+			State:   types.TaskStateFailed,
+			Message: message("Task failed"),
+		})
+
+	case "rejected":
+		return endTask(ctx, errorType, outcome.Outcome{
+			State:   types.TaskStateRejected,
+			Message: message("Task rejected: the agent does not perform this request"),
+		})
+
+	case "auth_required":
+		return endTask(ctx, errorType, outcome.Outcome{
+			State:   types.TaskStateAuthRequired,
+			Message: message("Authentication required: provide credentials to continue"),
+		})
+
+	case "jsonrpc":
+		code := intArg("code", -32603)
+		fallback, ok := outcome.CodeMessages[code]
+		if !ok {
+			fallback = "Server error"
 		}
-		return "", fmt.Errorf("not found error: %s", customMessage)
+		return endTask(ctx, errorType, outcome.Outcome{
+			State:   types.TaskStateFailed,
+			Message: message(fallback),
+			Code:    code,
+			Times:   intArg("times", 0),
+		})
+
+	case "http":
+		status := intArg("status_code", http.StatusServiceUnavailable)
+		return endTask(ctx, errorType, outcome.Outcome{
+			State:      types.TaskStateFailed,
+			Message:    message(fmt.Sprintf("%d %s", status, http.StatusText(status))),
+			HTTPStatus: status,
+			RetryAfter: time.Duration(intArg("retry_after_seconds", 0)) * time.Second,
+			Times:      intArg("times", 0),
+		})
+
+	case "panic":
+		panic(message("simulated panic in the error skill"))
+
+	case "partial":
+		total := intArg("partial_results", 3)
+		results := make([]any, 0, total)
+		for i := 1; i <= total; i++ {
+			result := map[string]any{"index": i, "value": fmt.Sprintf("partial result %d", i)}
+			results = append(results, result)
+			progress.Report(ctx, progress.Update{
+				Step:       i,
+				TotalSteps: total + 1,
+				Percent:    i * 100 / (total + 1),
+				Message:    fmt.Sprintf("Produced partial result %d", i),
+				Data:       result,
+			})
+		}
+
+		failure := message(fmt.Sprintf("Failed after producing %d partial results", total))
+		outcome.Set(ctx, outcome.Outcome{State: types.TaskStateFailed, Message: failure})
+		err := toolresult.NewError(errorType, failure, false)
+		err.Details = map[string]any{"partial_results": results}
+		return "", err
 
 	default:
-		return "", errors.New("unknown error_type: must be one of (validation, timeout, internal, not_found)")
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("unknown error_type %q", errorType), false)
 	}
 }
+
+// endTask ends the running task with o and fails the tool call with the same message
+func endTask(ctx context.Context, code string, o outcome.Outcome) (string, error) {
+	outcome.Set(ctx, o)
+	return "", toolresult.NewError(code, o.Message, false)
+}
@@ -0,0 +1,252 @@
+package skills
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	faker "github.com/inference-gateway/mock-agent/internal/faker"
+	filegen "github.com/inference-gateway/mock-agent/internal/filegen"
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+const (
+	// maxArtifactCount bounds how many artifacts one call creates
+	maxArtifactCount = 100
+	// maxArtifactBytes bounds the total size of the artifacts of one call
+	maxArtifactBytes = filegen.MaxSize
+	// maxArtifactChunks bounds how many chunks one artifact is streamed in
+	maxArtifactChunks = 10000
+	// maxArtifactChunkSize bounds the data of one streamed chunk
+	maxArtifactChunkSize = 16 << 20
+)
+
+// GenerateArtifactSkill struct holds the skill with services
+type GenerateArtifactSkill struct {
+	source *rng.Source
+}
+
+// NewGenerateArtifactSkill creates a new generate_artifact skill
+func NewGenerateArtifactSkill(source *rng.Source) server.Tool {
+	skill := &GenerateArtifactSkill{source: source}
+	formats := filegen.Formats()
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"format": map[string]any{
+				"type":        "string",
+				"description": fmt.Sprintf("File format of the artifacts (%s; default json)", strings.Join(formats, ", ")),
+				"enum":        formats,
+				"default":     "json",
+			},
+			"size_bytes": map[string]any{
+				"type":        "integer",
+				"description": "Exact size of each artifact in bytes (default 1024)",
+				"minimum":     1,
+				"maximum":     filegen.MaxSize,
+				"default":     1024,
+			},
+			"count": map[string]any{
+				"type":        "integer",
+				"description": "Number of artifacts to create (default 1)",
+				"minimum":     1,
+				"maximum":     maxArtifactCount,
+				"default":     1,
+			},
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Base name of the artifact files, without extension (default artifact)",
+			},
+			"chunk_size_bytes": map[string]any{
+				"type":        "integer",
+				"description": "Also stream each artifact to the client in artifact-update chunks of this many bytes (default 0, not streamed)",
+				"minimum":     0,
+				"maximum":     maxArtifactChunkSize,
+				"default":     0,
+			},
+			"seed": map[string]any{
+				"type":        "integer",
+				"description": "Non-zero seed that makes this call generate the same content every time",
+			},
+		},
+	}
+	return server.NewBasicTool(
+		"generate_artifact",
+		"Generate artifacts of a given file type and size",
+		parameters,
+		schema.Validated("generate_artifact", parameters, skill.GenerateArtifactHandler),
+	)
+}
+
+// GenerateArtifactHandler handles the generate_artifact skill execution
+func (s *GenerateArtifactSkill) GenerateArtifactHandler(ctx context.Context, args map[string]any) (string, error) {
+	artifactService, ok := ctx.Value(server.ArtifactServiceContextKey).(server.ArtifactService)
+	if !ok || artifactService == nil {
+		return "", toolresult.NewError("artifacts_disabled", "artifact storage is not configured, set A2A_ARTIFACTS_ENABLE=true", false)
+	}
+	task, _ := ctx.Value(server.TaskContextKey).(*types.Task)
+
+	formatName := "json"
+	if val, ok := args["format"].(string); ok && val != "" {
+		formatName = val
+	}
+	format, ok := filegen.Lookup(formatName)
+	if !ok {
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("unknown format %q", formatName), false)
+	}
+
+	size := 1024
+	if val, ok := args["size_bytes"].(float64); ok {
+		size = int(val)
+	}
+	if size < format.MinSize {
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("%s artifacts are at least %d bytes", format.Name, format.MinSize), false)
+	}
+
+	count := 1
+	if val, ok := args["count"].(float64); ok {
+		count = int(val)
+	}
+	if size*count > maxArtifactBytes {
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("size_bytes × count must be at most %d bytes per call", maxArtifactBytes), false)
+	}
+
+	name := "artifact"
+	if val, ok := args["name"].(string); ok && val != "" {
+		name = strings.TrimSuffix(val, format.Extension)
+	}
+
+	chunkSize := 0
+	if val, ok := args["chunk_size_bytes"].(float64); ok {
+		chunkSize = int(val)
+	}
+	if chunkSize > 0 && (size+chunkSize-1)/chunkSize > maxArtifactChunks {
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("chunk_size_bytes must be at least %d to stream %d bytes in at most %d chunks", (size+maxArtifactChunks-1)/maxArtifactChunks, size, maxArtifactChunks), false)
+	}
+
+	source := s.source
+	seed, _ := args["seed"].(float64)
+	if seed != 0 {
+		source = rng.New(int64(seed), nil)
+	}
+
+	artifacts := make([]map[string]any, 0, count)
+	for i := 1; i <= count; i++ {
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("generate_artifact canceled after %d of %d artifacts: %w", i-1, count, err)
+		}
+
+		data, err := format.Generate(source, faker.DefaultLocale, size)
+		if err != nil {
+			return "", toolresult.NewError("generation_failed", err.Error(), false)
+		}
+
+		filename := name + format.Extension
+		if count > 1 {
+			filename = fmt.Sprintf("%s-%d%s", name, i, format.Extension)
+		}
+		mimeType := format.MIMEType
+		description := fmt.Sprintf("Generated %s file of %d bytes", format.Name, size)
+		artifact, err := artifactService.CreateFileArtifact(filename, description, filename, data, &mimeType)
+		if err != nil {
+			return "", toolresult.NewError("storage_failed", err.Error(), true)
+		}
+		if task != nil {
+			artifactService.AddArtifactToTask(task, artifact)
+		}
+
+		digest := sha256.Sum256(data)
+		summary := map[string]any{
+			"artifact_id": artifact.ArtifactID,
+			"filename":    filename,
+			"mime_type":   mimeType,
+			"size_bytes":  size,
+			"sha256":      hex.EncodeToString(digest[:]),
+		}
+		if file, ok := artifact.Parts[0].(types.FilePart); ok {
+			if uri, ok := file.File.(types.FileWithUri); ok {
+				summary["url"] = uri.URI
+			}
+		}
+		if chunkSize > 0 {
+			chunks, err := streamArtifact(ctx, artifact, format, filename, data, chunkSize)
+			if err != nil {
+				return "", fmt.Errorf("generate_artifact canceled while streaming %s: %w", filename, err)
+			}
+			summary["chunks"] = chunks
+		}
+		artifacts = append(artifacts, summary)
+	}
+
+	response := map[string]any{
+		"status":     "success",
+		"format":     format.Name,
+		"mime_type":  format.MIMEType,
+		"size_bytes": size,
+		"count":      count,
+		"artifacts":  artifacts,
+	}
+	if seed != 0 {
+		response["seed"] = int64(seed)
+	}
+
+	result, err := json.Marshal(response)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return string(result), nil
+}
+
+// streamArtifact delivers the content of a stored artifact to the client in
+// chunks of about chunkSize bytes: text formats as text parts cut between
+// characters, the others as base64 file parts. It returns how many chunks
+// were sent.
+func streamArtifact(ctx context.Context, artifact types.Artifact, format *filegen.Format, filename string, data []byte, chunkSize int) (int, error) {
+	chunks := 0
+	for offset := 0; offset < len(data); chunks++ {
+		if err := ctx.Err(); err != nil {
+			return chunks, err
+		}
+
+		end := min(offset+chunkSize, len(data))
+		var part types.Part
+		if format.Text {
+			for end < len(data) && !utf8.RuneStart(data[end]) {
+				end++
+			}
+			part = types.NewTextPart(string(data[offset:end]))
+		} else {
+			part = types.FilePart{
+				Kind: "file",
+				File: types.FileWithBytes{
+					Bytes:    base64.StdEncoding.EncodeToString(data[offset:end]),
+					MIMEType: &format.MIMEType,
+					Name:     &filename,
+				},
+			}
+		}
+
+		progress.SendChunk(ctx, progress.Chunk{
+			Artifact: types.Artifact{
+				ArtifactID: artifact.ArtifactID,
+				Name:       artifact.Name,
+				Parts:      []types.Part{part},
+			},
+			Append:    offset > 0,
+			LastChunk: end == len(data),
+		})
+		offset = end
+	}
+	return chunks, nil
+}
@@ -0,0 +1,90 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// artifactStore keeps the files the skill creates. The methods the skill
+// does not call are left to the nil embedded interface.
+type artifactStore struct {
+	server.ArtifactService
+	files map[string][]byte
+}
+
+func (s *artifactStore) CreateFileArtifact(name, description, filename string, data []byte, mimeType *string) (types.Artifact, error) {
+	s.files[filename] = data
+	return types.Artifact{ArtifactID: "artifact-" + filename, Name: &name, Parts: []types.Part{types.FilePart{Kind: "file", File: types.FileWithUri{URI: "http://localhost/" + filename}}}}, nil
+}
+
+func (s *artifactStore) AddArtifactToTask(task *types.Task, artifact types.Artifact) {}
+
+func TestGenerateArtifact(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantFiles map[string]int
+		wantCode  string
+	}{
+		{"defaults", map[string]any{}, map[string]int{"artifact.json": 1024}, ""},
+		{"several files", map[string]any{"format": "csv", "size_bytes": 2048.0, "count": 3.0, "name": "report"}, map[string]int{"report-1.csv": 2048, "report-2.csv": 2048, "report-3.csv": 2048}, ""},
+		{"over the total size", map[string]any{"format": "binary", "size_bytes": float64(8 << 20), "count": 100.0}, nil, "invalid_arguments"},
+		{"below the format minimum", map[string]any{"format": "pdf", "size_bytes": 10.0}, nil, "invalid_arguments"},
+		{"too many chunks", map[string]any{"size_bytes": 100000.0, "chunk_size_bytes": 1.0}, nil, "invalid_arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &artifactStore{files: map[string][]byte{}}
+			ctx := context.WithValue(context.Background(), server.ArtifactServiceContextKey, server.ArtifactService(store))
+			skill := &GenerateArtifactSkill{source: rng.New(1, nil)}
+
+			result, err := skill.GenerateArtifactHandler(ctx, tt.args)
+			if tt.wantCode != "" {
+				var failure *toolresult.Error
+				if !errors.As(err, &failure) || failure.Code != tt.wantCode {
+					t.Fatalf("GenerateArtifactHandler() = %s, %v, want a %s error", result, err, tt.wantCode)
+				}
+				if len(store.files) > 0 {
+					t.Errorf("stored %d files before failing", len(store.files))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateArtifactHandler() error = %v", err)
+			}
+
+			var response struct {
+				Status    string `json:"status"`
+				Artifacts []struct {
+					Filename string `json:"filename"`
+					URL      string `json:"url"`
+				} `json:"artifacts"`
+			}
+			if err := json.Unmarshal([]byte(result), &response); err != nil {
+				t.Fatalf("GenerateArtifactHandler() = %s, not JSON: %v", result, err)
+			}
+			if response.Status != "success" || len(response.Artifacts) != len(tt.wantFiles) {
+				t.Errorf("GenerateArtifactHandler() = %s", result)
+			}
+			for filename, size := range tt.wantFiles {
+				if got := len(store.files[filename]); got != size {
+					t.Errorf("%s has %d bytes, want %d", filename, got, size)
+				}
+			}
+		})
+	}
+
+	skill := &GenerateArtifactSkill{source: rng.New(1, nil)}
+	if _, err := skill.GenerateArtifactHandler(context.Background(), map[string]any{}); err == nil {
+		t.Error("GenerateArtifactHandler() without artifact storage succeeded")
+	}
+}
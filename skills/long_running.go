@@ -0,0 +1,155 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+// LongRunningSkill struct holds the skill with services
+type LongRunningSkill struct {
+	updateInterval time.Duration
+}
+
+// NewLongRunningSkill creates a new long_running skill. Steps longer than
+// updateInterval also report progress every updateInterval while they run.
+func NewLongRunningSkill(updateInterval time.Duration) server.Tool {
+	skill := &LongRunningSkill{updateInterval: updateInterval}
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"steps": map[string]any{
+				"type":        "integer",
+				"description": "Number of steps of the job (default 5)",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     5,
+			},
+			"step_duration_seconds": map[string]any{
+				"type":        "number",
+				"description": "Duration of each step in seconds (default 1)",
+				"minimum":     0,
+				"maximum":     300,
+				"default":     1,
+			},
+			"fail_at_step": map[string]any{
+				"type":        "integer",
+				"description": "Step at which the job fails (default 0, never)",
+				"minimum":     0,
+				"maximum":     100,
+				"default":     0,
+			},
+			"job_name": map[string]any{
+				"type":        "string",
+				"description": "Name of the job used in progress messages",
+			},
+		},
+	}
+	return server.NewBasicTool(
+		"long_running",
+		"Simulate a multi-stage job that reports progress while it runs",
+		parameters,
+		schema.Validated("long_running", parameters, skill.LongRunningHandler),
+	)
+}
+
+// LongRunningHandler handles the long_running skill execution
+func (s *LongRunningSkill) LongRunningHandler(ctx context.Context, args map[string]any) (string, error) {
+	steps := 5
+	if val, ok := args["steps"].(float64); ok {
+		steps = int(val)
+	}
+
+	stepDuration := time.Second
+	if val, ok := args["step_duration_seconds"].(float64); ok {
+		stepDuration = time.Duration(val * float64(time.Second))
+	}
+
+	failAtStep := 0
+	if val, ok := args["fail_at_step"].(float64); ok {
+		failAtStep = int(val)
+	}
+
+	jobName := "Job"
+	if val, ok := args["job_name"].(string); ok && val != "" {
+		jobName = val
+	}
+
+	startTime := time.Now()
+	updates := 0
+	report := func(step int, percent int, message string) {
+		updates++
+		progress.Report(ctx, progress.Update{
+			Step:       step,
+			TotalSteps: steps,
+			Percent:    percent,
+			Message:    message,
+		})
+	}
+
+	report(0, 0, fmt.Sprintf("%s started: %d steps", jobName, steps))
+
+	for step := 1; step <= steps; step++ {
+		if err := s.runStep(ctx, step, steps, stepDuration, func(percent int) {
+			report(step, percent, fmt.Sprintf("%s: step %d/%d in progress (%d%%)", jobName, step, steps, percent))
+		}); err != nil {
+			return "", err
+		}
+
+		if step == failAtStep {
+			percent := (step - 1) * 100 / steps
+			report(step, percent, fmt.Sprintf("%s: step %d/%d failed", jobName, step, steps))
+			return "", toolresult.NewError("job_failed", fmt.Sprintf("%s failed at step %d of %d", jobName, step, steps), false)
+		}
+
+		percent := step * 100 / steps
+		report(step, percent, fmt.Sprintf("%s: step %d/%d completed (%d%%)", jobName, step, steps, percent))
+	}
+
+	result, err := json.Marshal(map[string]any{
+		"status":           "success",
+		"job_name":         jobName,
+		"steps":            steps,
+		"completed_steps":  steps,
+		"progress_updates": updates,
+		"duration_seconds": time.Since(startTime).Seconds(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// runStep waits out one step, calling heartbeat with the overall percentage
+// every update interval
+func (s *LongRunningSkill) runStep(ctx context.Context, step, steps int, duration time.Duration, heartbeat func(percent int)) error {
+	start := time.Now()
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	var tick <-chan time.Time
+	if s.updateInterval > 0 && s.updateInterval < duration {
+		ticker := time.NewTicker(s.updateInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-tick:
+			done := float64(step-1) + float64(time.Since(start))/float64(duration)
+			heartbeat(min(int(done*100/float64(steps)), step*100/steps))
+		case <-ctx.Done():
+			return fmt.Errorf("long_running canceled at step %d of %d: %w", step, steps, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+)
+
+func TestLongRunning(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		interval    time.Duration
+		wantUpdates []string
+		wantCode    string
+	}{
+		{
+			name:        "completes",
+			args:        map[string]any{"steps": 2.0, "step_duration_seconds": 0.0, "job_name": "Import"},
+			wantUpdates: []string{"Import started: 2 steps", "Import: step 1/2 completed (50%)", "Import: step 2/2 completed (100%)"},
+		},
+		{
+			name:        "fails at a step",
+			args:        map[string]any{"steps": 4.0, "step_duration_seconds": 0.0, "fail_at_step": 2.0},
+			wantUpdates: []string{"Job started: 4 steps", "Job: step 1/4 completed (25%)", "Job: step 2/4 failed"},
+			wantCode:    "job_failed",
+		},
+		{
+			name:        "reports while a step runs",
+			args:        map[string]any{"steps": 1.0, "step_duration_seconds": 0.2},
+			interval:    50 * time.Millisecond,
+			wantUpdates: []string{"Job started: 1 steps", "Job: step 1/1 in progress", "Job: step 1/1 completed (100%)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updates []progress.Update
+			ctx := progress.WithReporter(context.Background(), func(update progress.Update) {
+				updates = append(updates, update)
+			})
+
+			skill := &LongRunningSkill{updateInterval: tt.interval}
+			result, err := skill.LongRunningHandler(ctx, tt.args)
+			if tt.wantCode != "" {
+				var failure *toolresult.Error
+				if !errors.As(err, &failure) || failure.Code != tt.wantCode {
+					t.Fatalf("LongRunningHandler() = %s, %v, want a %s error", result, err, tt.wantCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("LongRunningHandler() error = %v", err)
+				}
+				var response map[string]any
+				if err := json.Unmarshal([]byte(result), &response); err != nil || response["status"] != "success" || response["progress_updates"] != float64(len(updates)) {
+					t.Errorf("LongRunningHandler() = %s, %d updates", result, len(updates))
+				}
+			}
+
+			// Heartbeats depend on timing, so repeats collapse into one
+			var messages []string
+			for i, update := range updates {
+				if i > 0 && update.Percent < updates[i-1].Percent {
+					t.Errorf("progress went back from %d%% to %d%%", updates[i-1].Percent, update.Percent)
+				}
+				message := update.Message
+				if i := strings.Index(message, " in progress"); i >= 0 {
+					message = message[:i+len(" in progress")]
+				}
+				if len(messages) > 0 && messages[len(messages)-1] == message {
+					continue
+				}
+				messages = append(messages, message)
+			}
+			if strings.Join(messages, "|") != strings.Join(tt.wantUpdates, "|") {
+				t.Errorf("updates = %q, want %q", messages, tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestLongRunningCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := (&LongRunningSkill{}).LongRunningHandler(ctx, map[string]any{"steps": 3.0, "step_duration_seconds": 60.0})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("LongRunningHandler() error = %v, want it canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %s", elapsed)
+	}
+}
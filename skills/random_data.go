@@ -2,84 +2,174 @@ package skills
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/json"
 	"fmt"
-	"math/big"
+	"strings"
 
-	"github.com/google/uuid"
 	server "github.com/inference-gateway/adk/server"
+
+	faker "github.com/inference-gateway/mock-agent/internal/faker"
+	progress "github.com/inference-gateway/mock-agent/internal/progress"
+	rng "github.com/inference-gateway/mock-agent/internal/rng"
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
 )
 
+// maxRandomDataCount bounds how many items one call generates
+const maxRandomDataCount = 10000
+
 // RandomDataSkill struct holds the skill with services
 type RandomDataSkill struct {
+	source *rng.Source
 }
 
 // NewRandomDataSkill creates a new random_data skill
-func NewRandomDataSkill() server.Tool {
-	skill := &RandomDataSkill{}
+func NewRandomDataSkill(source *rng.Source) server.Tool {
+	skill := &RandomDataSkill{source: source}
+	kinds := faker.Kinds()
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"data_type": map[string]any{
+				"type":        "string",
+				"description": fmt.Sprintf("Type of data to generate (%s); not needed with schema", strings.Join(kinds, ", ")),
+				"enum":        kinds,
+			},
+			"schema": map[string]any{
+				"type":        "object",
+				"description": "JSON Schema of the records to generate instead of a data_type",
+			},
+			"count": map[string]any{
+				"type":        "integer",
+				"description": "Number of items to generate (default 1)",
+				"minimum":     1,
+				"maximum":     maxRandomDataCount,
+				"default":     1,
+			},
+			"locale": map[string]any{
+				"type":        "string",
+				"description": fmt.Sprintf("Locale of names, addresses and phone numbers (%s; default %s)", strings.Join(faker.Locales(), ", "), faker.DefaultLocale),
+				"enum":        faker.Locales(),
+				"default":     faker.DefaultLocale,
+			},
+			"seed": map[string]any{
+				"type":        "integer",
+				"description": "Non-zero seed that makes this call return the same data every time",
+			},
+			"stream": map[string]any{
+				"type":        "boolean",
+				"description": "Deliver the items in batches as progress updates instead of in the result (default false)",
+				"default":     false,
+			},
+			"batch_size": map[string]any{
+				"type":        "integer",
+				"description": "Number of items per streamed batch (default 100)",
+				"minimum":     1,
+				"maximum":     1000,
+				"default":     100,
+			},
+		},
+	}
 	return server.NewBasicTool(
 		"random_data",
 		"Generate random test data",
-		map[string]any{
-			"type":       "object",
-			"properties": map[string]any{},
-		},
-		skill.RandomDataHandler,
+		parameters,
+		schema.Validated("random_data", parameters, skill.RandomDataHandler),
 	)
 }
 
 // RandomDataHandler handles the random_data skill execution
 func (s *RandomDataSkill) RandomDataHandler(ctx context.Context, args map[string]any) (string, error) {
-	dataType, ok := args["data_type"].(string)
-	if !ok {
-		return "", fmt.Errorf("data_type parameter is required and must be a string")
+	dataType, _ := args["data_type"].(string)
+	recordSchema, _ := args["schema"].(map[string]any)
+	if dataType == "" && recordSchema == nil {
+		return "", toolresult.NewError("invalid_arguments", "either data_type or schema is required", false)
 	}
 
 	count := 1
-	if val, ok := args["count"]; ok {
-		if c, ok := val.(float64); ok {
-			count = int(c)
+	if val, ok := args["count"].(float64); ok {
+		count = int(val)
+	}
+
+	locale := faker.DefaultLocale
+	if val, ok := args["locale"].(string); ok && val != "" {
+		locale = val
+	}
+
+	source := s.source
+	seed, _ := args["seed"].(float64)
+	seeded := seed != 0
+	if seeded {
+		source = rng.New(int64(seed), nil)
+	}
+	f := faker.New(source, locale)
+
+	var next func() (any, error)
+	if recordSchema != nil {
+		dataType = "record"
+		records, err := f.Records(recordSchema)
+		if err != nil {
+			return "", toolresult.NewError("invalid_schema", err.Error(), false)
 		}
+		next = records.Next
+	} else {
+		next = func() (any, error) { return f.Generate(dataType) }
 	}
 
-	if count < 1 || count > 100 {
-		return "", fmt.Errorf("count must be between 1 and 100")
+	stream, _ := args["stream"].(bool)
+	batchSize := 100
+	if val, ok := args["batch_size"].(float64); ok {
+		batchSize = int(val)
 	}
+	batches := (count + batchSize - 1) / batchSize
 
-	var results []string
+	response := map[string]any{
+		"status":    "success",
+		"data_type": dataType,
+		"locale":    locale,
+		"count":     count,
+	}
+	if seeded {
+		response["seed"] = int64(seed)
+	}
+
+	results := make([]any, 0, min(count, batchSize))
 	for i := 0; i < count; i++ {
-		switch dataType {
-		case "uuid":
-			results = append(results, uuid.New().String())
-
-		case "email":
-			results = append(results, fmt.Sprintf("test%d@example.com", i+1))
-
-		case "name":
-			names := []string{"Alice Johnson", "Bob Smith", "Carol Williams", "David Brown", "Eve Davis"}
-			results = append(results, names[i%len(names)])
-
-		case "number":
-			n, _ := rand.Int(rand.Reader, big.NewInt(1000000))
-			results = append(results, fmt.Sprintf("%d", n.Int64()))
-
-		case "json":
-			obj := map[string]any{
-				"id":     i + 1,
-				"uuid":   uuid.New().String(),
-				"name":   fmt.Sprintf("Item %d", i+1),
-				"active": i%2 == 0,
+		if i%batchSize == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", fmt.Errorf("random_data canceled after %d of %d items: %w", i, count, err)
 			}
-			jsonBytes, _ := json.Marshal(obj)
-			results = append(results, string(jsonBytes))
+		}
 
-		default:
-			return "", fmt.Errorf("unknown data_type: must be one of (uuid, email, name, number, json)")
+		item, err := next()
+		if err != nil {
+			return "", toolresult.NewError("invalid_schema", err.Error(), false)
+		}
+		results = append(results, item)
+
+		if stream && (len(results) == batchSize || i == count-1) {
+			batch := (i + batchSize) / batchSize
+			progress.Report(ctx, progress.Update{
+				Step:       batch,
+				TotalSteps: batches,
+				Percent:    (i + 1) * 100 / count,
+				Message:    fmt.Sprintf("Generated %d of %d %s items", i+1, count, dataType),
+				Data:       results,
+			})
+			results = make([]any, 0, batchSize)
 		}
 	}
 
-	resultsJSON, _ := json.Marshal(results)
-	return fmt.Sprintf(`{"status": "success", "data_type": %q, "count": %d, "results": %s}`,
-		dataType, count, string(resultsJSON)), nil
+	if stream {
+		response["streamed"] = true
+		response["batches"] = batches
+	} else {
+		response["results"] = results
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
 }
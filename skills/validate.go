@@ -4,87 +4,143 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
-	"regexp"
+	"strings"
 
-	"github.com/google/uuid"
 	server "github.com/inference-gateway/adk/server"
+
+	schema "github.com/inference-gateway/mock-agent/internal/schema"
+	toolresult "github.com/inference-gateway/mock-agent/internal/toolresult"
+	validation "github.com/inference-gateway/mock-agent/internal/validation"
 )
 
+// maxValidateInputs bounds how many inputs one call validates
+const maxValidateInputs = 1000
+
 // ValidateSkill struct holds the skill with services
 type ValidateSkill struct {
+	registry *validation.Registry
+}
+
+// validateResult is the outcome for one input of a batch
+type validateResult struct {
+	Input  string               `json:"input"`
+	Valid  bool                 `json:"valid"`
+	Errors []validation.Failure `json:"errors"`
 }
 
 // NewValidateSkill creates a new validate skill
-func NewValidateSkill() server.Tool {
-	skill := &ValidateSkill{}
+func NewValidateSkill(registry *validation.Registry) server.Tool {
+	skill := &ValidateSkill{registry: registry}
+	names := registry.Names()
+	parameters := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"input": map[string]any{
+				"type":        "string",
+				"description": "The input to validate",
+			},
+			"inputs": map[string]any{
+				"type":        "array",
+				"description": "Several inputs to validate in one call instead of input",
+				"items":       map[string]any{"type": "string"},
+				"minItems":    1,
+				"maxItems":    maxValidateInputs,
+			},
+			"validation_type": map[string]any{
+				"type":        "string",
+				"description": fmt.Sprintf("Type of validation (%s)", strings.Join(names, ", ")),
+				"enum":        names,
+			},
+			"schema": map[string]any{
+				"type":        "object",
+				"description": "JSON Schema the input must conform to, for json_schema",
+			},
+		},
+		"required": []string{"validation_type"},
+	}
 	return server.NewBasicTool(
 		"validate",
 		"Validate input against common patterns",
-		map[string]any{
-			"type":       "object",
-			"properties": map[string]any{},
-		},
-		skill.ValidateHandler,
+		parameters,
+		schema.Validated("validate", parameters, skill.ValidateHandler),
 	)
 }
 
 // ValidateHandler handles the validate skill execution
 func (s *ValidateSkill) ValidateHandler(ctx context.Context, args map[string]any) (string, error) {
-	input, ok := args["input"].(string)
+	validationType, _ := args["validation_type"].(string)
+	definition, ok := s.registry.Lookup(validationType)
 	if !ok {
-		return "", fmt.Errorf("input parameter is required and must be a string")
+		return "", toolresult.NewError("invalid_arguments",
+			fmt.Sprintf("unknown validation_type %q: must be one of (%s)", validationType, strings.Join(s.registry.Names(), ", ")), false)
 	}
 
-	validationType, ok := args["validation_type"].(string)
-	if !ok {
-		return "", fmt.Errorf("validation_type parameter is required and must be a string")
+	options := validation.Options{}
+	options.Schema, _ = args["schema"].(map[string]any)
+	if definition.RequiresSchema && options.Schema == nil {
+		return "", toolresult.NewError("invalid_arguments", fmt.Sprintf("%s needs a schema to validate against", validationType), false)
 	}
 
-	var isValid bool
-	var errorMsg string
-
-	switch validationType {
-	case "email":
-		emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-		isValid = emailRegex.MatchString(input)
-		if !isValid {
-			errorMsg = "Invalid email format"
-		}
+	input, single := args["input"].(string)
+	rawInputs, batch := args["inputs"].([]any)
+	if !single && !batch {
+		return "", toolresult.NewError("invalid_arguments", "either input or inputs is required", false)
+	}
 
-	case "url":
-		_, err := url.ParseRequestURI(input)
-		isValid = err == nil
-		if !isValid {
-			errorMsg = fmt.Sprintf("Invalid URL format: %v", err)
+	if !batch {
+		failures := runValidator(definition, input, options)
+		errorMsg := ""
+		if len(failures) > 0 {
+			errorMsg = failures[0].Reason
 		}
+		return marshalValidate(map[string]any{
+			"status":          "success",
+			"valid":           len(failures) == 0,
+			"validation_type": validationType,
+			"input":           input,
+			"error":           errorMsg,
+			"errors":          failures,
+		})
+	}
 
-	case "json":
-		var js json.RawMessage
-		err := json.Unmarshal([]byte(input), &js)
-		isValid = err == nil
-		if !isValid {
-			errorMsg = fmt.Sprintf("Invalid JSON: %v", err)
+	results := make([]validateResult, 0, len(rawInputs))
+	validCount := 0
+	for _, raw := range rawInputs {
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("validate canceled after %d of %d inputs: %w", len(results), len(rawInputs), err)
 		}
-
-	case "uuid":
-		_, err := uuid.Parse(input)
-		isValid = err == nil
-		if !isValid {
-			errorMsg = "Invalid UUID format"
+		input, _ := raw.(string)
+		failures := runValidator(definition, input, options)
+		if len(failures) == 0 {
+			validCount++
 		}
+		results = append(results, validateResult{Input: input, Valid: len(failures) == 0, Errors: failures})
+	}
 
-	case "phone":
-		phoneRegex := regexp.MustCompile(`^[\d\s\-\+\(\)]{10,}$`)
-		isValid = phoneRegex.MatchString(input)
-		if !isValid {
-			errorMsg = "Invalid phone number format"
-		}
+	return marshalValidate(map[string]any{
+		"status":          "success",
+		"validation_type": validationType,
+		"valid":           validCount == len(results),
+		"count":           len(results),
+		"valid_count":     validCount,
+		"invalid_count":   len(results) - validCount,
+		"results":         results,
+	})
+}
 
-	default:
-		return "", fmt.Errorf("unknown validation_type: must be one of (email, url, json, uuid, phone)")
+// runValidator runs a validator, never returning nil so results list errors as []
+func runValidator(definition validation.Definition, input string, options validation.Options) []validation.Failure {
+	failures := definition.Validate(input, options)
+	if failures == nil {
+		failures = []validation.Failure{}
 	}
+	return failures
+}
 
-	return fmt.Sprintf(`{"status": "success", "valid": %t, "validation_type": %q, "input": %q, "error": %q}`,
-		isValid, validationType, input, errorMsg), nil
+func marshalValidate(response map[string]any) (string, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
 }